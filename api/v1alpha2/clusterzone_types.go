@@ -15,6 +15,31 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ClusterZoneSpec defines the desired state of ClusterZone
+type ClusterZoneSpec struct {
+	ZoneSpec `json:",inline"`
+	// AllowedNamespaces restricts the namespaces whose RRsets may reference this ClusterZone.
+	// If not set, RRsets from all namespaces are allowed.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// AllowedNamespaces defines which namespaces may create RRsets in a ClusterZone, and under which names.
+// A namespace is allowed if it is listed in Names or matches Selector.
+// If neither Names nor Selector is set, all namespaces are allowed.
+type AllowedNamespaces struct {
+	// List of the allowed namespaces.
+	// +optional
+	Names []string `json:"names,omitempty"`
+	// Selector of the allowed namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// DNS suffix (e.g. "apps.example.org") the FQDN of RRsets from allowed namespaces must end with.
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-]+\.)*[a-zA-Z0-9-]+\.?$`
+	// +optional
+	NameSuffix *string `json:"nameSuffix,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -27,8 +52,8 @@ type ClusterZone struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   ZoneSpec   `json:"spec,omitempty"`
-	Status ZoneStatus `json:"status,omitempty"`
+	Spec   ClusterZoneSpec `json:"spec,omitempty"`
+	Status ZoneStatus      `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,119 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DKIMKeySpec defines the DKIM key of a mail domain and its rotation
+type DKIMKeySpec struct {
+	// ZoneRef references the Zone/ClusterZone the DKIM records are published in,
+	// the mail domain is the name of the zone.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Selector prefix of the keys, the selector of each key is "<selector>-<creation timestamp>"
+	// and its record is published as "<selector>-<creation timestamp>._domainkey.<zone>".
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Selector string `json:"selector"`
+	// Algorithm of the keys, one of "RSA", "Ed25519", defaults to "RSA".
+	// +kubebuilder:validation:Enum:=RSA;Ed25519
+	// +kubebuilder:default:="RSA"
+	// +optional
+	Algorithm *string `json:"algorithm,omitempty"`
+	// Size of the RSA keys in bits, one of 1024, 2048, 4096, defaults to 2048. Ignored for Ed25519 keys.
+	// +kubebuilder:validation:Enum:=1024;2048;4096
+	// +kubebuilder:default:=2048
+	// +optional
+	KeySize *int32 `json:"keySize,omitempty"`
+	// RotationPeriod after which a new key is generated (e.g. "2160h"), keys are never rotated if not set.
+	// +optional
+	RotationPeriod *metav1.Duration `json:"rotationPeriod,omitempty"`
+	// GracePeriod the record of a rotated key is kept published, defaults to "168h".
+	// +kubebuilder:default:="168h"
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// SecretName is the name of the Secret the private key of the current key is stored in, defaults to the name of the DKIMKey.
+	// +optional
+	SecretName *string `json:"secretName,omitempty"`
+	// DNS TTL of the DKIM records, in seconds.
+	// +kubebuilder:default:=3600
+	// +optional
+	TTL uint32 `json:"ttl,omitempty"`
+}
+
+// DKIMKeyPublication describes a key whose record is published
+type DKIMKeyPublication struct {
+	// Selector of the key
+	Selector string `json:"selector"`
+	// Algorithm of the key, one of "RSA", "Ed25519"
+	Algorithm string `json:"algorithm"`
+	// Size of the key in bits, RSA keys only
+	// +optional
+	KeySize int32 `json:"keySize,omitempty"`
+	// PublicKey is the "p=" tag of the DKIM record, the base64 encoded public key
+	PublicKey string `json:"publicKey"`
+	// CreationTime is the time the key was generated
+	CreationTime metav1.Time `json:"creationTime"`
+	// ExpirationTime is the time the record of a rotated key is unpublished, not set for the current and the next keys
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// DKIMKeyStatus defines the observed state of DKIMKey
+type DKIMKeyStatus struct {
+	// Selector of the current key, the one mails must be signed with.
+	// +optional
+	Selector *string `json:"selector,omitempty"`
+	// NextRotationTime is the time the next key is generated.
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+	// Keys whose record is published: the current key, the next key until it is activated
+	// and the rotated keys still in their grace period.
+	// +optional
+	Keys               []DKIMKeyPublication `json:"keys,omitempty"`
+	SyncStatus         *string              `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition   `json:"conditions,omitempty"`
+	ObservedGeneration *int64               `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Selector",type="string",JSONPath=".status.selector"
+// +kubebuilder:printcolumn:name="Next Rotation",type="date",JSONPath=".status.nextRotationTime"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// DKIMKey is the Schema for the dkimkeys API.
+// It generates the DKIM keys of a mail domain, stores the private key in a Secret
+// and publishes the public keys as RRsets.
+type DKIMKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DKIMKeySpec   `json:"spec,omitempty"`
+	Status DKIMKeyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DKIMKeyList contains a list of DKIMKey
+type DKIMKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DKIMKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DKIMKey{}, &DKIMKeyList{})
+}
@@ -13,6 +13,8 @@
 package v1alpha2
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -91,3 +93,13 @@ func (c *ClusterRRset) SetStatus(status RRsetStatus) {
 func (c *ClusterRRset) Copy() GenericRRset {
 	return c.DeepCopy()
 }
+
+// RRsetClaimKey returns the key of a RRset/ClusterRRset in the claim indexes, its canonical DNS name and its type:
+// the RRsets/ClusterRRsets with the same key declare the same DNS entry
+func RRsetClaimKey(gr GenericRRset) string {
+	name := gr.GetSpec().Name
+	if !strings.HasSuffix(name, ".") {
+		name = name + "." + gr.GetSpec().ZoneRef.Name
+	}
+	return strings.TrimSuffix(name, ".") + "./" + gr.GetSpec().Type
+}
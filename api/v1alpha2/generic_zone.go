@@ -13,8 +13,18 @@
 package v1alpha2
 
 import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+// Reasons of the Available condition of a Zone/ClusterZone denied by the operator
+const (
+	ZoneReasonDuplicated   = "ZoneDuplicated"
+	ZoneReasonForeignOwner = "ForeignOwner"
 )
 
 // +kubebuilder:object:root=false
@@ -78,7 +88,7 @@ func (c *ClusterZone) GetTypeMeta() *metav1.TypeMeta {
 }
 
 func (c *ClusterZone) GetSpec() *ZoneSpec {
-	return &c.Spec
+	return &c.Spec.ZoneSpec
 }
 
 func (c *ClusterZone) GetStatus() ZoneStatus {
@@ -92,3 +102,13 @@ func (c *ClusterZone) SetStatus(status ZoneStatus) {
 func (c *ClusterZone) Copy() GenericZone {
 	return c.DeepCopy()
 }
+
+// ZoneCatalogKey returns the key of a Zone/ClusterZone in the catalog indexes, the name of the catalog it is a member of
+// without trailing dot: a zone denied as a duplicate or owned by another instance is not a member
+func ZoneCatalogKey(gz GenericZone) string {
+	if condition := meta.FindStatusCondition(gz.GetStatus().Conditions, "Available"); condition != nil &&
+		(condition.Reason == ZoneReasonDuplicated || condition.Reason == ZoneReasonForeignOwner) {
+		return ""
+	}
+	return strings.TrimSuffix(ptr.Deref(gz.GetSpec().Catalog, ""), ".")
+}
@@ -0,0 +1,164 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MailPolicySpec defines the mail policies of a mail domain, published as TXT records
+// +kubebuilder:validation:XValidation:rule="has(self.spf) || has(self.dmarc) || has(self.mtaSts) || has(self.tlsRpt)",message="at least one of spf, dmarc, mtaSts and tlsRpt must be set"
+type MailPolicySpec struct {
+	// ZoneRef references the Zone/ClusterZone the records are published in.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Domain is the mail domain, relative to the zone (e.g. "newsletter"), defaults to the zone itself.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]([a-z0-9_-]*[a-z0-9])?(\.[a-z0-9_]([a-z0-9_-]*[a-z0-9])?)*$`
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+	// SPF policy, published at the mail domain.
+	// +optional
+	SPF *SPF `json:"spf,omitempty"`
+	// DMARC policy, published at "_dmarc.<domain>".
+	// +optional
+	DMARC *DMARC `json:"dmarc,omitempty"`
+	// MTA-STS policy, published at "_mta-sts.<domain>".
+	// +optional
+	MTASTS *MTASTS `json:"mtaSts,omitempty"`
+	// TLS-RPT policy, published at "_smtp._tls.<domain>".
+	// +optional
+	TLSRPT *TLSRPT `json:"tlsRpt,omitempty"`
+	// DNS TTL of the records, in seconds.
+	// +kubebuilder:default:=3600
+	// +optional
+	TTL uint32 `json:"ttl,omitempty"`
+}
+
+// SPF describes the hosts allowed to send mails for the domain (RFC 7208)
+// +kubebuilder:validation:XValidation:rule="!has(self.mechanisms) || self.mechanisms.filter(m, m.type in ['a', 'mx', 'include', 'exists']).size() <= 10",message="SPF allows at most 10 mechanisms requiring a DNS lookup (a, mx, include, exists)"
+type SPF struct {
+	// Mechanisms matching the allowed hosts, evaluated in order.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Mechanisms []SPFMechanism `json:"mechanisms,omitempty"`
+	// All is the result for the hosts matching no mechanism, one of "Fail", "SoftFail", "Neutral", defaults to "SoftFail".
+	// +kubebuilder:validation:Enum:=Fail;SoftFail;Neutral
+	// +kubebuilder:default:="SoftFail"
+	// +optional
+	All *string `json:"all,omitempty"`
+}
+
+// SPFMechanism is a mechanism of a SPF policy
+// +kubebuilder:validation:XValidation:rule="!(self.type in ['ip4', 'ip6', 'include', 'exists']) || has(self.value)",message="value is required for ip4, ip6, include and exists mechanisms"
+type SPFMechanism struct {
+	// Type of the mechanism, one of "a", "mx", "ip4", "ip6", "include", "exists".
+	// +kubebuilder:validation:Enum:=a;mx;ip4;ip6;include;exists
+	Type string `json:"type"`
+	// Value of the mechanism: an address or network for "ip4"/"ip6", a domain for "include"/"exists",
+	// an optional domain and/or prefix length for "a"/"mx" (e.g. "example.org", "example.org/24", "/24").
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.:/%{}+=-]+$`
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// Qualifier is the result for the hosts matching the mechanism, one of "Pass", "Fail", "SoftFail", "Neutral", defaults to "Pass".
+	// +kubebuilder:validation:Enum:=Pass;Fail;SoftFail;Neutral
+	// +optional
+	Qualifier *string `json:"qualifier,omitempty"`
+}
+
+// DMARC describes the handling of the mails failing SPF and DKIM checks (RFC 7489)
+type DMARC struct {
+	// Policy applied to the mails of the domain, one of "none", "quarantine", "reject".
+	// +kubebuilder:validation:Enum:=none;quarantine;reject
+	Policy string `json:"policy"`
+	// SubdomainPolicy applied to the mails of the subdomains, defaults to the policy of the domain.
+	// +kubebuilder:validation:Enum:=none;quarantine;reject
+	// +optional
+	SubdomainPolicy *string `json:"subdomainPolicy,omitempty"`
+	// Percentage of the mails the policy is applied to.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+	// AggregateReports lists the addresses aggregate reports are sent to ("rua").
+	// +optional
+	AggregateReports []MailAddress `json:"aggregateReports,omitempty"`
+	// ForensicReports lists the addresses failure reports are sent to ("ruf").
+	// +optional
+	ForensicReports []MailAddress `json:"forensicReports,omitempty"`
+	// DKIMAlignment mode, one of "relaxed", "strict", defaults to "relaxed".
+	// +kubebuilder:validation:Enum:=relaxed;strict
+	// +optional
+	DKIMAlignment *string `json:"dkimAlignment,omitempty"`
+	// SPFAlignment mode, one of "relaxed", "strict", defaults to "relaxed".
+	// +kubebuilder:validation:Enum:=relaxed;strict
+	// +optional
+	SPFAlignment *string `json:"spfAlignment,omitempty"`
+}
+
+// MailAddress is an email address reports are sent to (e.g. "dmarc@example.org")
+// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+$`
+type MailAddress string
+
+// MTASTS announces the MTA-STS policy of the domain (RFC 8461).
+// The policy itself must be served at "https://mta-sts.<domain>/.well-known/mta-sts.txt".
+type MTASTS struct {
+	// ID of the policy, must be changed each time the policy is updated.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]{1,32}$`
+	ID string `json:"id"`
+}
+
+// TLSRPT describes where the reports of TLS failures are sent (RFC 8460)
+type TLSRPT struct {
+	// Reports lists the email addresses and HTTPS endpoints the reports are sent to.
+	// +kubebuilder:validation:MinItems=1
+	Reports []TLSRPTDestination `json:"reports"`
+}
+
+// TLSRPTDestination is an email address (e.g. "tls-rpt@example.org") or an HTTPS endpoint (e.g. "https://reports.example.org/tls")
+// +kubebuilder:validation:Pattern=`^([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+|https://[^\s,;!"]+)$`
+type TLSRPTDestination string
+
+// MailPolicyStatus defines the observed state of MailPolicy
+type MailPolicyStatus struct {
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Domain",type="string",JSONPath=".spec.domain"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// MailPolicy is the Schema for the mailpolicies API.
+// It renders the SPF, DMARC, MTA-STS and TLS-RPT policies of a mail domain into TXT RRsets.
+type MailPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MailPolicySpec   `json:"spec,omitempty"`
+	Status MailPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MailPolicyList contains a list of MailPolicy
+type MailPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MailPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MailPolicy{}, &MailPolicyList{})
+}
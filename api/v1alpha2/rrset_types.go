@@ -16,6 +16,10 @@ import (
 )
 
 // RRsetSpec defines the desired state of RRset
+// +kubebuilder:validation:XValidation:rule="!has(self.managePTR) || !self.managePTR || self.type == 'A' || self.type == 'AAAA'",message="managePTR can only be set on A and AAAA records"
+// +kubebuilder:validation:XValidation:rule="has(self.lua) != (has(self.records) && size(self.records) > 0)",message="exactly one of records and lua must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.lua) || self.type == 'LUA'",message="lua can only be set on LUA records"
+// +kubebuilder:validation:XValidation:rule="!has(self.healthCheck) || self.type == 'A' || self.type == 'AAAA'",message="healthCheck can only be set on A and AAAA records"
 type RRsetSpec struct {
 	// Type of the record (e.g. "A", "PTR", "MX").
 	Type string `json:"type"`
@@ -25,20 +29,155 @@ type RRsetSpec struct {
 	// DNS TTL of the records, in seconds.
 	TTL uint32 `json:"ttl"`
 	// All records in this Resource Record Set.
-	Records []string `json:"records"`
+	// +optional
+	Records []string `json:"records,omitempty"`
+	// Lua builds the record of a LUA RRSet from a common LUA function, instead of Records.
+	// +optional
+	Lua *LuaRecord `json:"lua,omitempty"`
 	// Comment on RRSet.
 	// +optional
 	Comment *string `json:"comment,omitempty"`
 	// ZoneRef reference the zone the RRSet depends on.
 	ZoneRef ZoneRef `json:"zoneRef"`
+	// ManagePTR generates a PTR record for each address of an A/AAAA RRSet,
+	// in the matching in-addr.arpa/ip6.arpa Zone or ClusterZone.
+	// PTR records are deleted along with the RRSet.
+	// +optional
+	ManagePTR *bool `json:"managePTR,omitempty"`
+	// TakeOver allows the RRSet to replace a RRSet with the same name and type
+	// existing in PowerDNS and not created by the operator.
+	// A RRSet owned by another operator instance is never replaced.
+	// +optional
+	TakeOver *bool `json:"takeOver,omitempty"`
+	// HealthCheck is run by the operator on each address of an A/AAAA RRSet,
+	// only the records of the healthy addresses are published.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// HealthCheck describes how the addresses of a RRSet are checked
+// +kubebuilder:validation:XValidation:rule="self.type != 'TCP' || (!has(self.path) && !has(self.host) && !has(self.expectedStatuses) && !has(self.insecureSkipVerify))",message="path, host, expectedStatuses and insecureSkipVerify can only be set on HTTP and HTTPS health checks"
+type HealthCheck struct {
+	// Type of the health check: TCP checks the port accepts connections,
+	// HTTP and HTTPS check a GET request on the port returns an expected status.
+	// +kubebuilder:validation:Enum:=TCP;HTTP;HTTPS
+	Type string `json:"type"`
+	// Port to check.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Path of the HTTP request, defaults to "/".
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path *string `json:"path,omitempty"`
+	// Host is sent as the Host header of the HTTP request and as the server name of the TLS handshake.
+	// +optional
+	Host *string `json:"host,omitempty"`
+	// ExpectedStatuses are the HTTP statuses of a healthy address, defaults to any 2xx or 3xx status.
+	// Redirections are not followed.
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	// +optional
+	ExpectedStatuses []int32 `json:"expectedStatuses,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificate of HTTPS health checks.
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+	// Interval between two checks of an address.
+	// +kubebuilder:default:="10s"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="interval must be at least 1s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Timeout of a check.
+	// +kubebuilder:default:="3s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// HealthyThreshold is the number of consecutive successful checks for an unhealthy address to become healthy.
+	// +kubebuilder:default:=2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthyThreshold int32 `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is the number of consecutive failed checks for a healthy address to become unhealthy.
+	// +kubebuilder:default:=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThreshold int32 `json:"unhealthyThreshold,omitempty"`
+	// MinHealthy is the minimum number of published records: when fewer addresses are healthy,
+	// all the records are published, as withdrawing them would not help.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinHealthy int32 `json:"minHealthy,omitempty"`
+}
+
+// LuaRecord describes the LUA record built for a RRSet.
+// Exactly one function must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.ifportup), has(self.ifurlup), has(self.pickrandom), has(self.pickwrandom), has(self.snippet)].filter(f, f).size() == 1",message="exactly one of ifportup, ifurlup, pickrandom, pickwrandom and snippet must be set"
+type LuaRecord struct {
+	// Type of the records returned by the LUA function.
+	// The ifportup, ifurlup, pickrandom and pickwrandom functions only return A or AAAA records.
+	// +kubebuilder:validation:Enum:=A;AAAA;CNAME;TXT;MX;SRV;PTR
+	Type string `json:"type"`
+	// IfPortUp returns the addresses on which a TCP port is up.
+	// +optional
+	IfPortUp *LuaIfPortUp `json:"ifportup,omitempty"`
+	// IfURLUp returns the addresses on which an URL is up.
+	// +optional
+	IfURLUp *LuaIfURLUp `json:"ifurlup,omitempty"`
+	// PickRandom returns one random address.
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	PickRandom []string `json:"pickrandom,omitempty"`
+	// PickWRandom returns one random address, according to its weight.
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	PickWRandom []LuaWeightedAddress `json:"pickwrandom,omitempty"`
+	// Snippet is a raw LUA snippet, for the functions not covered by the other fields.
+	// Double quotes and backslashes are escaped by the operator.
+	// +optional
+	Snippet *string `json:"snippet,omitempty"`
+}
+
+// LuaIfPortUp describes an ifportup LUA function
+type LuaIfPortUp struct {
+	// Port to check.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Addresses to check.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
 }
 
+// LuaIfURLUp describes an ifurlup LUA function
+type LuaIfURLUp struct {
+	// URL to check, on each address.
+	URL string `json:"url"`
+	// Addresses to check.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
+}
+
+// LuaWeightedAddress is an address with its weight
+type LuaWeightedAddress struct {
+	// Weight of the address.
+	// +kubebuilder:validation:Minimum=0
+	Weight int32 `json:"weight"`
+	// Address returned.
+	Address string `json:"address"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.__namespace__) || self.kind == 'Zone'",message="namespace can only be set when kind is Zone"
 type ZoneRef struct {
 	// Name of the zone.
 	Name string `json:"name"`
 	// Kind of the Zone resource (Zone or ClusterZone)
 	// +kubebuilder:validation:Enum:=Zone;ClusterZone
 	Kind string `json:"kind"`
+	// Namespace of the Zone, defaults to the namespace of the RRset.
+	// Referencing a Zone from another namespace requires a ZoneReferenceGrant in that namespace.
+	// Only supported by RRsets referencing a Zone.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
 }
 
 // RRsetStatus defines the observed state of RRset
@@ -48,6 +187,49 @@ type RRsetStatus struct {
 	SyncStatus         *string            `json:"syncStatus,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+	// PTRRecords are the PTR records generated for the addresses of the RRSet
+	PTRRecords []PTRRecordStatus `json:"ptrRecords,omitempty"`
+	// ObservedRecords are the records of the RRSet in PowerDNS, for RRsets referencing an observed zone
+	ObservedRecords []string `json:"observedRecords,omitempty"`
+	// HealthChecks is the health of the addresses of a RRSet with a health check
+	HealthChecks []TargetHealth `json:"healthChecks,omitempty"`
+}
+
+// TargetHealth describes the health of an address of a RRSet
+type TargetHealth struct {
+	// Address checked
+	Address string `json:"address"`
+	// Healthy is true if the address is healthy, addresses are healthy until their first failed checks
+	Healthy bool `json:"healthy"`
+	// Published is true if the record of the address is published: it is healthy,
+	// or too few addresses are healthy
+	Published bool `json:"published"`
+	// ConsecutiveSuccesses is the number of consecutive successful checks, up to the healthy threshold
+	// +optional
+	ConsecutiveSuccesses int32 `json:"consecutiveSuccesses,omitempty"`
+	// ConsecutiveFailures is the number of consecutive failed checks, up to the unhealthy threshold
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// LastTransitionTime is the last time the address became healthy or unhealthy
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message explains the last failed check
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// PTRRecordStatus describes the PTR record generated for an address
+type PTRRecordStatus struct {
+	// Address the PTR record is generated for
+	Address string `json:"address"`
+	// Name of the PTR record
+	Name string `json:"name"`
+	// Zone the PTR record belongs to
+	Zone string `json:"zone"`
+	// Conflict explains why the PTR record is not managed by the RRSet,
+	// e.g. it already points to another name
+	// +optional
+	Conflict *string `json:"conflict,omitempty"`
 }
 
 //+kubebuilder:object:root=true
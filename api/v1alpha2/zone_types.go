@@ -16,6 +16,9 @@ import (
 )
 
 // ZoneSpec defines the desired state of Zone
+// +kubebuilder:validation:XValidation:rule="!has(self.soa) || !(self.kind in ['Slave', 'Consumer'])",message="soa cannot be set on Slave and Consumer zones"
+// +kubebuilder:validation:XValidation:rule="!has(self.recordsPolicy) || self.recordsPolicy != 'Authoritative' || !(self.kind in ['Slave', 'Consumer'])",message="recordsPolicy Authoritative cannot be set on Slave and Consumer zones"
+// +kubebuilder:validation:XValidation:rule="!has(self.managementPolicy) || self.managementPolicy != 'Observe' || !has(self.recordsPolicy) || self.recordsPolicy != 'Authoritative'",message="recordsPolicy Authoritative cannot be set on observed zones"
 type ZoneSpec struct {
 	// Kind of the zone, one of "Native", "Master", "Slave", "Producer", "Consumer".
 	// +kubebuilder:validation:Enum:=Native;Master;Slave;Producer;Consumer
@@ -24,6 +27,10 @@ type ZoneSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:items:Pattern=`^([a-zA-Z0-9-]+\.)*[a-zA-Z0-9-]+$`
 	Nameservers []string `json:"nameservers"`
+	// DNS TTL of the NS records of the zone, in seconds.
+	// If not set, the TTL set by PowerDNS is kept.
+	// +optional
+	NameserversTTL *uint32 `json:"nameserversTTL,omitempty"`
 	// The catalog this zone is a member of
 	// +optional
 	Catalog *string `json:"catalog,omitempty"`
@@ -32,6 +39,106 @@ type ZoneSpec struct {
 	// +kubebuilder:default:="DEFAULT"
 	// +optional
 	SOAEditAPI *string `json:"soa_edit_api,omitempty"`
+	// The fields of the SOA record of the zone, the fields not set keep the value defined by PowerDNS.
+	// The serial is not part of it, it is managed by PowerDNS according to SOA-EDIT-API.
+	// +optional
+	SOA *SOA `json:"soa,omitempty"`
+	// TemplateRef references the ZoneTemplate whose RRsets are created in the zone
+	// +optional
+	TemplateRef *TemplateRef `json:"templateRef,omitempty"`
+	// The policy applied to the RRSets of the zone in PowerDNS, one of "Additive", "Authoritative", defaults to "Additive".
+	// With "Additive", the RRSets not declared in Kubernetes are left untouched.
+	// With "Authoritative", the RRSets not declared by a RRset, a ClusterRRset or the zone itself are deleted.
+	// +kubebuilder:validation:Enum:=Additive;Authoritative
+	// +kubebuilder:default:="Additive"
+	// +optional
+	RecordsPolicy *string `json:"recordsPolicy,omitempty"`
+	// Prune configures the deletion of the RRSets not declared in Kubernetes, with the "Authoritative" records policy
+	// +optional
+	Prune *Prune `json:"prune,omitempty"`
+	// The management policy of the zone, one of "Manage", "Observe", defaults to "Manage".
+	// An observed zone, and the RRSets referencing it, are only read from PowerDNS: nothing is ever created, updated or deleted.
+	// +kubebuilder:validation:Enum:=Manage;Observe
+	// +kubebuilder:default:="Manage"
+	// +optional
+	ManagementPolicy *string `json:"managementPolicy,omitempty"`
+}
+
+// Prune configures the deletion of the RRSets of a zone not declared in Kubernetes
+type Prune struct {
+	// ReportOnly lists the RRSets not declared in Kubernetes in the status of the zone, instead of deleting them
+	// +optional
+	ReportOnly bool `json:"reportOnly,omitempty"`
+	// Allowlist of the RRSets never deleted, even if not declared in Kubernetes
+	// +optional
+	Allowlist []PruneAllowlistEntry `json:"allowlist,omitempty"`
+}
+
+// PruneAllowlistEntry selects RRSets of a zone which are never deleted
+type PruneAllowlistEntry struct {
+	// Pattern (e.g. "*.legacy.example.org") the FQDN of the RRSets must match.
+	// The '*' wildcard matches any sequence of characters, dots included.
+	Name string `json:"name"`
+	// Type of the RRSets (e.g. "TXT"), all types if not set
+	// +optional
+	Type *string `json:"type,omitempty"`
+}
+
+// SOA defines the fields of the SOA record of a zone
+type SOA struct {
+	// Primary nameserver of the zone (MNAME), e.g. "ns1.example.org".
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-]+\.)*[a-zA-Z0-9-]+\.?$`
+	// +optional
+	Primary *string `json:"primary,omitempty"`
+	// Mailbox of the person responsible for the zone (RNAME),
+	// either as an e-mail address ("hostmaster@example.org") or in the SOA format ("hostmaster.example.org").
+	// +optional
+	Contact *string `json:"contact,omitempty"`
+	// Interval, in seconds, before the secondaries refresh the zone.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Refresh *uint32 `json:"refresh,omitempty"`
+	// Interval, in seconds, before the secondaries retry a failed refresh.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retry *uint32 `json:"retry,omitempty"`
+	// Duration, in seconds, after which the secondaries stop answering for the zone if it cannot be refreshed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Expire *uint32 `json:"expire,omitempty"`
+	// TTL, in seconds, of negative answers (NXDOMAIN, NODATA).
+	// +optional
+	Minimum *uint32 `json:"minimum,omitempty"`
+	// DNS TTL of the SOA record, in seconds.
+	// +optional
+	TTL *uint32 `json:"ttl,omitempty"`
+}
+
+// DelegationStatus defines the records delegating a zone from its parent zone
+type DelegationStatus struct {
+	// Parent is the name of the zone holding the NS and glue records of the delegation (e.g. "example.com.")
+	Parent string `json:"parent"`
+	// Glue are the names of the nameservers whose glue records are written in the parent zone
+	// +optional
+	Glue []string `json:"glue,omitempty"`
+}
+
+// SOAStatus defines the observed fields of the SOA record of a zone, the serial is reported in the zone status
+type SOAStatus struct {
+	// Primary nameserver of the zone (MNAME).
+	Primary string `json:"primary"`
+	// Mailbox of the person responsible for the zone (RNAME), in the SOA format.
+	Contact string `json:"contact"`
+	// Interval, in seconds, before the secondaries refresh the zone.
+	Refresh uint32 `json:"refresh"`
+	// Interval, in seconds, before the secondaries retry a failed refresh.
+	Retry uint32 `json:"retry"`
+	// Duration, in seconds, after which the secondaries stop answering for the zone if it cannot be refreshed.
+	Expire uint32 `json:"expire"`
+	// TTL, in seconds, of negative answers.
+	Minimum uint32 `json:"minimum"`
+	// DNS TTL of the SOA record, in seconds.
+	TTL uint32 `json:"ttl"`
 }
 
 // ZoneStatus defines the observed state of Zone
@@ -62,7 +169,23 @@ type ZoneStatus struct {
 	DNSsec *bool `json:"dnssec,omitempty"`
 	// The catalog this zone is a member of.
 	// +optional
-	Catalog            *string            `json:"catalog,omitempty"`
+	Catalog *string `json:"catalog,omitempty"`
+	// The member zones of the catalog ("Producer" type zones only).
+	// +optional
+	Members []string `json:"members,omitempty"`
+	// The number of records of the zone.
+	// +optional
+	RecordsCount *int32 `json:"recordsCount,omitempty"`
+	// The SOA record of the zone.
+	// +optional
+	SOA *SOAStatus `json:"soa,omitempty"`
+	// The delegation of the zone from its closest parent zone managed by the operator.
+	// +optional
+	Delegation *DelegationStatus `json:"delegation,omitempty"`
+	// The RRSets of PowerDNS not declared in Kubernetes, as "<name> <type>",
+	// reported instead of being deleted with the "Authoritative" records policy in report only mode.
+	// +optional
+	UnmanagedRRsets    []string           `json:"unmanagedRRsets,omitempty"`
 	SyncStatus         *string            `json:"syncStatus,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
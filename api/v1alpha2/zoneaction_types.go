@@ -0,0 +1,75 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneActionSpec defines the action to run once on a zone
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type ZoneActionSpec struct {
+	// ZoneRef references the Zone/ClusterZone the action is run on.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Action to run, one of "Notify", "Rectify", "AxfrRetrieve", "FlushCache".
+	// +kubebuilder:validation:Enum:=Notify;Rectify;AxfrRetrieve;FlushCache
+	Action string `json:"action"`
+	// TTLSecondsAfterFinished limits the lifetime of a ZoneAction which has finished.
+	// The ZoneAction is deleted that many seconds after its completion. If not set, it is never deleted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ZoneActionStatus defines the outcome of the action
+type ZoneActionStatus struct {
+	// Result is the response of the PowerDNS API, or the error it returned.
+	// +optional
+	Result *string `json:"result,omitempty"`
+	// CompletionTime is the time the action finished, successfully or not.
+	// +optional
+	CompletionTime     *metav1.Time       `json:"completionTime,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="Completion",type="date",JSONPath=".status.completionTime"
+// ZoneAction is the Schema for the zoneactions API.
+// It runs a PowerDNS operational action once on a zone, in the style of a Job.
+type ZoneAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ZoneActionSpec   `json:"spec,omitempty"`
+	Status ZoneActionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneActionList contains a list of ZoneAction
+type ZoneActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneAction{}, &ZoneActionList{})
+}
@@ -0,0 +1,71 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZonePolicySpec defines which DNS names the selected namespaces may manage
+type ZonePolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to.
+	// An empty selector matches all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// List of DNS suffixes (e.g. "apps.example.org") Zones may be created for.
+	// A Zone is allowed if its name is equal to, or a subdomain of, one of the suffixes.
+	// If empty, no restriction applies.
+	// +kubebuilder:validation:items:Pattern=`^([a-zA-Z0-9-]+\.)*[a-zA-Z0-9-]+\.?$`
+	// +optional
+	AllowedZoneSuffixes []string `json:"allowedZoneSuffixes,omitempty"`
+	// List of patterns (e.g. "*.myapp.example.org") the FQDN of RRsets referencing a ClusterZone must match.
+	// The '*' wildcard matches any sequence of characters, dots included.
+	// If empty, no restriction applies.
+	// +optional
+	AllowedRecordPatterns []string `json:"allowedRecordPatterns,omitempty"`
+	// List of record types (e.g. "A", "CNAME") RRsets may use.
+	// If empty, no restriction applies.
+	// +optional
+	AllowedTypes []string `json:"allowedTypes,omitempty"`
+	// Minimum DNS TTL of RRsets, in seconds.
+	// +optional
+	MinTTL *uint32 `json:"minTTL,omitempty"`
+	// Maximum DNS TTL of RRsets, in seconds.
+	// +optional
+	MaxTTL *uint32 `json:"maxTTL,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// +kubebuilder:printcolumn:name="Zone Suffixes",type="string",JSONPath=".spec.allowedZoneSuffixes"
+// +kubebuilder:printcolumn:name="Types",type="string",JSONPath=".spec.allowedTypes"
+// ZonePolicy is the Schema for the zonepolicies API
+type ZonePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZonePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZonePolicyList contains a list of ZonePolicy
+type ZonePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZonePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZonePolicy{}, &ZonePolicyList{})
+}
@@ -0,0 +1,65 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneReferenceGrantSpec defines which namespaces may reference the Zones of the grant namespace
+type ZoneReferenceGrantSpec struct {
+	// From lists the namespaces whose RRsets may reference the Zones listed in To.
+	// +kubebuilder:validation:MinItems=1
+	From []ZoneReferenceGrantFrom `json:"from"`
+	// To lists the Zones, in the namespace of the grant, which may be referenced.
+	// +kubebuilder:validation:MinItems=1
+	To []ZoneReferenceGrantTo `json:"to"`
+}
+
+// ZoneReferenceGrantFrom describes a namespace allowed to reference Zones
+type ZoneReferenceGrantFrom struct {
+	// Namespace of the RRsets.
+	Namespace string `json:"namespace"`
+}
+
+// ZoneReferenceGrantTo describes the Zones which may be referenced
+type ZoneReferenceGrantTo struct {
+	// Name of the Zone. If not set, all Zones of the namespace may be referenced.
+	// +optional
+	Name *string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+
+// ZoneReferenceGrant is the Schema for the zonereferencegrants API.
+// It authorizes RRsets from other namespaces to reference Zones of its namespace,
+// in the style of the Gateway API ReferenceGrant.
+type ZoneReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZoneReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneReferenceGrantList contains a list of ZoneReferenceGrant
+type ZoneReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneReferenceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneReferenceGrant{}, &ZoneReferenceGrantList{})
+}
@@ -0,0 +1,86 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneTemplateSpec defines the default SOA record, NS TTL and RRsets of the zones using the template
+type ZoneTemplateSpec struct {
+	// Default SOA record of the zones using the template,
+	// each field is only used if the zone does not set it in its own SOA.
+	// +optional
+	SOA *SOA `json:"soa,omitempty"`
+	// Default DNS TTL of the NS records, in seconds, of the zones using the template which do not set one.
+	// +optional
+	NameserversTTL *uint32 `json:"nameserversTTL,omitempty"`
+	// List of the RRsets rendered in each zone using the template.
+	// The "{zone}" placeholder is replaced by the name of the zone (e.g. "example.org")
+	// in the name, records and comment of the RRsets.
+	// +listType=map
+	// +listMapKey=id
+	// +optional
+	RRsets []ZoneTemplateRRset `json:"rrsets,omitempty"`
+}
+
+// ZoneTemplateRRset describes a RRset rendered from a ZoneTemplate
+type ZoneTemplateRRset struct {
+	// Identifier of the RRset in the template,
+	// the RRset rendered for a zone is named "<zone>-<id>".
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	ID string `json:"id"`
+	// Type of the record (e.g. "MX", "TXT", "CAA").
+	Type string `json:"type"`
+	// Name of the record, relative to the zone or absolute if ending with a dot (e.g. "_dmarc", "{zone}.").
+	Name string `json:"name"`
+	// DNS TTL of the records, in seconds.
+	TTL uint32 `json:"ttl"`
+	// All records in this Resource Record Set.
+	// +kubebuilder:validation:MinItems=1
+	Records []string `json:"records"`
+	// Comment on RRSet.
+	// +optional
+	Comment *string `json:"comment,omitempty"`
+}
+
+// TemplateRef references the ZoneTemplate of a zone
+type TemplateRef struct {
+	// Name of the ZoneTemplate.
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// +kubebuilder:printcolumn:name="RRsets",type="string",JSONPath=".spec.rrsets[*].id"
+// ZoneTemplate is the Schema for the zonetemplates API
+type ZoneTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ZoneTemplateSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneTemplateList contains a list of ZoneTemplate
+type ZoneTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneTemplate{}, &ZoneTemplateList{})
+}
@@ -20,6 +20,36 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NameSuffix != nil {
+		in, out := &in.NameSuffix, &out.NameSuffix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRRset) DeepCopyInto(out *ClusterRRset) {
 	*out = *in
@@ -139,7 +169,28 @@ func (in *ClusterZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRset) DeepCopyInto(out *RRset) {
+func (in *ClusterZoneSpec) DeepCopyInto(out *ClusterZoneSpec) {
+	*out = *in
+	in.ZoneSpec.DeepCopyInto(&out.ZoneSpec)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterZoneSpec.
+func (in *ClusterZoneSpec) DeepCopy() *ClusterZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DKIMKey) DeepCopyInto(out *DKIMKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -147,18 +198,18 @@ func (in *RRset) DeepCopyInto(out *RRset) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRset.
-func (in *RRset) DeepCopy() *RRset {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMKey.
+func (in *DKIMKey) DeepCopy() *DKIMKey {
 	if in == nil {
 		return nil
 	}
-	out := new(RRset)
+	out := new(DKIMKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RRset) DeepCopyObject() runtime.Object {
+func (in *DKIMKey) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -166,31 +217,31 @@ func (in *RRset) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetList) DeepCopyInto(out *RRsetList) {
+func (in *DKIMKeyList) DeepCopyInto(out *DKIMKeyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RRset, len(*in))
+		*out = make([]DKIMKey, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetList.
-func (in *RRsetList) DeepCopy() *RRsetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMKeyList.
+func (in *DKIMKeyList) DeepCopy() *DKIMKeyList {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetList)
+	out := new(DKIMKeyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RRsetList) DeepCopyObject() runtime.Object {
+func (in *DKIMKeyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -198,43 +249,85 @@ func (in *RRsetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetSpec) DeepCopyInto(out *RRsetSpec) {
+func (in *DKIMKeyPublication) DeepCopyInto(out *DKIMKeyPublication) {
 	*out = *in
-	if in.Records != nil {
-		in, out := &in.Records, &out.Records
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Comment != nil {
-		in, out := &in.Comment, &out.Comment
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMKeyPublication.
+func (in *DKIMKeyPublication) DeepCopy() *DKIMKeyPublication {
+	if in == nil {
+		return nil
+	}
+	out := new(DKIMKeyPublication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DKIMKeySpec) DeepCopyInto(out *DKIMKeySpec) {
+	*out = *in
+	in.ZoneRef.DeepCopyInto(&out.ZoneRef)
+	if in.Algorithm != nil {
+		in, out := &in.Algorithm, &out.Algorithm
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeySize != nil {
+		in, out := &in.KeySize, &out.KeySize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RotationPeriod != nil {
+		in, out := &in.RotationPeriod, &out.RotationPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
 		*out = new(string)
 		**out = **in
 	}
-	out.ZoneRef = in.ZoneRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetSpec.
-func (in *RRsetSpec) DeepCopy() *RRsetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMKeySpec.
+func (in *DKIMKeySpec) DeepCopy() *DKIMKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetSpec)
+	out := new(DKIMKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
+func (in *DKIMKeyStatus) DeepCopyInto(out *DKIMKeyStatus) {
 	*out = *in
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
-	}
-	if in.DnsEntryName != nil {
-		in, out := &in.DnsEntryName, &out.DnsEntryName
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
 		*out = new(string)
 		**out = **in
 	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]DKIMKeyPublication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SyncStatus != nil {
 		in, out := &in.SyncStatus, &out.SyncStatus
 		*out = new(string)
@@ -254,193 +347,1467 @@ func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetStatus.
-func (in *RRsetStatus) DeepCopy() *RRsetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMKeyStatus.
+func (in *DKIMKeyStatus) DeepCopy() *DKIMKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetStatus)
+	out := new(DKIMKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Zone) DeepCopyInto(out *Zone) {
+func (in *DMARC) DeepCopyInto(out *DMARC) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.SubdomainPolicy != nil {
+		in, out := &in.SubdomainPolicy, &out.SubdomainPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AggregateReports != nil {
+		in, out := &in.AggregateReports, &out.AggregateReports
+		*out = make([]MailAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForensicReports != nil {
+		in, out := &in.ForensicReports, &out.ForensicReports
+		*out = make([]MailAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.DKIMAlignment != nil {
+		in, out := &in.DKIMAlignment, &out.DKIMAlignment
+		*out = new(string)
+		**out = **in
+	}
+	if in.SPFAlignment != nil {
+		in, out := &in.SPFAlignment, &out.SPFAlignment
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
-func (in *Zone) DeepCopy() *Zone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMARC.
+func (in *DMARC) DeepCopy() *DMARC {
 	if in == nil {
 		return nil
 	}
-	out := new(Zone)
+	out := new(DMARC)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Zone) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneList) DeepCopyInto(out *ZoneList) {
+func (in *DelegationStatus) DeepCopyInto(out *DelegationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Zone, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Glue != nil {
+		in, out := &in.Glue, &out.Glue
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneList.
-func (in *ZoneList) DeepCopy() *ZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegationStatus.
+func (in *DelegationStatus) DeepCopy() *DelegationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneList)
+	out := new(DelegationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ZoneList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
 	}
-	return nil
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpectedStatuses != nil {
+		in, out := &in.ExpectedStatuses, &out.ExpectedStatuses
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.InsecureSkipVerify != nil {
+		in, out := &in.InsecureSkipVerify, &out.InsecureSkipVerify
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneRef) DeepCopyInto(out *ZoneRef) {
+func (in *LuaIfPortUp) DeepCopyInto(out *LuaIfPortUp) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRef.
-func (in *ZoneRef) DeepCopy() *ZoneRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaIfPortUp.
+func (in *LuaIfPortUp) DeepCopy() *LuaIfPortUp {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneRef)
+	out := new(LuaIfPortUp)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+func (in *LuaIfURLUp) DeepCopyInto(out *LuaIfURLUp) {
 	*out = *in
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
-		*out = new(string)
-		**out = **in
-	}
-	if in.SOAEditAPI != nil {
-		in, out := &in.SOAEditAPI, &out.SOAEditAPI
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
-func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaIfURLUp.
+func (in *LuaIfURLUp) DeepCopy() *LuaIfURLUp {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneSpec)
+	out := new(LuaIfURLUp)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneStatus) DeepCopyInto(out *ZoneStatus) {
+func (in *LuaRecord) DeepCopyInto(out *LuaRecord) {
 	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(string)
-		**out = **in
-	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-	if in.Kind != nil {
-		in, out := &in.Kind, &out.Kind
-		*out = new(string)
-		**out = **in
-	}
-	if in.Serial != nil {
-		in, out := &in.Serial, &out.Serial
-		*out = new(uint32)
-		**out = **in
-	}
-	if in.NotifiedSerial != nil {
-		in, out := &in.NotifiedSerial, &out.NotifiedSerial
-		*out = new(uint32)
-		**out = **in
+	if in.IfPortUp != nil {
+		in, out := &in.IfPortUp, &out.IfPortUp
+		*out = new(LuaIfPortUp)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EditedSerial != nil {
-		in, out := &in.EditedSerial, &out.EditedSerial
-		*out = new(uint32)
-		**out = **in
+	if in.IfURLUp != nil {
+		in, out := &in.IfURLUp, &out.IfURLUp
+		*out = new(LuaIfURLUp)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Masters != nil {
-		in, out := &in.Masters, &out.Masters
+	if in.PickRandom != nil {
+		in, out := &in.PickRandom, &out.PickRandom
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.DNSsec != nil {
-		in, out := &in.DNSsec, &out.DNSsec
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
-		*out = new(string)
-		**out = **in
+	if in.PickWRandom != nil {
+		in, out := &in.PickWRandom, &out.PickWRandom
+		*out = make([]LuaWeightedAddress, len(*in))
+		copy(*out, *in)
 	}
-	if in.SyncStatus != nil {
-		in, out := &in.SyncStatus, &out.SyncStatus
+	if in.Snippet != nil {
+		in, out := &in.Snippet, &out.Snippet
 		*out = new(string)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ObservedGeneration != nil {
-		in, out := &in.ObservedGeneration, &out.ObservedGeneration
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaRecord.
+func (in *LuaRecord) DeepCopy() *LuaRecord {
+	if in == nil {
+		return nil
 	}
+	out := new(LuaRecord)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneStatus.
-func (in *ZoneStatus) DeepCopy() *ZoneStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaWeightedAddress) DeepCopyInto(out *LuaWeightedAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaWeightedAddress.
+func (in *LuaWeightedAddress) DeepCopy() *LuaWeightedAddress {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneStatus)
+	out := new(LuaWeightedAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTASTS) DeepCopyInto(out *MTASTS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTASTS.
+func (in *MTASTS) DeepCopy() *MTASTS {
+	if in == nil {
+		return nil
+	}
+	out := new(MTASTS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MailPolicy) DeepCopyInto(out *MailPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailPolicy.
+func (in *MailPolicy) DeepCopy() *MailPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MailPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MailPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MailPolicyList) DeepCopyInto(out *MailPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MailPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailPolicyList.
+func (in *MailPolicyList) DeepCopy() *MailPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MailPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MailPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MailPolicySpec) DeepCopyInto(out *MailPolicySpec) {
+	*out = *in
+	in.ZoneRef.DeepCopyInto(&out.ZoneRef)
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	if in.SPF != nil {
+		in, out := &in.SPF, &out.SPF
+		*out = new(SPF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DMARC != nil {
+		in, out := &in.DMARC, &out.DMARC
+		*out = new(DMARC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTASTS != nil {
+		in, out := &in.MTASTS, &out.MTASTS
+		*out = new(MTASTS)
+		**out = **in
+	}
+	if in.TLSRPT != nil {
+		in, out := &in.TLSRPT, &out.TLSRPT
+		*out = new(TLSRPT)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailPolicySpec.
+func (in *MailPolicySpec) DeepCopy() *MailPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MailPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MailPolicyStatus) DeepCopyInto(out *MailPolicyStatus) {
+	*out = *in
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailPolicyStatus.
+func (in *MailPolicyStatus) DeepCopy() *MailPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MailPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PTRRecordStatus) DeepCopyInto(out *PTRRecordStatus) {
+	*out = *in
+	if in.Conflict != nil {
+		in, out := &in.Conflict, &out.Conflict
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PTRRecordStatus.
+func (in *PTRRecordStatus) DeepCopy() *PTRRecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PTRRecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prune) DeepCopyInto(out *Prune) {
+	*out = *in
+	if in.Allowlist != nil {
+		in, out := &in.Allowlist, &out.Allowlist
+		*out = make([]PruneAllowlistEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prune.
+func (in *Prune) DeepCopy() *Prune {
+	if in == nil {
+		return nil
+	}
+	out := new(Prune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneAllowlistEntry) DeepCopyInto(out *PruneAllowlistEntry) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneAllowlistEntry.
+func (in *PruneAllowlistEntry) DeepCopy() *PruneAllowlistEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneAllowlistEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRset) DeepCopyInto(out *RRset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRset.
+func (in *RRset) DeepCopy() *RRset {
+	if in == nil {
+		return nil
+	}
+	out := new(RRset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RRset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetList) DeepCopyInto(out *RRsetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RRset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetList.
+func (in *RRsetList) DeepCopy() *RRsetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RRsetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetSpec) DeepCopyInto(out *RRsetSpec) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Lua != nil {
+		in, out := &in.Lua, &out.Lua
+		*out = new(LuaRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	in.ZoneRef.DeepCopyInto(&out.ZoneRef)
+	if in.ManagePTR != nil {
+		in, out := &in.ManagePTR, &out.ManagePTR
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TakeOver != nil {
+		in, out := &in.TakeOver, &out.TakeOver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetSpec.
+func (in *RRsetSpec) DeepCopy() *RRsetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DnsEntryName != nil {
+		in, out := &in.DnsEntryName, &out.DnsEntryName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PTRRecords != nil {
+		in, out := &in.PTRRecords, &out.PTRRecords
+		*out = make([]PTRRecordStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedRecords != nil {
+		in, out := &in.ObservedRecords, &out.ObservedRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]TargetHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetStatus.
+func (in *RRsetStatus) DeepCopy() *RRsetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOA) DeepCopyInto(out *SOA) {
+	*out = *in
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(string)
+		**out = **in
+	}
+	if in.Contact != nil {
+		in, out := &in.Contact, &out.Contact
+		*out = new(string)
+		**out = **in
+	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Expire != nil {
+		in, out := &in.Expire, &out.Expire
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOA.
+func (in *SOA) DeepCopy() *SOA {
+	if in == nil {
+		return nil
+	}
+	out := new(SOA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOAStatus) DeepCopyInto(out *SOAStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOAStatus.
+func (in *SOAStatus) DeepCopy() *SOAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SOAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPF) DeepCopyInto(out *SPF) {
+	*out = *in
+	if in.Mechanisms != nil {
+		in, out := &in.Mechanisms, &out.Mechanisms
+		*out = make([]SPFMechanism, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPF.
+func (in *SPF) DeepCopy() *SPF {
+	if in == nil {
+		return nil
+	}
+	out := new(SPF)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPFMechanism) DeepCopyInto(out *SPFMechanism) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.Qualifier != nil {
+		in, out := &in.Qualifier, &out.Qualifier
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPFMechanism.
+func (in *SPFMechanism) DeepCopy() *SPFMechanism {
+	if in == nil {
+		return nil
+	}
+	out := new(SPFMechanism)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSRPT) DeepCopyInto(out *TLSRPT) {
+	*out = *in
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = make([]TLSRPTDestination, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSRPT.
+func (in *TLSRPT) DeepCopy() *TLSRPT {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSRPT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealth) DeepCopyInto(out *TargetHealth) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealth.
+func (in *TargetHealth) DeepCopy() *TargetHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRef) DeepCopyInto(out *TemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRef.
+func (in *TemplateRef) DeepCopy() *TemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Zone) DeepCopyInto(out *Zone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
+func (in *Zone) DeepCopy() *Zone {
+	if in == nil {
+		return nil
+	}
+	out := new(Zone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Zone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAction) DeepCopyInto(out *ZoneAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAction.
+func (in *ZoneAction) DeepCopy() *ZoneAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneActionList) DeepCopyInto(out *ZoneActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneActionList.
+func (in *ZoneActionList) DeepCopy() *ZoneActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneActionSpec) DeepCopyInto(out *ZoneActionSpec) {
+	*out = *in
+	in.ZoneRef.DeepCopyInto(&out.ZoneRef)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneActionSpec.
+func (in *ZoneActionSpec) DeepCopy() *ZoneActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneActionStatus) DeepCopyInto(out *ZoneActionStatus) {
+	*out = *in
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneActionStatus.
+func (in *ZoneActionStatus) DeepCopy() *ZoneActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneList) DeepCopyInto(out *ZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Zone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneList.
+func (in *ZoneList) DeepCopy() *ZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZonePolicy) DeepCopyInto(out *ZonePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZonePolicy.
+func (in *ZonePolicy) DeepCopy() *ZonePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ZonePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZonePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZonePolicyList) DeepCopyInto(out *ZonePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZonePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZonePolicyList.
+func (in *ZonePolicyList) DeepCopy() *ZonePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZonePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZonePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZonePolicySpec) DeepCopyInto(out *ZonePolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedZoneSuffixes != nil {
+		in, out := &in.AllowedZoneSuffixes, &out.AllowedZoneSuffixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRecordPatterns != nil {
+		in, out := &in.AllowedRecordPatterns, &out.AllowedRecordPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedTypes != nil {
+		in, out := &in.AllowedTypes, &out.AllowedTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinTTL != nil {
+		in, out := &in.MinTTL, &out.MinTTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZonePolicySpec.
+func (in *ZonePolicySpec) DeepCopy() *ZonePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZonePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRef) DeepCopyInto(out *ZoneRef) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRef.
+func (in *ZoneRef) DeepCopy() *ZoneRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReferenceGrant) DeepCopyInto(out *ZoneReferenceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReferenceGrant.
+func (in *ZoneReferenceGrant) DeepCopy() *ZoneReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReferenceGrantFrom) DeepCopyInto(out *ZoneReferenceGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReferenceGrantFrom.
+func (in *ZoneReferenceGrantFrom) DeepCopy() *ZoneReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReferenceGrantList) DeepCopyInto(out *ZoneReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneReferenceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReferenceGrantList.
+func (in *ZoneReferenceGrantList) DeepCopy() *ZoneReferenceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReferenceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReferenceGrantSpec) DeepCopyInto(out *ZoneReferenceGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ZoneReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ZoneReferenceGrantTo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReferenceGrantSpec.
+func (in *ZoneReferenceGrantSpec) DeepCopy() *ZoneReferenceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReferenceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneReferenceGrantTo) DeepCopyInto(out *ZoneReferenceGrantTo) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneReferenceGrantTo.
+func (in *ZoneReferenceGrantTo) DeepCopy() *ZoneReferenceGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneReferenceGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NameserversTTL != nil {
+		in, out := &in.NameserversTTL, &out.NameserversTTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(string)
+		**out = **in
+	}
+	if in.SOAEditAPI != nil {
+		in, out := &in.SOAEditAPI, &out.SOAEditAPI
+		*out = new(string)
+		**out = **in
+	}
+	if in.SOA != nil {
+		in, out := &in.SOA, &out.SOA
+		*out = new(SOA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateRef)
+		**out = **in
+	}
+	if in.RecordsPolicy != nil {
+		in, out := &in.RecordsPolicy, &out.RecordsPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(Prune)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementPolicy != nil {
+		in, out := &in.ManagementPolicy, &out.ManagementPolicy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
+func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneStatus) DeepCopyInto(out *ZoneStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Serial != nil {
+		in, out := &in.Serial, &out.Serial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.NotifiedSerial != nil {
+		in, out := &in.NotifiedSerial, &out.NotifiedSerial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EditedSerial != nil {
+		in, out := &in.EditedSerial, &out.EditedSerial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSsec != nil {
+		in, out := &in.DNSsec, &out.DNSsec
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(string)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecordsCount != nil {
+		in, out := &in.RecordsCount, &out.RecordsCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SOA != nil {
+		in, out := &in.SOA, &out.SOA
+		*out = new(SOAStatus)
+		**out = **in
+	}
+	if in.Delegation != nil {
+		in, out := &in.Delegation, &out.Delegation
+		*out = new(DelegationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnmanagedRRsets != nil {
+		in, out := &in.UnmanagedRRsets, &out.UnmanagedRRsets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneStatus.
+func (in *ZoneStatus) DeepCopy() *ZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneTemplate) DeepCopyInto(out *ZoneTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneTemplate.
+func (in *ZoneTemplate) DeepCopy() *ZoneTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneTemplateList) DeepCopyInto(out *ZoneTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneTemplateList.
+func (in *ZoneTemplateList) DeepCopy() *ZoneTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneTemplateRRset) DeepCopyInto(out *ZoneTemplateRRset) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneTemplateRRset.
+func (in *ZoneTemplateRRset) DeepCopy() *ZoneTemplateRRset {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneTemplateRRset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneTemplateSpec) DeepCopyInto(out *ZoneTemplateSpec) {
+	*out = *in
+	if in.SOA != nil {
+		in, out := &in.SOA, &out.SOA
+		*out = new(SOA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NameserversTTL != nil {
+		in, out := &in.NameserversTTL, &out.NameserversTTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RRsets != nil {
+		in, out := &in.RRsets, &out.RRsets
+		*out = make([]ZoneTemplateRRset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneTemplateSpec.
+func (in *ZoneTemplateSpec) DeepCopy() *ZoneTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
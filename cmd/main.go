@@ -12,24 +12,33 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crconfig "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/powerdns-operator/powerdns-operator/internal/config"
 	"github.com/powerdns-operator/powerdns-operator/internal/controller"
+	"github.com/powerdns-operator/powerdns-operator/internal/tracing"
+	webhookdnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/internal/webhook/v1alpha2"
 
 	powerdns "github.com/joeig/go-powerdns/v3"
 
@@ -50,39 +59,10 @@ func init() {
 }
 
 func main() {
-	var metricsAddr string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
-
-	apiURL := os.Getenv("PDNS_API_URL")
-	if apiURL == "" {
-		apiURL = "http://localhost:8081"
-	}
-
-	apiKey := os.Getenv("PDNS_API_KEY")
-	if apiKey == "" {
-		apiKey = "secret"
-	}
-
-	apiVhost := os.Getenv("PDNS_API_VHOST")
-	if apiVhost == "" {
-		apiVhost = "localhost"
-	}
-
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
-		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", false,
-		"If set the metrics endpoint is served securely")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
-		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	flag.StringVar(&apiURL, "pdns-api-url", apiURL, "The URL of the PowerDNS API")
-	flag.StringVar(&apiKey, "pdns-api-key", apiKey, "The API key to authenticate with the PowerDNS API")
-	flag.StringVar(&apiVhost, "pdns-api-vhost", apiVhost, "The vhost of the PowerDNS API")
+	var configFile string
+	flag.StringVar(&configFile, "config", "",
+		"The path of the configuration file of the operator. Environment variables and flags override it")
+	flags := config.BindFlags(flag.CommandLine)
 	opts := zap.Options{
 		Development: false,
 	}
@@ -90,8 +70,44 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
-	setupLog.Info("PowerDNS API URL", "url", apiURL)
-	setupLog.Info("PowerDNS API vhost", "vhost", apiVhost)
+
+	// The configuration file, if any, is overridden by the environment variables and then by the flags
+	cfg := config.Default()
+	if configFile != "" {
+		var err error
+		if cfg, err = config.Load(configFile); err != nil {
+			setupLog.Error(err, "unable to load the configuration file")
+			os.Exit(1)
+		}
+		setupLog.Info("Configuration file loaded", "path", configFile)
+	}
+	cfg.OverrideFromEnv()
+	flags.Override(cfg)
+	if err := cfg.Validate(); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		os.Exit(1)
+	}
+	apiKey, err := cfg.PowerDNS.Key()
+	if err != nil {
+		setupLog.Error(err, "unable to read the PowerDNS API key")
+		os.Exit(1)
+	}
+	setupLog.Info("PowerDNS API URL", "url", cfg.PowerDNS.URL)
+	setupLog.Info("PowerDNS API vhost", "vhost", cfg.PowerDNS.VHost)
+
+	if cfg.Tracing.OTLPEndpoint != "" {
+		setupLog.Info("OpenTelemetry tracing enabled", "endpoint", cfg.Tracing.OTLPEndpoint)
+		shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.OTLPEndpoint, cfg.Tracing.OTLPInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "unable to flush traces")
+			}
+		}()
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -105,7 +121,7 @@ func main() {
 	}
 
 	tlsOpts := []func(*tls.Config){}
-	if !enableHTTP2 {
+	if !cfg.Manager.EnableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
@@ -116,14 +132,24 @@ func main() {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
-			BindAddress:   metricsAddr,
-			SecureServing: secureMetrics,
+			BindAddress:   cfg.Manager.MetricsBindAddress,
+			SecureServing: cfg.Manager.MetricsSecure,
 			TLSOpts:       tlsOpts,
 		},
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "6bc048b3.cav.enablers.ob",
+		HealthProbeBindAddress: cfg.Manager.HealthProbeBindAddress,
+		LeaderElection:         cfg.Manager.LeaderElection.Enabled,
+		LeaderElectionID:       cfg.Manager.LeaderElection.ID,
+		Cache: cache.Options{
+			SyncPeriod: syncPeriod(cfg),
+			ByObject: map[client.Object]cache.ByObject{
+				// Only the Secrets of the DKIMKeys are cached
+				&corev1.Secret{}: {Label: controller.DKIMSecretSelector()},
+			},
+		},
+		Controller: crconfig.Controller{
+			GroupKindConcurrency: cfg.Controllers.GroupKindConcurrency(),
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -141,55 +167,118 @@ func main() {
 		os.Exit(1)
 	}
 
-	pdnsClient := PDNSClientInitializer(apiURL, apiKey, apiVhost)
-	if err != nil {
-		setupLog.Error(err, "unable to initialize connection with PowerDNS server")
-		os.Exit(1)
-	}
+	pdnsClient := PDNSClientInitializer(cfg.PowerDNS.URL, apiKey, cfg.PowerDNS.VHost)
+	controller.Configure(controller.Settings{
+		NameserversTTL: cfg.Defaults.NameserversTTL,
+		Account:        cfg.Defaults.Account,
+		PrunePeriod:    cfg.Resync.PrunePeriod.Duration,
+		ObservePeriod:  cfg.Resync.ObservePeriod.Duration,
+	})
+	// Client calls (both Kubernetes and PowerDNS) are wrapped in spans,
+	// they are no-op when tracing is disabled
+	k8sClient := controller.NewTracedClient(mgr.GetClient())
+	pdnsClienter := controller.NewTracedPdnsClienter(controller.PdnsClienter{
+		Records:  pdnsClient.Records,
+		Zones:    pdnsClient.Zones,
+		Metadata: pdnsClient.Metadata,
+		Actions:  controller.NewActionsClient(pdnsClient, apiKey),
+		OwnerID:  cfg.PowerDNS.OwnerID,
+	})
 	if err = (&controller.ZoneReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
-		},
+		Client:     k8sClient,
+		Scheme:     mgr.GetScheme(),
+		PDNSClient: pdnsClienter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Zone")
 		os.Exit(1)
 	}
 	if err = (&controller.RRsetReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
-		},
+		Client:     k8sClient,
+		Scheme:     mgr.GetScheme(),
+		PDNSClient: pdnsClienter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RRset")
 		os.Exit(1)
 	}
 	if err = (&controller.ClusterZoneReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
-		},
+		Client:     k8sClient,
+		Scheme:     mgr.GetScheme(),
+		PDNSClient: pdnsClienter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterZone")
 		os.Exit(1)
 	}
 	if err = (&controller.ClusterRRsetReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
-		},
+		Client:     k8sClient,
+		Scheme:     mgr.GetScheme(),
+		PDNSClient: pdnsClienter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterRRset")
 		os.Exit(1)
 	}
+	if cfg.Features.ZoneActions {
+		if err = (&controller.ZoneActionReconciler{
+			Client:     k8sClient,
+			Scheme:     mgr.GetScheme(),
+			PDNSClient: pdnsClienter,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ZoneAction")
+			os.Exit(1)
+		}
+	}
+	if cfg.Features.DKIMKeys {
+		if err = (&controller.DKIMKeyReconciler{
+			Client:    k8sClient,
+			Scheme:    mgr.GetScheme(),
+			APIReader: mgr.GetAPIReader(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DKIMKey")
+			os.Exit(1)
+		}
+	}
+	if cfg.Features.MailPolicies {
+		if err = (&controller.MailPolicyReconciler{
+			Client: k8sClient,
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "MailPolicy")
+			os.Exit(1)
+		}
+	}
+	if cfg.Features.ServiceDiscovery {
+		if err = (&controller.ServiceReconciler{
+			Client:   k8sClient,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("service-discovery"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Service")
+			os.Exit(1)
+		}
+	} else if err = (&controller.ServiceCleaner{
+		Client:    k8sClient,
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create runnable", "runnable", "ServiceCleaner")
+		os.Exit(1)
+	}
+	if cfg.Features.Webhooks {
+		if err = webhookdnsv1alpha2.SetupZoneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Zone")
+			os.Exit(1)
+		}
+		if err = webhookdnsv1alpha2.SetupRRsetWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RRset")
+			os.Exit(1)
+		}
+		if err = webhookdnsv1alpha2.SetupClusterZoneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterZone")
+			os.Exit(1)
+		}
+		if err = webhookdnsv1alpha2.SetupClusterRRsetWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterRRset")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -208,6 +297,14 @@ func main() {
 	}
 }
 
+// syncPeriod returns the period all the resources are reconciled again, nil for the default of controller-runtime
+func syncPeriod(cfg *config.OperatorConfiguration) *time.Duration {
+	if cfg.Resync.SyncPeriod == nil {
+		return nil
+	}
+	return &cfg.Resync.SyncPeriod.Duration
+}
+
 func PDNSClientInitializer(baseURL string, key string, vhost string) *powerdns.Client {
 	return powerdns.New(baseURL, vhost, powerdns.WithAPIKey(key))
 }
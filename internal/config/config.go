@@ -0,0 +1,343 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package config loads and validates the configuration file of the operator.
+// The configuration is versioned like a Kubernetes object, environment variables and flags override it.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	APIVersion = "config.dns.cav.enablers.ob/v1alpha1"
+	Kind       = "OperatorConfiguration"
+
+	// group of the resources reconciled by the operator, Services excepted
+	group = "dns.cav.enablers.ob"
+)
+
+// Kinds are the kinds of the resources reconciled by the operator, whose concurrency is configurable
+var Kinds = []string{"Zone", "ClusterZone", "RRset", "ClusterRRset", "ZoneAction", "DKIMKey", "MailPolicy", "Service"}
+
+// OperatorConfiguration is the configuration of the operator
+type OperatorConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// PowerDNS is the connection to the PowerDNS server
+	PowerDNS PowerDNS `json:"powerDNS"`
+	// Manager configures the controller manager
+	Manager Manager `json:"manager"`
+	// Defaults apply to the resources which do not set them
+	Defaults Defaults `json:"defaults"`
+	// Controllers configures the controllers
+	Controllers Controllers `json:"controllers"`
+	// Resync configures the periods resources are reconciled again, without any change
+	Resync Resync `json:"resync"`
+	// Features enables or disables the optional features
+	Features Features `json:"features"`
+	// Tracing configures the export of traces
+	Tracing Tracing `json:"tracing"`
+}
+
+// PowerDNS is the connection to the PowerDNS server
+type PowerDNS struct {
+	// URL of the PowerDNS API
+	URL string `json:"url"`
+	// APIKey authenticates with the PowerDNS API, prefer APIKeyFile
+	APIKey string `json:"apiKey,omitempty"`
+	// APIKeyFile is the path of a file holding the API key, e.g. mounted from a Secret
+	APIKeyFile string `json:"apiKeyFile,omitempty"`
+	// VHost of the PowerDNS API
+	VHost string `json:"vhost"`
+	// OwnerID identifies the operator instance when several instances share the same PowerDNS server
+	OwnerID string `json:"ownerID,omitempty"`
+}
+
+// Manager configures the controller manager
+type Manager struct {
+	// MetricsBindAddress is the address the metric endpoint binds to, "0" disables it
+	MetricsBindAddress string `json:"metricsBindAddress"`
+	// MetricsSecure serves the metrics endpoint securely
+	MetricsSecure bool `json:"metricsSecure,omitempty"`
+	// HealthProbeBindAddress is the address the probe endpoint binds to
+	HealthProbeBindAddress string `json:"healthProbeBindAddress"`
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers
+	EnableHTTP2 bool `json:"enableHTTP2,omitempty"`
+	// LeaderElection ensures there is only one active controller manager
+	LeaderElection LeaderElection `json:"leaderElection"`
+}
+
+// LeaderElection configures the leader election of the controller manager
+type LeaderElection struct {
+	Enabled bool `json:"enabled"`
+	// ID is the name of the Lease, it must differ between operator instances running in the same namespace
+	ID string `json:"id"`
+}
+
+// Defaults apply to the resources which do not set them
+type Defaults struct {
+	// NameserversTTL is the TTL of the NS records of the zones, in seconds
+	NameserversTTL uint32 `json:"nameserversTTL"`
+	// Account marks the objects of PowerDNS owned by the operator, it must be the same for all the instances sharing the server
+	Account string `json:"account"`
+}
+
+// Controllers configures the controllers
+type Controllers struct {
+	// Concurrency is the number of concurrent reconciliations, indexed by kind (e.g. "RRset"), defaults to 1
+	Concurrency map[string]int `json:"concurrency,omitempty"`
+}
+
+// Resync configures the periods resources are reconciled again, without any change
+type Resync struct {
+	// SyncPeriod is the period all the resources are reconciled again, defaults to the one of controller-runtime
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// PrunePeriod is the period zones with the "Authoritative" records policy are reconciled again
+	PrunePeriod metav1.Duration `json:"prunePeriod"`
+	// ObservePeriod is the period observed zones, and the RRsets referencing them, are read again from PowerDNS
+	ObservePeriod metav1.Duration `json:"observePeriod"`
+}
+
+// Features enables or disables the optional features
+type Features struct {
+	// Webhooks enables the admission webhooks
+	Webhooks bool `json:"webhooks"`
+	// ZoneActions enables the ZoneAction controller
+	ZoneActions bool `json:"zoneActions"`
+	// DKIMKeys enables the DKIMKey controller
+	DKIMKeys bool `json:"dkimKeys"`
+	// MailPolicies enables the MailPolicy controller
+	MailPolicies bool `json:"mailPolicies"`
+	// ServiceDiscovery enables the publication of the records of annotated headless Services
+	ServiceDiscovery bool `json:"serviceDiscovery"`
+}
+
+// Tracing configures the export of traces
+type Tracing struct {
+	// OTLPEndpoint is the OTLP/gRPC endpoint (host:port) traces are exported to, tracing is disabled if empty
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+	// OTLPInsecure exports traces without TLS
+	OTLPInsecure bool `json:"otlpInsecure,omitempty"`
+}
+
+// Default returns the configuration of an operator started without configuration file
+func Default() *OperatorConfiguration {
+	return &OperatorConfiguration{
+		TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		PowerDNS: PowerDNS{
+			URL:    "http://localhost:8081",
+			APIKey: "secret",
+			VHost:  "localhost",
+		},
+		Manager: Manager{
+			MetricsBindAddress:     ":8080",
+			HealthProbeBindAddress: ":8081",
+			LeaderElection:         LeaderElection{ID: "6bc048b3.cav.enablers.ob"},
+		},
+		Defaults: Defaults{
+			NameserversTTL: 1500,
+			Account:        "powerdns-operator",
+		},
+		Resync: Resync{
+			PrunePeriod:   metav1.Duration{Duration: 5 * time.Minute},
+			ObservePeriod: metav1.Duration{Duration: time.Minute},
+		},
+		Features: Features{
+			Webhooks:         true,
+			ZoneActions:      true,
+			DKIMKeys:         true,
+			MailPolicies:     true,
+			ServiceDiscovery: true,
+		},
+	}
+}
+
+// Load reads the configuration file, the fields it does not set keep their default value
+func Load(path string) (*OperatorConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := Default()
+	c.TypeMeta = metav1.TypeMeta{}
+	// The default API key only applies when the file sets neither apiKey nor apiKeyFile
+	defaultAPIKey := c.PowerDNS.APIKey
+	c.PowerDNS.APIKey = ""
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	if c.PowerDNS.APIKey == "" && c.PowerDNS.APIKeyFile == "" {
+		c.PowerDNS.APIKey = defaultAPIKey
+	}
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		return nil, fmt.Errorf("invalid configuration file %s: apiVersion %s and kind %s expected, got %q and %q", path, APIVersion, Kind, c.APIVersion, c.Kind)
+	}
+	return c, nil
+}
+
+// OverrideFromEnv overrides the connection to the PowerDNS server with the PDNS_API_URL, PDNS_API_KEY and PDNS_API_VHOST environment variables,
+// and disables the webhooks when ENABLE_WEBHOOKS is "false"
+func (c *OperatorConfiguration) OverrideFromEnv() {
+	if value := os.Getenv("PDNS_API_URL"); value != "" {
+		c.PowerDNS.URL = value
+	}
+	if value := os.Getenv("PDNS_API_KEY"); value != "" {
+		c.PowerDNS.APIKey = value
+		c.PowerDNS.APIKeyFile = ""
+	}
+	if value := os.Getenv("PDNS_API_VHOST"); value != "" {
+		c.PowerDNS.VHost = value
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "false" {
+		c.Features.Webhooks = false
+	}
+}
+
+// Validate returns an error listing the invalid fields of the configuration
+func (c *OperatorConfiguration) Validate() error {
+	var errs []error
+	if u, err := url.Parse(c.PowerDNS.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("powerDNS.url: an absolute http or https URL is expected, got %q", c.PowerDNS.URL))
+	}
+	if c.PowerDNS.APIKey != "" && c.PowerDNS.APIKeyFile != "" {
+		errs = append(errs, errors.New("powerDNS.apiKey and powerDNS.apiKeyFile are mutually exclusive"))
+	}
+	if c.PowerDNS.VHost == "" {
+		errs = append(errs, errors.New("powerDNS.vhost must be set"))
+	}
+	if strings.Contains(c.PowerDNS.OwnerID, "/") {
+		errs = append(errs, fmt.Errorf("powerDNS.ownerID must not contain '/', got %q", c.PowerDNS.OwnerID))
+	}
+	if c.Manager.LeaderElection.Enabled && c.Manager.LeaderElection.ID == "" {
+		errs = append(errs, errors.New("manager.leaderElection.id must be set when leader election is enabled"))
+	}
+	if c.Defaults.NameserversTTL == 0 {
+		errs = append(errs, errors.New("defaults.nameserversTTL must be positive"))
+	}
+	if c.Defaults.Account == "" || strings.Contains(c.Defaults.Account, "/") {
+		errs = append(errs, fmt.Errorf("defaults.account must be set and must not contain '/', got %q", c.Defaults.Account))
+	}
+	for kind, concurrency := range c.Controllers.Concurrency {
+		if !slices.Contains(Kinds, kind) {
+			errs = append(errs, fmt.Errorf("controllers.concurrency: unknown kind %q, one of %s expected", kind, strings.Join(Kinds, ", ")))
+		} else if concurrency < 1 {
+			errs = append(errs, fmt.Errorf("controllers.concurrency.%s must be positive", kind))
+		}
+	}
+	if c.Resync.SyncPeriod != nil && c.Resync.SyncPeriod.Duration <= 0 {
+		errs = append(errs, errors.New("resync.syncPeriod must be positive"))
+	}
+	if c.Resync.PrunePeriod.Duration <= 0 {
+		errs = append(errs, errors.New("resync.prunePeriod must be positive"))
+	}
+	if c.Resync.ObservePeriod.Duration <= 0 {
+		errs = append(errs, errors.New("resync.observePeriod must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// Key returns the API key of the PowerDNS API, read from APIKeyFile if set
+func (p PowerDNS) Key() (string, error) {
+	if p.APIKeyFile == "" {
+		return p.APIKey, nil
+	}
+	data, err := os.ReadFile(p.APIKeyFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// GroupKindConcurrency returns the concurrency of the controllers, indexed by the group kind of the resources they reconcile,
+// as expected by the controller manager
+func (c Controllers) GroupKindConcurrency() map[string]int {
+	result := make(map[string]int, len(c.Concurrency))
+	for kind, concurrency := range c.Concurrency {
+		key := kind + "." + group
+		if kind == "Service" {
+			key = kind
+		}
+		result[key] = concurrency
+	}
+	return result
+}
+
+// Flags are the command line flags overriding the configuration
+type Flags struct {
+	fs     *flag.FlagSet
+	values *OperatorConfiguration
+}
+
+// BindFlags defines the flags overriding the configuration on fs
+func BindFlags(fs *flag.FlagSet) *Flags {
+	v := Default()
+	fs.StringVar(&v.Manager.MetricsBindAddress, "metrics-bind-address", v.Manager.MetricsBindAddress, "The address the metric endpoint binds to.")
+	fs.StringVar(&v.Manager.HealthProbeBindAddress, "health-probe-bind-address", v.Manager.HealthProbeBindAddress, "The address the probe endpoint binds to.")
+	fs.BoolVar(&v.Manager.LeaderElection.Enabled, "leader-elect", v.Manager.LeaderElection.Enabled,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.BoolVar(&v.Manager.MetricsSecure, "metrics-secure", v.Manager.MetricsSecure,
+		"If set the metrics endpoint is served securely")
+	fs.BoolVar(&v.Manager.EnableHTTP2, "enable-http2", v.Manager.EnableHTTP2,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	fs.StringVar(&v.PowerDNS.URL, "pdns-api-url", v.PowerDNS.URL, "The URL of the PowerDNS API")
+	fs.StringVar(&v.PowerDNS.APIKey, "pdns-api-key", v.PowerDNS.APIKey, "The API key to authenticate with the PowerDNS API")
+	fs.StringVar(&v.PowerDNS.VHost, "pdns-api-vhost", v.PowerDNS.VHost, "The vhost of the PowerDNS API")
+	fs.StringVar(&v.Tracing.OTLPEndpoint, "tracing-otlp-endpoint", v.Tracing.OTLPEndpoint,
+		"The OTLP/gRPC endpoint (host:port) traces are exported to. Tracing is disabled if empty")
+	fs.BoolVar(&v.Tracing.OTLPInsecure, "tracing-otlp-insecure", v.Tracing.OTLPInsecure,
+		"If set, traces are exported to the OTLP endpoint without TLS")
+	fs.StringVar(&v.PowerDNS.OwnerID, "owner-id", v.PowerDNS.OwnerID,
+		"Identifies this operator instance when several instances share the same PowerDNS server")
+	return &Flags{fs: fs, values: v}
+}
+
+// Override overrides the configuration with the flags set on the command line, once parsed
+func (f *Flags) Override(c *OperatorConfiguration) {
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "metrics-bind-address":
+			c.Manager.MetricsBindAddress = f.values.Manager.MetricsBindAddress
+		case "health-probe-bind-address":
+			c.Manager.HealthProbeBindAddress = f.values.Manager.HealthProbeBindAddress
+		case "leader-elect":
+			c.Manager.LeaderElection.Enabled = f.values.Manager.LeaderElection.Enabled
+		case "metrics-secure":
+			c.Manager.MetricsSecure = f.values.Manager.MetricsSecure
+		case "enable-http2":
+			c.Manager.EnableHTTP2 = f.values.Manager.EnableHTTP2
+		case "pdns-api-url":
+			c.PowerDNS.URL = f.values.PowerDNS.URL
+		case "pdns-api-key":
+			c.PowerDNS.APIKey = f.values.PowerDNS.APIKey
+			c.PowerDNS.APIKeyFile = ""
+		case "pdns-api-vhost":
+			c.PowerDNS.VHost = f.values.PowerDNS.VHost
+		case "tracing-otlp-endpoint":
+			c.Tracing.OTLPEndpoint = f.values.Tracing.OTLPEndpoint
+		case "tracing-otlp-insecure":
+			c.Tracing.OTLPInsecure = f.values.Tracing.OTLPInsecure
+		case "owner-id":
+			c.PowerDNS.OwnerID = f.values.PowerDNS.OwnerID
+		}
+	})
+}
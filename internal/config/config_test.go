@@ -0,0 +1,147 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes the content in a file of a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+apiVersion: config.dns.cav.enablers.ob/v1alpha1
+kind: OperatorConfiguration
+powerDNS:
+  url: https://powerdns.example.org:8081
+  apiKeyFile: /etc/powerdns-operator/api-key
+controllers:
+  concurrency:
+    RRset: 4
+resync:
+  prunePeriod: 10m
+features:
+  serviceDiscovery: false
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.PowerDNS.URL != "https://powerdns.example.org:8081" || c.PowerDNS.APIKey != "" || c.PowerDNS.APIKeyFile != "/etc/powerdns-operator/api-key" {
+		t.Errorf("unexpected PowerDNS configuration %+v", c.PowerDNS)
+	}
+	if c.Resync.PrunePeriod.Duration != 10*time.Minute || c.Features.ServiceDiscovery {
+		t.Errorf("unexpected configuration %+v", c)
+	}
+	// The fields not set keep their default value
+	if c.PowerDNS.VHost != "localhost" || c.Defaults.NameserversTTL != 1500 || !c.Features.DKIMKeys || c.Manager.LeaderElection.ID != "6bc048b3.cav.enablers.ob" {
+		t.Errorf("unexpected default values %+v", c)
+	}
+	if got := c.Controllers.GroupKindConcurrency(); len(got) != 1 || got["RRset.dns.cav.enablers.ob"] != 4 {
+		t.Errorf("unexpected group kind concurrency %v", got)
+	}
+
+	var testCases = []struct {
+		description string
+		content     string
+		wantErr     string
+	}{
+		{"wrong kind", "apiVersion: config.dns.cav.enablers.ob/v1alpha1\nkind: Configuration\n", "kind OperatorConfiguration expected"},
+		{"wrong version", "apiVersion: config.dns.cav.enablers.ob/v1\nkind: OperatorConfiguration\n", "apiVersion config.dns.cav.enablers.ob/v1alpha1"},
+		{"unknown field", "apiVersion: config.dns.cav.enablers.ob/v1alpha1\nkind: OperatorConfiguration\nserver:\n  url: http://localhost\n", "unknown field"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := Load(writeFile(t, "config.yaml", tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var testCases = []struct {
+		description string
+		modify      func(c *OperatorConfiguration)
+		wantErr     string
+	}{
+		{"default", func(c *OperatorConfiguration) {}, ""},
+		{"relative URL", func(c *OperatorConfiguration) { c.PowerDNS.URL = "powerdns:8081" }, "powerDNS.url"},
+		{"API key and file", func(c *OperatorConfiguration) { c.PowerDNS.APIKeyFile = "/key" }, "mutually exclusive"},
+		{"owner ID with slash", func(c *OperatorConfiguration) { c.PowerDNS.OwnerID = "a/b" }, "powerDNS.ownerID"},
+		{"empty account", func(c *OperatorConfiguration) { c.Defaults.Account = "" }, "defaults.account"},
+		{"unknown kind", func(c *OperatorConfiguration) { c.Controllers.Concurrency = map[string]int{"Record": 2} }, `unknown kind "Record"`},
+		{"null concurrency", func(c *OperatorConfiguration) { c.Controllers.Concurrency = map[string]int{"Zone": 0} }, "controllers.concurrency.Zone"},
+		{"null period", func(c *OperatorConfiguration) { c.Resync.ObservePeriod.Duration = 0 }, "resync.observePeriod"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := Default()
+			tc.modify(c)
+			err := c.Validate()
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverride(t *testing.T) {
+	c := Default()
+	c.PowerDNS.URL = "http://file.example.org"
+	c.PowerDNS.VHost = "file"
+	c.Manager.MetricsBindAddress = ":9090"
+
+	t.Setenv("PDNS_API_URL", "http://env.example.org")
+	t.Setenv("PDNS_API_KEY", "env-key")
+	t.Setenv("ENABLE_WEBHOOKS", "false")
+	c.OverrideFromEnv()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	if err := fs.Parse([]string{"--pdns-api-url=http://flag.example.org", "--leader-elect"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	flags.Override(c)
+
+	if c.PowerDNS.URL != "http://flag.example.org" {
+		t.Errorf("got URL %s, the flag should override the environment", c.PowerDNS.URL)
+	}
+	if c.PowerDNS.APIKey != "env-key" || c.Features.Webhooks {
+		t.Errorf("the environment should override the file, got %+v", c)
+	}
+	if c.PowerDNS.VHost != "file" || c.Manager.MetricsBindAddress != ":9090" {
+		t.Errorf("the flags not set should not override the file, got %+v", c)
+	}
+	if !c.Manager.LeaderElection.Enabled {
+		t.Errorf("leader election should be enabled by the flag")
+	}
+}
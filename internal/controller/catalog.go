@@ -0,0 +1,105 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// catalogName returns the name of the catalog the zone is a member of, without trailing dot
+func catalogName(zone dnsv1alpha2.GenericZone) string {
+	return strings.TrimSuffix(ptr.Deref(zone.GetSpec().Catalog, ""), ".")
+}
+
+// catalogIndexer indexes the Zones/ClusterZones by the catalog they are a member of
+func catalogIndexer(rawObj client.Object) []string {
+	zone, ok := rawObj.(dnsv1alpha2.GenericZone)
+	if !ok || dnsv1alpha2.ZoneCatalogKey(zone) == "" {
+		return nil
+	}
+	return []string{dnsv1alpha2.ZoneCatalogKey(zone)}
+}
+
+// checkCatalog returns the status of the zone regarding the catalog it is a member of:
+// Pending while the catalog is not a synchronized zone, Failed if it is not a "Producer" zone,
+// nil if the zone is not a member of a catalog or the catalog is valid
+func checkCatalog(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) (*string, string, error) {
+	name := catalogName(zone)
+	if name == "" {
+		return nil, "", nil
+	}
+	if name == zone.GetName() {
+		return ptr.To(FAILED_STATUS), "a zone cannot be a member of itself", nil
+	}
+
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Name": name}); err != nil {
+		return nil, "", err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Entry.Name": name}); err != nil {
+		return nil, "", err
+	}
+	var catalog dnsv1alpha2.GenericZone
+	for i := range zones.Items {
+		if ptr.Deref(zones.Items[i].Status.SyncStatus, "") == SUCCEEDED_STATUS {
+			catalog = &zones.Items[i]
+		}
+	}
+	for i := range clusterZones.Items {
+		if ptr.Deref(clusterZones.Items[i].Status.SyncStatus, "") == SUCCEEDED_STATUS {
+			catalog = &clusterZones.Items[i]
+		}
+	}
+
+	if catalog == nil {
+		return ptr.To(PENDING_STATUS), fmt.Sprintf("catalog %s is not an available Zone/ClusterZone", name), nil
+	}
+	if catalog.GetSpec().Kind != string(powerdns.ProducerZoneKind) {
+		return ptr.To(FAILED_STATUS), fmt.Sprintf("catalog %s is a %s %s, a %s zone is expected", name, catalog.GetSpec().Kind, zoneKind(catalog), powerdns.ProducerZoneKind), nil
+	}
+	return nil, "", nil
+}
+
+// catalogMembers returns the sorted names of the Zones/ClusterZones which are members of the zone, if it is a "Producer" zone
+func catalogMembers(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) ([]string, error) {
+	if zone.GetSpec().Kind != string(powerdns.ProducerZoneKind) {
+		return nil, nil
+	}
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Catalog": zone.GetName()}); err != nil {
+		return nil, err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Catalog": zone.GetName()}); err != nil {
+		return nil, err
+	}
+
+	var members []string
+	for _, z := range zones.Items {
+		members = append(members, z.Name)
+	}
+	for _, z := range clusterZones.Items {
+		members = append(members, z.Name)
+	}
+	slices.Sort(members)
+	return slices.Compact(members), nil
+}
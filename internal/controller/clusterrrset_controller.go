@@ -21,10 +21,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 )
@@ -38,7 +41,7 @@ type ClusterRRsetReconciler struct {
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(clusterRrsetsStatusesMetric)
+	metrics.Registry.MustRegister(clusterRrsetsStatusesMetric, clusterRrsetsTargetsHealthMetric)
 }
 
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterrrsets,verbs=get;list;watch;create;update;patch;delete
@@ -191,7 +194,7 @@ func (r *ClusterRRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterRRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one ClusterRRset/RRset exists for DNS entry
+	// Synchronized ClusterRRsets are indexed by DNS entry, to find the ClusterRRsets managing a record
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.Entry.Name", func(rawObj client.Object) []string {
 		// grab the ClusterRRset object, extract its name...
 		var RRsetName string
@@ -202,7 +205,21 @@ func (r *ClusterRRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	// All the ClusterRRsets are indexed by DNS entry, to elect the one synchronized among duplicates
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.Entry.Claim", rrsetClaimIndexer); err != nil {
+		return err
+	}
+	// ClusterRRsets are indexed by their PTR records in conflict, to check them again when the PTR records are released
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.PTRConflict", ptrConflictIndexer); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.ClusterRRset{}).
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedClusterRRsets)).
+		Watches(&dnsv1alpha2.ClusterRRset{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedClusterRRsets)).
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestsFromMapFunc(r.findPTRConflicts), builder.WithPredicates(ptrReleasedPredicate)).
+		Watches(&dnsv1alpha2.ClusterRRset{}, handler.EnqueueRequestsFromMapFunc(r.findPTRConflicts), builder.WithPredicates(ptrReleasedPredicate)).
+		// The health of the addresses is checked in the background, the ClusterRRset is reconciled once it is
+		WatchesRawSource(source.Channel(clusterRRsetsChecked, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
@@ -19,6 +19,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -74,7 +75,7 @@ var _ = Describe("ClusterRRset Controller", func() {
 		}
 		zone.SetResourceVersion("")
 		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
-			zone.Spec = dnsv1alpha2.ZoneSpec{
+			zone.Spec.ZoneSpec = dnsv1alpha2.ZoneSpec{
 				Kind:        zoneKind,
 				Nameservers: []string{zoneNS1, zoneNS2},
 			}
@@ -202,6 +203,7 @@ var _ = Describe("ClusterRRset Controller", func() {
 			recreationResourceComment := "Just a comment"
 			recreationResourceRecords := []string{"127.0.0.1", "127.0.0.2"}
 			recreationResourceZoneName := zoneName
+			waitUntilOlderThanNow(ctx, types.NamespacedName{Name: resourceName}, &dnsv1alpha2.ClusterRRset{})
 
 			By("Creating a RRset")
 			resource := &dnsv1alpha2.RRset{
@@ -238,6 +240,19 @@ var _ = Describe("ClusterRRset Controller", func() {
 				err := k8sClient.Get(ctx, typeNamespacedName, recreatedRrset)
 				return err == nil && recreatedRrset.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
 			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(recreatedRrset.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(RrsetReasonDuplicated))
+			Expect(condition.Message).To(Equal(RrsetMessageDuplicated+"ClusterRRset "+resourceName), "the message should name the synchronized ClusterRRset")
+
+			By("Deleting the duplicated RRset")
+			Expect(k8sClient.Delete(ctx, recreatedRrset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, recreatedRrset)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromRecordsMap(makeCanonical(resourceName))
+			Expect(found).To(BeTrue(), "the RRset synchronized by the existing ClusterRRset should not be deleted")
 		})
 	})
 })
@@ -17,10 +17,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 )
@@ -40,6 +43,7 @@ func init() {
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones/finalizers,verbs=update
+//+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonetemplates,verbs=get;list;watch
 
 func (r *ClusterZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -86,7 +90,7 @@ func (r *ClusterZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one Zone/ClusterZone exists for one DNS entry
+	// Synchronized ClusterZones are indexed by DNS entry, to find the parent, catalog or duplicates of a zone
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.Entry.Name", func(rawObj client.Object) []string {
 		// grab the ClusterZone object, extract its name...
 		var ZoneName string
@@ -97,9 +101,85 @@ func (r *ClusterZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	// All the ClusterZones are indexed by DNS entry, to elect the one synchronized among duplicates
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.Entry.Claim", zoneClaimIndexer); err != nil {
+		return err
+	}
+	// Members are indexed by catalog, to maintain the members of a catalog in its status
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.Catalog", catalogIndexer); err != nil {
+		return err
+	}
+	// ClusterZones are indexed by their parent domains, to move their delegation when a parent zone changes
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.Parents", parentZonesIndexer); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.ClusterZone{}).
 		Owns(&dnsv1alpha2.ClusterRRset{}).
 		Owns(&dnsv1alpha2.RRset{}).
+		// The related zones only depend on the spec and the synchronization status of a Zone/ClusterZone
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findChildClusterZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogClusterZone), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogClusterZone), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogMembers), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogMembers), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ZoneTemplate{}, handler.EnqueueRequestsFromMapFunc(r.findClusterZonesForTemplate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedClusterZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedClusterZones), builder.WithPredicates(zoneChangedPredicate)).
 		Complete(r)
 }
+
+// findClusterZonesForTemplate enqueues the ClusterZones using a ZoneTemplate, to apply its defaults and render its RRsets again
+func (r *ClusterZoneReconciler) findClusterZonesForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ClusterZoneList
+	if err := r.List(ctx, &zones); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterZones")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, z := range zones.Items {
+		if templateName(&z) == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+		}
+	}
+	return requests
+}
+
+// findCatalogMembers enqueues the ClusterZones which are members of a Zone/ClusterZone,
+// they may be waiting for this catalog to be available
+func (r *ClusterZoneReconciler) findCatalogMembers(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ClusterZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"ClusterZone.Catalog": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterZones")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(zones.Items))
+	for _, z := range zones.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+	}
+	return requests
+}
+
+// findCatalogClusterZone enqueues the ClusterZone named after the catalog of a Zone/ClusterZone, its members may have changed
+func (r *ClusterZoneReconciler) findCatalogClusterZone(_ context.Context, obj client.Object) []reconcile.Request {
+	zone, ok := obj.(dnsv1alpha2.GenericZone)
+	if !ok || catalogName(zone) == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: catalogName(zone)}}}
+}
+
+// findChildClusterZones enqueues the ClusterZones which are subdomains of a ClusterZone,
+// their delegation may have to be moved to, or from, this parent zone
+func (r *ClusterZoneReconciler) findChildClusterZones(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ClusterZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"ClusterZone.Parents": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterZones")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(zones.Items))
+	for _, z := range zones.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+	}
+	return requests
+}
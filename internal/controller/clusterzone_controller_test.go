@@ -15,13 +15,16 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,9 +47,33 @@ var _ = Describe("ClusterZone Controller", func() {
 		Name: resourceName,
 	}
 
+	catalogNamespacedName := types.NamespacedName{
+		Name: strings.TrimSuffix(resourceCatalog, "."),
+	}
+
 	BeforeEach(func() {
 		ctx := context.Background()
 
+		By("creating the catalog ClusterZone resource")
+		catalog := &dnsv1alpha2.ClusterZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: catalogNamespacedName.Name,
+			},
+		}
+		catalog.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, catalog, func() error {
+			catalog.Spec.ZoneSpec = dnsv1alpha2.ZoneSpec{
+				Kind:        PRODUCER_KIND_ZONE,
+				Nameservers: resourceNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, catalogNamespacedName, catalog)
+			return err == nil && catalog.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+
 		By("creating the ClusterZone resource")
 		resource := &dnsv1alpha2.ClusterZone{
 			ObjectMeta: metav1.ObjectMeta{
@@ -54,8 +81,8 @@ var _ = Describe("ClusterZone Controller", func() {
 			},
 		}
 		resource.SetResourceVersion("")
-		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
-			resource.Spec = dnsv1alpha2.ZoneSpec{
+		_, err = controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
+			resource.Spec.ZoneSpec = dnsv1alpha2.ZoneSpec{
 				Kind:        resourceKind,
 				Nameservers: resourceNameservers,
 				Catalog:     ptr.To(resourceCatalog),
@@ -97,6 +124,15 @@ var _ = Describe("ClusterZone Controller", func() {
 			_, found := readFromZonesMap(makeCanonical(resourceName))
 			return found
 		}, timeout, interval).Should(BeFalse())
+
+		By("Cleanup the catalog ClusterZone")
+		catalog := &dnsv1alpha2.ClusterZone{}
+		Expect(k8sClient.Get(ctx, catalogNamespacedName, catalog)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, catalog)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, catalogNamespacedName, catalog)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
 	})
 
 	Context("When existing resource", func() {
@@ -115,7 +151,8 @@ var _ = Describe("ClusterZone Controller", func() {
 			Expect(getMockedNameservers(resourceName)).To(Equal(resourceNameservers), "Nameservers should be equal")
 			Expect(getMockedCatalog(resourceName)).To(Equal(resourceCatalog), "Catalog should be equal")
 			Expect(clusterzone.GetFinalizers()).To(ContainElement(RESOURCES_FINALIZER_NAME), "Zone should contain the finalizer")
-			Expect(fmt.Sprintf("%d", *(clusterzone.Status.Serial))).To(Equal(fmt.Sprintf("%s01", time.Now().UTC().Format("20060102"))), "Serial should be YYYYMMDD01")
+			// The serial is incremented by the delegation of the catalog zone, a subdomain of the zone
+			Expect(fmt.Sprintf("%d", *(clusterzone.Status.Serial))).To(HavePrefix(time.Now().UTC().Format("20060102")), "Serial should be YYYYMMDDnn")
 		})
 	})
 	Context("When creating a Zone with an existing ClusterZone with same FQDN", func() {
@@ -127,6 +164,7 @@ var _ = Describe("ClusterZone Controller", func() {
 			recreationResourceKind := NATIVE_KIND_ZONE
 			recreationResourceCatalog := "catalog.example4.org."
 			recreationResourceNameservers := []string{"ns1.example4.org", "ns2.example4.org"}
+			waitUntilOlderThanNow(ctx, types.NamespacedName{Name: resourceName}, &dnsv1alpha2.ClusterZone{})
 
 			By("Creating a Zone")
 			resource := &dnsv1alpha2.Zone{
@@ -157,6 +195,101 @@ var _ = Describe("ClusterZone Controller", func() {
 				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
 				return err == nil && updatedZone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
 			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(updatedZone.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(ZoneReasonDuplicated))
+			Expect(condition.Message).To(Equal(ZoneMessageDuplicated+"ClusterZone "+resourceName), "the message should name the synchronized ClusterZone")
+
+			By("Deleting the duplicated Zone")
+			Expect(k8sClient.Delete(ctx, updatedZone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromZonesMap(makeCanonical(recreationResourceName))
+			Expect(found).To(BeTrue(), "the zone synchronized by the existing ClusterZone should not be deleted")
+		})
+	})
+	Context("When creating a Zone member of a catalog", func() {
+		It("should wait for the catalog, list the members and protect the catalog from deletion", Label("zone-creation", "catalog"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			catalogName := "catalog.example14.org"
+			memberNamespace := "example1"
+			memberName := "example14.org"
+			nameservers := []string{"ns1.example14.org", "ns2.example14.org"}
+
+			By("Creating a Zone member of a non-existing catalog")
+			member := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      memberName,
+					Namespace: memberNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        MASTER_KIND_ZONE,
+					Nameservers: nameservers,
+					Catalog:     ptr.To(catalogName + "."),
+				},
+			}
+			Expect(k8sClient.Create(ctx, member)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(member), member)
+				return err == nil && member.IsInExpectedStatus(FIRST_GENERATION, PENDING_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromZonesMap(makeCanonical(memberName))
+			Expect(found).To(BeFalse(), "Zone should not be created before its catalog")
+
+			By("Creating the catalog as a Native ClusterZone")
+			catalog := &dnsv1alpha2.ClusterZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: catalogName,
+				},
+				Spec: dnsv1alpha2.ClusterZoneSpec{
+					ZoneSpec: dnsv1alpha2.ZoneSpec{
+						Kind:        NATIVE_KIND_ZONE,
+						Nameservers: nameservers,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, catalog)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(member), member)
+				return err == nil && member.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(member.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonInvalidCatalog))
+
+			By("Turning the catalog into a Producer ClusterZone")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(catalog), catalog)).To(Succeed())
+			catalog.Spec.Kind = PRODUCER_KIND_ZONE
+			Expect(k8sClient.Update(ctx, catalog)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(member), member)
+				return err == nil && member.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedCatalog(memberName)).To(Equal(catalogName + "."))
+			Eventually(func() []string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(catalog), catalog)
+				return catalog.Status.Members
+			}, timeout, interval).Should(Equal([]string{memberName}))
+
+			By("Deleting the catalog while it still has members")
+			Expect(k8sClient.Delete(ctx, catalog)).To(Succeed())
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(catalog), catalog)
+				if condition := meta.FindStatusCondition(catalog.Status.Conditions, "Available"); condition != nil {
+					return condition.Reason
+				}
+				return ""
+			}, timeout, interval).Should(Equal(ZoneReasonCatalogHasMembers))
+			_, found = readFromZonesMap(makeCanonical(catalogName))
+			Expect(found).To(BeTrue(), "Catalog should not be deleted while it has members")
+
+			By("Deleting the member")
+			Expect(k8sClient.Delete(ctx, member)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(catalog), catalog)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
 		})
 	})
 })
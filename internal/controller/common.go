@@ -13,6 +13,7 @@ package controller
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,7 +30,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (_ ctrl.Result, err error) {
+	ctx, span := startSpan(ctx, "zoneReconcile", zoneAttributes(gz)...)
+	defer func() { endSpan(span, err) }()
+
 	isInFailedStatus := (gz.GetStatus().SyncStatus != nil && *gz.GetStatus().SyncStatus == FAILED_STATUS)
 
 	// examine DeletionTimestamp to determine if object is under deletion
@@ -47,12 +51,51 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 		// The object is being deleted
 		finalizerRemoved := false
 		if controllerutil.ContainsFinalizer(gz, RESOURCES_FINALIZER_NAME) {
-			// our finalizer is present, so lets handle any external dependency
-			if err := deleteZoneExternalResources(ctx, gz, PDNSClient, log); err != nil {
-				// if fail to delete the external resource, return with error
-				// so that it can be retried
+			// A duplicated zone does not delete the zone synchronized by the elected one
+			elected, err := electedZone(ctx, gz, cl)
+			if err != nil {
+				log.Error(err, "unable to find Zone related to the DNS Name")
 				return ctrl.Result{}, err
 			}
+			// An observed zone is never deleted from PowerDNS
+			if !isObserved(gz) && elected.GetUID() == gz.GetUID() {
+				// A catalog is only deleted once it has no more members
+				members, err := catalogMembers(ctx, gz, cl)
+				if err != nil {
+					log.Error(err, "unable to find the members of the catalog")
+					return ctrl.Result{}, err
+				}
+				if len(members) > 0 {
+					original := gz.Copy()
+					status := gz.GetStatus()
+					status.Members = members
+					meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+						Reason:             ZoneReasonCatalogHasMembers,
+						Message:            ZoneMessageCatalogHasMembers + strings.Join(members, ", "),
+					})
+					gz.SetStatus(status)
+					if err := cl.Status().Patch(ctx, gz, client.MergeFrom(original)); err != nil {
+						log.Error(err, "unable to patch Zone status")
+						return ctrl.Result{}, err
+					}
+					log.Info("Catalog still has members, waiting for their deletion", "Members", members)
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
+
+				// our finalizer is present, so lets handle any external dependency
+				if err := deleteDelegation(ctx, gz, cl, PDNSClient, log); err != nil {
+					log.Error(err, "Failed to delete delegation from parent zone")
+					return ctrl.Result{}, err
+				}
+				if err := deleteZoneExternalResources(ctx, gz, PDNSClient, log); err != nil {
+					// if fail to delete the external resource, return with error
+					// so that it can be retried
+					return ctrl.Result{}, err
+				}
+			}
 			// remove our finalizer from the list and update it.
 			controllerutil.RemoveFinalizer(gz, RESOURCES_FINALIZER_NAME)
 			finalizerRemoved = true
@@ -73,6 +116,11 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 		return ctrl.Result{}, nil
 	}
 
+	// A Zone previously rejected because of its catalog, template, owner or duplicate, or not found while observed, must be checked again, they may have changed
+	if condition := meta.FindStatusCondition(gz.GetStatus().Conditions, "Available"); condition != nil && (condition.Reason == ZoneReasonInvalidCatalog || condition.Reason == ZoneReasonTemplateFailed || condition.Reason == ZoneReasonForeignOwner || condition.Reason == ZoneReasonNotFound || condition.Reason == ZoneReasonDuplicated) {
+		isModified = true
+	}
+
 	// We cannot exit previously (at the early moments of reconcile), because we have to allow deletion process
 	if isInFailedStatus && !isModified {
 		// Update resource metrics
@@ -80,26 +128,16 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 		return ctrl.Result{}, nil
 	}
 
-	// If a Zone already exists with the same DNS name:
-	// * Stop reconciliation
-	// * Append a Failed Status on Zone
-	var existingZones dnsv1alpha2.ZoneList
-	if err := cl.List(ctx, &existingZones, client.MatchingFields{"Zone.Entry.Name": gz.GetName()}); err != nil {
+	// If other Zones/ClusterZones exist with the same DNS name (e.g. example.com in namespaces example1 and example3,
+	// or a Zone and a ClusterZone example.com), only the oldest one is synchronized:
+	// * Stop reconciliation of the other ones
+	// * Append a Failed Status on them, naming the elected one
+	elected, err := electedZone(ctx, gz, cl)
+	if err != nil {
 		log.Error(err, "unable to find Zone related to the DNS Name")
 		return ctrl.Result{}, err
 	}
-	var existingClusterZones dnsv1alpha2.ClusterZoneList
-	if err := cl.List(ctx, &existingClusterZones, client.MatchingFields{"ClusterZone.Entry.Name": gz.GetName()}); err != nil {
-		log.Error(err, "unable to find ClusterZone related to the DNS Name")
-		return ctrl.Result{}, err
-	}
-
-	// Multiple use-cases:
-	// 1 Zone (example.com in NS example1) + 1 Zone (example.com in NS example3)
-	// In that case: len(existingZones.Items) > 1
-	// 1 Zone (example.com in NS example1) + 1 ClusterZone (example.com)
-	// In that case: len(existingZones.Items) >= 1 AND len(existingClusterZones.Items) >= 1
-	if len(existingZones.Items) > 1 || (len(existingZones.Items) >= 1 && len(existingClusterZones.Items) >= 1) {
+	if elected.GetUID() != gz.GetUID() {
 		original := gz.Copy()
 		conditions := gz.GetStatus().Conditions
 		meta.SetStatusCondition(&conditions, metav1.Condition{
@@ -107,7 +145,7 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 			Status:             metav1.ConditionFalse,
 			LastTransitionTime: metav1.Time{Time: time.Now().UTC()},
 			Reason:             ZoneReasonDuplicated,
-			Message:            ZoneMessageDuplicated,
+			Message:            ZoneMessageDuplicated + objectReference(elected),
 		})
 		gz.SetStatus(dnsv1alpha2.ZoneStatus{
 			SyncStatus:         ptr.To(FAILED_STATUS),
@@ -125,17 +163,115 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 		return ctrl.Result{}, nil
 	}
 
+	// An observed zone is only read from PowerDNS
+	if isObserved(gz) {
+		return observeZoneReconcile(ctx, gz, cl, PDNSClient, log)
+	}
+
+	// The catalog must be a synchronized "Producer" zone before its members are created
+	catalogStatus, catalogMessage, err := checkCatalog(ctx, gz, cl)
+	if err != nil {
+		log.Error(err, "unable to check the catalog of the Zone")
+		return ctrl.Result{}, err
+	}
+	if catalogStatus != nil {
+		original := gz.Copy()
+		status := gz.GetStatus()
+		status.SyncStatus = catalogStatus
+		status.ObservedGeneration = ptr.To(gz.GetGeneration())
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+			Reason:             ZoneReasonInvalidCatalog,
+			Message:            catalogMessage,
+		})
+		gz.SetStatus(status)
+		if err := cl.Status().Patch(ctx, gz, client.MergeFrom(original)); err != nil {
+			log.Error(err, "unable to patch Zone status")
+			return ctrl.Result{}, err
+		}
+
+		// Update resource metrics
+		updateZonesMetrics(gz)
+
+		if *catalogStatus == PENDING_STATUS {
+			// Catalog and member zones may be created at the same time, requeue after few seconds
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// The SOA fields and NS TTL not set on the zone are taken from its ZoneTemplate
+	desired, err := withTemplateDefaults(ctx, gz, cl)
+	if err != nil {
+		log.Error(err, "unable to get the ZoneTemplate of the Zone")
+		return ctrl.Result{}, err
+	}
+
 	// Get zone
 	zoneRes, err := getZoneExternalResources(ctx, gz.GetObjectMeta().Name, PDNSClient, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	syncStatus, conditionMessage, conditionReason, conditionStatus, err := zoneExternalResourcesReconcile(ctx, zoneRes, gz, PDNSClient, log)
+	syncStatus, conditionMessage, conditionReason, conditionStatus, err := zoneExternalResourcesReconcile(ctx, zoneRes, desired, PDNSClient, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Synchronize the SOA record, once the zone exists
+	var soa *dnsv1alpha2.SOAStatus
+	if syncStatus == nil {
+		soa, err = reconcileSOA(ctx, desired, PDNSClient, log)
+		if err != nil {
+			log.Error(err, "Failed to synchronize SOA record")
+			syncStatus = ptr.To(FAILED_STATUS)
+			conditionStatus = metav1.ConditionFalse
+			conditionReason = ZoneReasonSOASynchronizationFailed
+			conditionMessage = err.Error()
+		}
+	}
+
+	// Delegate the zone from its closest parent zone
+	delegation := gz.GetStatus().Delegation
+	if syncStatus == nil {
+		if delegation, err = reconcileDelegation(ctx, desired, cl, PDNSClient, log); err != nil {
+			log.Error(err, "Failed to delegate zone from parent zone")
+			syncStatus = ptr.To(FAILED_STATUS)
+			conditionStatus = metav1.ConditionFalse
+			conditionReason = ZoneReasonDelegationFailed
+			if isForeignOwnerError(err) {
+				conditionReason = ZoneReasonForeignOwner
+			}
+			conditionMessage = err.Error()
+		}
+	}
+
+	// Render the RRsets of the zone template
+	if syncStatus == nil {
+		if err := reconcileTemplate(ctx, gz, cl, log); err != nil {
+			log.Error(err, "Failed to render zone template")
+			syncStatus = ptr.To(FAILED_STATUS)
+			conditionStatus = metav1.ConditionFalse
+			conditionReason = ZoneReasonTemplateFailed
+			conditionMessage = err.Error()
+		}
+	}
+
+	// Delete the RRsets not declared in Kubernetes, with the "Authoritative" records policy
+	var unmanaged []string
+	if syncStatus == nil {
+		unmanaged, err = reconcilePrune(ctx, gz, cl, PDNSClient, log)
+		if err != nil {
+			log.Error(err, "Failed to prune RRsets")
+			syncStatus = ptr.To(FAILED_STATUS)
+			conditionStatus = metav1.ConditionFalse
+			conditionReason = ZoneReasonPruneFailed
+			conditionMessage = err.Error()
+		}
+	}
+
 	if syncStatus == nil {
 		syncStatus = ptr.To(SUCCEEDED_STATUS)
 	}
@@ -145,8 +281,13 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	members, err := catalogMembers(ctx, gz, cl)
+	if err != nil {
+		log.Error(err, "unable to find the members of the catalog")
+		return ctrl.Result{}, err
+	}
 
-	err = patchZoneStatus(ctx, gz, zoneRes, syncStatus, cl, metav1.Condition{
+	err = patchZoneStatus(ctx, gz, zoneRes, members, soa, delegation, unmanaged, syncStatus, cl, metav1.Condition{
 		Type:               "Available",
 		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
 		Status:             conditionStatus,
@@ -164,11 +305,23 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 	// Update resource metrics
 	updateZonesMetrics(gz)
 
+	// RRSets may be added to PowerDNS outside of Kubernetes, they are looked for periodically
+	if isAuthoritative(gz) {
+		return ctrl.Result{RequeueAfter: settings.PrunePeriod}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
-func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, lastUpdateTime *metav1.Time, scheme *runtime.Scheme, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, lastUpdateTime *metav1.Time, scheme *runtime.Scheme, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (_ ctrl.Result, err error) {
+	ctx, span := startSpan(ctx, "rrsetReconcile", rrsetAttributes(gr)...)
+	defer func() { endSpan(span, err) }()
+
 	isInFailedStatus := (gr.GetStatus().SyncStatus != nil && *gr.GetStatus().SyncStatus == FAILED_STATUS)
+	// A RRset refused because of a foreign RRSet, or not found in an observed zone, is checked again, the RRSet may have been removed from, or added to, PowerDNS since.
+	// A duplicated RRset is checked again too, the elected one may have been deleted since.
+	if condition := meta.FindStatusCondition(gr.GetStatus().Conditions, "Available"); condition != nil && (condition.Reason == RrsetReasonForeignRRset || condition.Reason == RrsetReasonForeignOwner || condition.Reason == RrsetReasonNotFound || condition.Reason == RrsetReasonDuplicated) {
+		isModified = true
+	}
 
 	// initialize syncStatus
 	var syncStatus *string
@@ -194,12 +347,12 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 		finalizerRemoved := false
 		if controllerutil.ContainsFinalizer(gr, RESOURCES_FINALIZER_NAME) {
 			// our finalizer is present, so lets handle any external dependency
-			if err := deleteRrsetExternalResources(ctx, zone, gr, PDNSClient, log); err != nil {
+			if err := deleteRrsetPublishedResources(ctx, gr, zone, cl, PDNSClient, log); err != nil {
 				// if fail to delete the external resource, return with error
 				// so that it can be retried
-				log.Error(err, "Failed to delete external resources")
 				return ctrl.Result{}, err
 			}
+			forgetTargetsHealth(gr)
 			// remove our finalizer from the list.
 			controllerutil.RemoveFinalizer(gr, RESOURCES_FINALIZER_NAME)
 			finalizerRemoved = true
@@ -230,26 +383,16 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 		return ctrl.Result{}, nil
 	}
 
-	// If a RRset already exists with the same DNS name:
-	// * Stop reconciliation
-	// * Append a Failed Status on RRset
-	var existingRRsets dnsv1alpha2.RRsetList
-	if err := cl.List(ctx, &existingRRsets, client.MatchingFields{"RRset.Entry.Name": getRRsetName(gr) + "/" + gr.GetSpec().Type}); err != nil {
-		log.Error(err, "unable to find RRsets related to the DNS Name")
-		return ctrl.Result{}, err
-	}
-	var existingClusterRRsets dnsv1alpha2.ClusterRRsetList
-	if err := cl.List(ctx, &existingClusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Name": getRRsetName(gr) + "/" + gr.GetSpec().Type}); err != nil {
+	// If other RRsets/ClusterRRsets exist with the same DNS name and type (e.g. test.example.com in namespaces example1 and example3,
+	// or a RRset and a ClusterRRset test.example.com), only the oldest one is synchronized:
+	// * Stop reconciliation of the other ones
+	// * Append a Failed Status on them, naming the elected one
+	elected, err := electedRRset(ctx, gr, cl)
+	if err != nil {
 		log.Error(err, "unable to find RRsets related to the DNS Name")
 		return ctrl.Result{}, err
 	}
-
-	// Multiple use-cases:
-	// 1 RRset (test.example.com in NS example1) + 1 RRset (test.example.com in NS example3)
-	// In that case: len(existingRRsets.Items) > 1
-	// 1 RRset (test.example.com in NS example1) + 1 ClusterRRset (test.example.com)
-	// In that case: len(existingRRsets.Items) >= 1 AND len(existingClusterRRsets.Items) >= 1
-	if len(existingRRsets.Items) > 1 || (len(existingRRsets.Items) >= 1 && len(existingClusterRRsets.Items) >= 1) {
+	if elected.GetUID() != gr.GetUID() {
 		original := gr.Copy()
 		conditions := gr.GetStatus().Conditions
 		meta.SetStatusCondition(&conditions, metav1.Condition{
@@ -257,7 +400,7 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 			Status:             metav1.ConditionFalse,
 			LastTransitionTime: *lastUpdateTime,
 			Reason:             RrsetReasonDuplicated,
-			Message:            RrsetMessageDuplicated,
+			Message:            RrsetMessageDuplicated + objectReference(elected),
 		})
 		name := getRRsetName(gr)
 		gr.SetStatus(dnsv1alpha2.RRsetStatus{
@@ -266,6 +409,7 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 			SyncStatus:         ptr.To(FAILED_STATUS),
 			ObservedGeneration: &gr.GetObjectMeta().Generation,
 			Conditions:         conditions,
+			PTRRecords:         gr.GetStatus().PTRRecords,
 		})
 		if err := cl.Status().Patch(ctx, gr, client.MergeFrom(original)); err != nil {
 			log.Error(err, "unable to patch RRSet status")
@@ -278,19 +422,52 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 		return ctrl.Result{}, nil
 	}
 
+	// A RRset referencing an observed zone only reports the records of PowerDNS
+	if isObserved(zone) {
+		return observeRRsetReconcile(ctx, gr, zone, lastUpdateTime, scheme, cl, PDNSClient, log)
+	}
+
+	// Health checks, the records of the addresses which are not published are withdrawn from PowerDNS
+	healthChecks, nextHealthCheck, consumeHealthResults := checkTargetsHealth(ctx, gr)
+	checkedRRset := gr.Copy()
+	checkedStatus := checkedRRset.GetStatus()
+	checkedStatus.HealthChecks = healthChecks
+	checkedRRset.SetStatus(checkedStatus)
+
 	// Create or Update
-	changed, err := createOrUpdateRrsetExternalResources(ctx, zone, gr, PDNSClient)
+	changed, err := createOrUpdateRrsetExternalResources(ctx, zone, checkedRRset, PDNSClient)
 	if err != nil {
 		log.Error(err, "Failed to create or update external resources")
 		syncStatus = ptr.To(FAILED_STATUS)
 		conditionStatus = metav1.ConditionFalse
 		conditionReason = RrsetReasonSynchronizationFailed
 		conditionMessage = err.Error()
+		if isForeignRRsetError(err) {
+			conditionReason = RrsetReasonForeignRRset
+			conditionMessage = err.Error() + ", set takeOver to replace it"
+		}
+		if isForeignOwnerError(err) {
+			conditionReason = RrsetReasonForeignOwner
+		}
 	}
 	if changed {
 		lastUpdateTime = &metav1.Time{Time: time.Now().UTC()}
 	}
 
+	// PTR records
+	ptrRecords := gr.GetStatus().PTRRecords
+	if syncStatus == nil {
+		ptrRecords, err = reconcilePTRRecords(ctx, checkedRRset, cl, PDNSClient, log)
+		if err != nil {
+			log.Error(err, "Failed to synchronize PTR records")
+			ptrRecords = gr.GetStatus().PTRRecords
+			syncStatus = ptr.To(FAILED_STATUS)
+			conditionStatus = metav1.ConditionFalse
+			conditionReason = RrsetReasonPTRSyncFailed
+			conditionMessage = err.Error()
+		}
+	}
+
 	// Set OwnerReference
 	if err := ownObject(ctx, zone, gr, scheme, cl, log); err != nil {
 		if errors.IsConflict(err) {
@@ -324,16 +501,21 @@ func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1
 		DnsEntryName:       &name,
 		SyncStatus:         syncStatus,
 		ObservedGeneration: &gr.GetObjectMeta().Generation,
+		Conditions:         conditions,
+		PTRRecords:         ptrRecords,
+		HealthChecks:       healthChecks,
 	})
 	if err := cl.Status().Patch(ctx, gr, client.MergeFrom(original)); err != nil {
 		log.Error(err, "unable to patch RRSet status")
 		return ctrl.Result{}, err
 	}
+	consumeHealthResults()
 
 	// Metrics calculation
 	updateRrsetsMetrics(getRRsetName(gr), gr)
 
-	return ctrl.Result{}, nil
+	// The addresses are checked again after the interval of the health check
+	return ctrl.Result{RequeueAfter: nextHealthCheck}, nil
 }
 
 func getZoneExternalResources(ctx context.Context, domain string, PDNSClient PdnsClienter, log logr.Logger) (*powerdns.Zone, error) {
@@ -367,6 +549,7 @@ func createZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZo
 		SOAEditAPI:  zone.GetSpec().SOAEditAPI,
 		Nameservers: zone.GetSpec().Nameservers,
 		Catalog:     catalog,
+		Account:     ptr.To(operatorAccount(PDNSClient.OwnerID)),
 	}
 
 	_, err := PDNSClient.Zones.Add(ctx, &z)
@@ -393,6 +576,7 @@ func updateZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZo
 		Nameservers: zone.GetSpec().Nameservers,
 		Catalog:     catalog,
 		SOAEditAPI:  zone.GetSpec().SOAEditAPI,
+		Account:     ptr.To(operatorAccount(PDNSClient.OwnerID)),
 	})
 	if err != nil {
 		log.Error(err, "Failed to update zone")
@@ -416,7 +600,17 @@ func updateNsOnZoneExternalResources(ctx context.Context, zone dnsv1alpha2.Gener
 }
 
 func deleteZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) error {
-	err := PDNSClient.Zones.Delete(ctx, zone.GetObjectMeta().Name)
+	// A Zone owned by another operator instance is left untouched
+	zoneRes, err := getZoneExternalResources(ctx, zone.GetObjectMeta().Name, PDNSClient, log)
+	if err != nil {
+		return err
+	}
+	if err := checkZoneOwnership(zone, zoneRes, PDNSClient.OwnerID); err != nil {
+		log.Info("Zone managed by another operator instance, skipping deletion", "Reason", err.Error())
+		return nil
+	}
+
+	err = PDNSClient.Zones.Delete(ctx, zone.GetObjectMeta().Name)
 	// Zone may have already been deleted and it is not an error
 	if err != nil && err.Error() != ZONE_NOT_FOUND_MSG {
 		log.Error(err, "Failed to delete zone")
@@ -435,6 +629,10 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 	if zoneRes.Name == nil {
 		// If Zone does not exist, create it
 		err := createZoneExternalResources(ctx, gz, PDNSClient, log)
+		// The NS records are created by PowerDNS with its default TTL
+		if err == nil && gz.GetSpec().NameserversTTL != nil {
+			err = updateNsOnZoneExternalResources(ctx, gz, *gz.GetSpec().NameserversTTL, PDNSClient, log)
+		}
 		if err != nil {
 			log.Error(err, "Failed to create external resources")
 			syncStatus = ptr.To(FAILED_STATUS)
@@ -443,6 +641,12 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 			conditionMessage = err.Error()
 		}
 	} else {
+		// A Zone owned by another operator instance is left untouched
+		if err := checkZoneOwnership(gz, zoneRes, PDNSClient.OwnerID); err != nil {
+			log.Info("Zone managed by another operator instance", "Reason", err.Error())
+			return ptr.To(FAILED_STATUS), err.Error(), ZoneReasonForeignOwner, metav1.ConditionFalse, nil
+		}
+
 		// If Zone exists, compare content and update it if necessary
 		ns, err := PDNSClient.Records.Get(ctx, gz.GetObjectMeta().Name, gz.GetObjectMeta().Name, ptr.To(powerdns.RRTypeNS))
 		if err != nil {
@@ -466,14 +670,17 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 		// Workflow is different on update types:
 		// Nameservers changes  => patch RRSet
 		// Other changes        => patch Zone
-		zoneIdentical, nsIdentical := zoneIsIdenticalToExternalZone(gz, zoneRes, nameservers)
+		zoneIdentical, nsIdentical := zoneIsIdenticalToExternalZone(gz, zoneRes, nameservers, ptr.Deref(filteredRRset.TTL, 0))
 
 		// Nameservers changes
 		if !nsIdentical {
-			ttl := ptr.To(DEFAULT_TTL_FOR_NS_RECORDS)
+			ttl := ptr.To(settings.NameserversTTL)
 			if filteredRRset.TTL != nil {
 				ttl = filteredRRset.TTL
 			}
+			if gz.GetSpec().NameserversTTL != nil {
+				ttl = gz.GetSpec().NameserversTTL
+			}
 			err := updateNsOnZoneExternalResources(ctx, gz, *ttl, PDNSClient, log)
 			if err != nil {
 				syncStatus = ptr.To(FAILED_STATUS)
@@ -482,8 +689,8 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 				conditionMessage = err.Error()
 			}
 		}
-		// Other changes
-		if !zoneIdentical {
+		// Other changes, the account of the zone marks it as owned by the operator
+		if !zoneIdentical || ptr.Deref(zoneRes.Account, "") != operatorAccount(PDNSClient.OwnerID) {
 			err := updateZoneExternalResources(ctx, gz, PDNSClient, log)
 			if err != nil {
 				syncStatus = ptr.To(FAILED_STATUS)
@@ -496,7 +703,7 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 	return syncStatus, conditionMessage, conditionReason, conditionStatus, nil
 }
 
-func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, status *string, cl client.Client, condition metav1.Condition) error {
+func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, members []string, soa *dnsv1alpha2.SOAStatus, delegation *dnsv1alpha2.DelegationStatus, unmanaged []string, status *string, cl client.Client, condition metav1.Condition) error {
 	original := zone.Copy()
 
 	kind := string(ptr.Deref(zoneRes.Kind, ""))
@@ -513,14 +720,58 @@ func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes
 		DNSsec:             zoneRes.DNSsec,
 		SyncStatus:         status,
 		Catalog:            zoneRes.Catalog,
+		Members:            members,
+		RecordsCount:       recordsCount(zoneRes),
+		SOA:                soa,
+		Delegation:         delegation,
+		UnmanagedRRsets:    unmanaged,
 		ObservedGeneration: ptr.To(zone.GetGeneration()),
 		Conditions:         conditions,
 	})
 	return cl.Status().Patch(ctx, zone, client.MergeFrom(original))
 }
 
+// deleteRrsetPublishedResources deletes the records and PTR records published for the RRset,
+// unless they are synchronized by another RRset elected among duplicates, or they belong to an observed zone
+func deleteRrsetPublishedResources(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	if isObserved(zone) {
+		return nil
+	}
+	elected, err := electedRRset(ctx, gr, cl)
+	if err != nil {
+		log.Error(err, "unable to find RRsets related to the DNS Name")
+		return err
+	}
+	if elected.GetUID() != gr.GetUID() {
+		return nil
+	}
+	if err := deletePTRRecords(ctx, gr, PDNSClient); err != nil {
+		log.Error(err, "Failed to delete PTR records")
+		return err
+	}
+	if err := deleteRrsetExternalResources(ctx, zone, gr, PDNSClient, log); err != nil {
+		log.Error(err, "Failed to delete external resources")
+		return err
+	}
+	return nil
+}
+
 func deleteRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter, log logr.Logger) error {
-	err := PDNSClient.Records.Delete(ctx, zone.GetObjectMeta().Name, getRRsetName(rrset), powerdns.RRType(rrset.GetSpec().Type))
+	// A RRSet of PowerDNS not owned by the operator is left untouched
+	rrType := powerdns.RRType(rrset.GetSpec().Type)
+	existing, err := getExternalRRset(ctx, PDNSClient, zone.GetObjectMeta().Name, getRRsetName(rrset), rrType)
+	if err != nil {
+		log.Error(err, "Failed to get record")
+		return err
+	}
+	if existing != nil {
+		if err := checkRRsetOwnership(rrset, *existing, PDNSClient.OwnerID); err != nil {
+			log.Info("Record not managed by the operator, skipping deletion", "Reason", err.Error())
+			return nil
+		}
+	}
+
+	err = PDNSClient.Records.Delete(ctx, zone.GetObjectMeta().Name, getRRsetName(rrset), powerdns.RRType(rrset.GetSpec().Type))
 	if err != nil {
 		log.Error(err, "Failed to delete record")
 		return err
@@ -532,6 +783,16 @@ func deleteRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZ
 func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter) (bool, error) {
 	name := getRRsetName(rrset)
 	rrType := powerdns.RRType(rrset.GetSpec().Type)
+	content, err := rrsetRecords(rrset)
+	if err != nil {
+		return false, err
+	}
+	// LUA records are only served by zones with LUA records enabled
+	if rrType == LUA_RECORD_TYPE {
+		if err := enableLuaRecords(ctx, zone, PDNSClient); err != nil {
+			return false, err
+		}
+	}
 	// Looking for a record with same Name and Type
 	records, err := PDNSClient.Records.Get(ctx, zone.GetObjectMeta().Name, name, &rrType)
 	if err != nil && !errors.IsNotFound(err) {
@@ -547,17 +808,18 @@ func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.
 			break
 		}
 	}
-	if filteredRecord.Name != nil && rrsetIsIdenticalToExternalRRset(rrset, filteredRecord) {
-		return false, nil
+	if filteredRecord.Name != nil {
+		if err := checkRRsetOwnership(rrset, filteredRecord, PDNSClient.OwnerID); err != nil {
+			return false, err
+		}
+		// A RRSet not yet marked as owned is written again to set the comment of the operator
+		if isOwnedRRset(filteredRecord, PDNSClient.OwnerID) && rrsetIsIdenticalToExternalRRset(rrset, filteredRecord) {
+			return false, nil
+		}
 	}
 
 	// Create or Update
-	operatorAccount := "powerdns-operator"
-	comments := func(*powerdns.RRset) {}
-	if rrset.GetSpec().Comment != nil {
-		comments = powerdns.WithComments(powerdns.Comment{Content: rrset.GetSpec().Comment, Account: &operatorAccount})
-	}
-	err = PDNSClient.Records.Change(ctx, zone.GetObjectMeta().Name, name, rrType, rrset.GetSpec().TTL, rrset.GetSpec().Records, comments)
+	err = PDNSClient.Records.Change(ctx, zone.GetObjectMeta().Name, name, rrType, rrset.GetSpec().TTL, content, powerdns.WithComments(operatorComment(rrset, PDNSClient.OwnerID)))
 	if err != nil {
 		return false, err
 	}
@@ -565,7 +827,24 @@ func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.
 	return true, nil
 }
 
+// enableLuaRecords sets the ENABLE-LUA-RECORDS metadata of the zone, if not already set
+func enableLuaRecords(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter) error {
+	metadata, err := PDNSClient.Metadata.Get(ctx, zone.GetObjectMeta().Name, METADATA_ENABLE_LUA_RECORDS)
+	if err != nil {
+		return err
+	}
+	if metadata != nil && slices.Equal(metadata.Metadata, []string{"1"}) {
+		return nil
+	}
+	_, err = PDNSClient.Metadata.Set(ctx, zone.GetObjectMeta().Name, METADATA_ENABLE_LUA_RECORDS, []string{"1"})
+	return err
+}
+
 func ownObject(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, scheme *runtime.Scheme, cl client.Client, log logr.Logger) error {
+	// Cross-namespace owner references are not allowed
+	if zone.GetNamespace() != "" && zone.GetNamespace() != rrset.GetNamespace() {
+		return nil
+	}
 	err := ctrl.SetControllerReference(zone, rrset, scheme)
 	if err != nil {
 		log.Error(err, "Failed to set owner reference. Is there already a controller managing this object?")
@@ -0,0 +1,402 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
+)
+
+// glueTypes are the types of the address records copied from a child zone to its parent
+var glueTypes = []powerdns.RRType{powerdns.RRTypeA, powerdns.RRTypeAAAA}
+
+// reconcileDelegation maintains, in the closest parent zone managed by the operator,
+// the NS records delegating the zone and the glue records of its in-zone nameservers.
+// The delegation recorded in the status is removed from its parent when another parent zone, or none, delegates the zone.
+// It returns the delegation to record in the status, nil if none.
+func reconcileDelegation(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (*dnsv1alpha2.DelegationStatus, error) {
+	parent, err := findDelegatingZone(ctx, gz, cl, log)
+	if err != nil {
+		return nil, err
+	}
+	former := gz.GetStatus().Delegation
+	var formerGlue []string
+	if former != nil {
+		if parent == nil || makeCanonical(parent.GetName()) != makeCanonical(former.Parent) {
+			log.Info("Removing the delegation from the former parent zone", "Parent", former.Parent)
+			if err := undelegateZone(ctx, former.Parent, gz, PDNSClient, former.Glue, func(name string, rrType powerdns.RRType) (bool, error) {
+				return isManagedByRRset(ctx, cl, former.Parent, name, rrType)
+			}); err != nil && err.Error() != ZONE_NOT_FOUND_MSG {
+				return former, err
+			}
+		} else {
+			formerGlue = former.Glue
+		}
+	}
+	if parent == nil {
+		return nil, nil
+	}
+	glue, err := delegateZone(ctx, parent.GetName(), gz, PDNSClient, formerGlue, func(name string, rrType powerdns.RRType) (bool, error) {
+		return isManagedByRRset(ctx, cl, parent.GetName(), name, rrType)
+	})
+	if err != nil {
+		// The glue records written before the failure are removed by the next reconciliation
+		return &dnsv1alpha2.DelegationStatus{Parent: makeCanonical(parent.GetName()), Glue: mergeGlue(formerGlue, glue)}, err
+	}
+	return &dnsv1alpha2.DelegationStatus{Parent: makeCanonical(parent.GetName()), Glue: glue}, nil
+}
+
+// deleteDelegation removes the NS and glue records of the zone from the parent zone recorded in its status,
+// or else from its closest parent zone managed by the operator
+func deleteDelegation(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	var parent string
+	var glue []string
+	if delegation := gz.GetStatus().Delegation; delegation != nil {
+		parent, glue = delegation.Parent, delegation.Glue
+	} else {
+		parentZone, err := findDelegatingZone(ctx, gz, cl, log)
+		if err != nil || parentZone == nil {
+			return err
+		}
+		parent = parentZone.GetName()
+	}
+	err := undelegateZone(ctx, parent, gz, PDNSClient, glue, func(name string, rrType powerdns.RRType) (bool, error) {
+		return isManagedByRRset(ctx, cl, parent, name, rrType)
+	})
+	if err != nil && err.Error() == ZONE_NOT_FOUND_MSG {
+		return nil
+	}
+	return err
+}
+
+// mergeGlue returns the sorted names of both glue lists, without duplicates
+func mergeGlue(a, b []string) []string {
+	glue := slices.Concat(a, b)
+	slices.Sort(glue)
+	return slices.Compact(glue)
+}
+
+// findDelegatingZone returns the closest synchronized parent Zone/ClusterZone of the zone, if it may delegate to it.
+// A Zone only delegates to Zones of its namespace, a ClusterZone delegates to the Zones of the namespaces it allows.
+func findDelegatingZone(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, log logr.Logger) (dnsv1alpha2.GenericZone, error) {
+	parent, err := findParentZone(ctx, gz.GetName(), cl)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	if isObserved(parent) {
+		log.Info("Parent zone is observed, skipping delegation", "Parent", parent.GetName())
+		return nil, nil
+	}
+
+	switch p := parent.(type) {
+	case *dnsv1alpha2.Zone:
+		if gz.GetNamespace() != p.Namespace {
+			log.Info("Parent Zone is in another namespace, skipping delegation", "Parent", p.Name, "Parent.Namespace", p.Namespace)
+			return nil, nil
+		}
+	case *dnsv1alpha2.ClusterZone:
+		if gz.GetNamespace() == "" {
+			break
+		}
+		ns := &corev1.Namespace{}
+		if err := cl.Get(ctx, client.ObjectKey{Name: gz.GetNamespace()}, ns); err != nil {
+			return nil, err
+		}
+		delegation := &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Namespace: gz.GetNamespace()},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Type:    string(powerdns.RRTypeNS),
+				Name:    makeCanonical(gz.GetName()),
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: p.Name, Kind: "ClusterZone"},
+			},
+		}
+		if err := policy.CheckClusterZoneAccess(p, ns, delegation); err != nil {
+			log.Info("Delegation not allowed by parent ClusterZone, skipping delegation", "Parent", p.Name, "Reason", err.Error())
+			return nil, nil
+		}
+	}
+	return parent, nil
+}
+
+// findParentZone returns the closest synchronized Zone/ClusterZone the zone name is a subdomain of
+func findParentZone(ctx context.Context, name string, cl client.Client) (dnsv1alpha2.GenericZone, error) {
+	return findClosestZone(ctx, parentDomain(name), cl)
+}
+
+// findClosestZone returns the synchronized Zone/ClusterZone named name, or else the closest one it is a subdomain of
+func findClosestZone(ctx context.Context, name string, cl client.Client) (dnsv1alpha2.GenericZone, error) {
+	for parentName := strings.TrimSuffix(name, "."); parentName != ""; parentName = parentDomain(parentName) {
+		var zones dnsv1alpha2.ZoneList
+		if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Name": parentName}); err != nil {
+			return nil, err
+		}
+		for i := range zones.Items {
+			if ptr.Deref(zones.Items[i].Status.SyncStatus, "") == SUCCEEDED_STATUS {
+				return &zones.Items[i], nil
+			}
+		}
+		var clusterZones dnsv1alpha2.ClusterZoneList
+		if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Entry.Name": parentName}); err != nil {
+			return nil, err
+		}
+		for i := range clusterZones.Items {
+			if ptr.Deref(clusterZones.Items[i].Status.SyncStatus, "") == SUCCEEDED_STATUS {
+				return &clusterZones.Items[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isManagedByRRset returns true if a RRset/ClusterRRset manages the name and type in the zone,
+// the operator must not override it with delegation records
+func isManagedByRRset(ctx context.Context, cl client.Client, zone, name string, rrType powerdns.RRType) (bool, error) {
+	key := makeCanonical(name) + "/" + string(rrType)
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets, client.MatchingFields{"RRset.Entry.Name": key}); err != nil {
+		return false, err
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Name": key}); err != nil {
+		return false, err
+	}
+	for _, rr := range rrsets.Items {
+		if makeCanonical(rr.Spec.ZoneRef.Name) == makeCanonical(zone) {
+			return true, nil
+		}
+	}
+	for _, rr := range clusterRRsets.Items {
+		if makeCanonical(rr.Spec.ZoneRef.Name) == makeCanonical(zone) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// delegateZone creates or updates, in the parent zone, the NS records of the child zone
+// and the glue records of the nameservers lying inside the child zone.
+// Glue records of the former nameservers formerGlue are removed.
+// It returns the sorted names of the nameservers whose glue records are written.
+func delegateZone(ctx context.Context, parent string, child dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, formerGlue []string, isManaged func(string, powerdns.RRType) (bool, error)) ([]string, error) {
+	childName := makeCanonical(child.GetName())
+	nameservers := make([]string, 0, len(child.GetSpec().Nameservers))
+	for _, ns := range child.GetSpec().Nameservers {
+		nameservers = append(nameservers, makeCanonical(ns))
+	}
+
+	managed, err := isManaged(childName, powerdns.RRTypeNS)
+	if err != nil {
+		return nil, err
+	}
+	if !managed {
+		if err := changeRRsetIfDifferent(ctx, PDNSClient, parent, childName, powerdns.RRTypeNS, child.GetSpec().NameserversTTL, nameservers, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	glue := map[string]bool{}
+	var written []string
+	for _, ns := range nameservers {
+		if !isSubdomain(ns, childName) {
+			continue
+		}
+		for _, rrType := range glueTypes {
+			address, err := getExternalRRset(ctx, PDNSClient, child.GetName(), ns, rrType)
+			if err != nil {
+				return written, err
+			}
+			if address == nil {
+				continue
+			}
+			glue[ns+"/"+string(rrType)] = true
+			managed, err := isManaged(ns, rrType)
+			if err != nil {
+				return written, err
+			}
+			if managed {
+				continue
+			}
+			if err := changeRRsetIfDifferent(ctx, PDNSClient, parent, ns, rrType, address.TTL, recordsContent(*address), ""); err != nil {
+				return written, err
+			}
+			if !slices.Contains(written, ns) {
+				written = append(written, ns)
+			}
+		}
+	}
+	slices.Sort(written)
+
+	return written, removeGlue(ctx, PDNSClient, parent, childName, slices.Concat(nameservers, formerGlue), func(name string, rrType powerdns.RRType) (bool, error) {
+		if glue[name+"/"+string(rrType)] {
+			return true, nil
+		}
+		return isManaged(name, rrType)
+	})
+}
+
+// undelegateZone removes, from the parent zone, the NS records of the child zone and the glue records
+// of its nameservers and of the former nameservers formerGlue, the records not written by the operator instance are left untouched
+func undelegateZone(ctx context.Context, parent string, child dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, formerGlue []string, isManaged func(string, powerdns.RRType) (bool, error)) error {
+	childName := makeCanonical(child.GetName())
+	nameservers := make([]string, 0, len(child.GetSpec().Nameservers))
+	for _, ns := range child.GetSpec().Nameservers {
+		nameservers = append(nameservers, makeCanonical(ns))
+	}
+
+	managed, err := isManaged(childName, powerdns.RRTypeNS)
+	if err != nil {
+		return err
+	}
+	if !managed {
+		if err := deleteGeneratedRRset(ctx, PDNSClient, parent, childName, powerdns.RRTypeNS); err != nil {
+			return err
+		}
+	}
+	return removeGlue(ctx, PDNSClient, parent, childName, slices.Concat(nameservers, formerGlue), isManaged)
+}
+
+// removeGlue deletes the address records of the nameservers from the parent zone, for the nameservers lying inside the child zone,
+// except the kept ones and the ones not written by the operator instance.
+// Only the RRSets of the nameservers are fetched, not the whole parent zone.
+func removeGlue(ctx context.Context, PDNSClient PdnsClienter, parent, childName string, nameservers []string, keep func(string, powerdns.RRType) (bool, error)) error {
+	checked := map[string]bool{}
+	for _, ns := range nameservers {
+		ns = makeCanonical(ns)
+		if checked[ns] || !isSubdomain(ns, childName) {
+			continue
+		}
+		checked[ns] = true
+		for _, rrType := range glueTypes {
+			rr, err := getExternalRRset(ctx, PDNSClient, parent, ns, rrType)
+			if err != nil {
+				return err
+			}
+			if rr == nil || !isOwnedRRset(*rr, PDNSClient.OwnerID) {
+				continue
+			}
+			kept, err := keep(ns, rrType)
+			if err != nil {
+				return err
+			}
+			if kept {
+				continue
+			}
+			if err := PDNSClient.Records.Delete(ctx, parent, ns, rrType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getExternalRRset returns the RRset with the name and type in the zone, nil if it does not exist
+func getExternalRRset(ctx context.Context, PDNSClient PdnsClienter, zone, name string, rrType powerdns.RRType) (*powerdns.RRset, error) {
+	rrsets, err := PDNSClient.Records.Get(ctx, zone, makeCanonical(name), &rrType)
+	if err != nil {
+		return nil, err
+	}
+	// An issue exist on GET API Calls, RRSets with another name or type may be included although we filter
+	// See https://github.com/PowerDNS/pdns/issues/14539
+	for _, rr := range rrsets {
+		if rr.Name != nil && *rr.Name == makeCanonical(name) && rr.Type != nil && *rr.Type == rrType && len(rr.Records) > 0 {
+			return &rr, nil
+		}
+	}
+	return nil, nil
+}
+
+// changeRRsetIfDifferent creates or replaces the RRset generated by the operator in the zone,
+// unless it already has the same TTL, records and comment.
+// A nil TTL keeps the TTL of the existing RRset, or uses the default TTL of NS records for a new one.
+// An existing RRset not written by the operator instance is only replaced if it already holds the records,
+// see checkGeneratedRRsetOwnership.
+func changeRRsetIfDifferent(ctx context.Context, PDNSClient PdnsClienter, zone, name string, rrType powerdns.RRType, ttl *uint32, content []string, comment string) error {
+	existing, err := getExternalRRset(ctx, PDNSClient, zone, name, rrType)
+	if err != nil {
+		return err
+	}
+	written := generatedComment(comment, PDNSClient.OwnerID)
+	if existing != nil {
+		if err := checkGeneratedRRsetOwnership(*existing, content, PDNSClient.OwnerID); err != nil {
+			return err
+		}
+		if ttl == nil {
+			ttl = existing.TTL
+		}
+		sameComment := slices.ContainsFunc(existing.Comments, func(c powerdns.Comment) bool {
+			return ptr.Deref(c.Account, "") == *written.Account && ptr.Deref(c.Content, "") == *written.Content
+		})
+		if ptr.Deref(existing.TTL, 0) == ptr.Deref(ttl, 0) && sameRecords(normalize.Records(string(rrType), recordsContent(*existing)), normalize.Records(string(rrType), content)) && sameComment {
+			return nil
+		}
+	}
+	if ttl == nil {
+		ttl = ptr.To(settings.NameserversTTL)
+	}
+	return PDNSClient.Records.Change(ctx, zone, makeCanonical(name), rrType, *ttl, content, powerdns.WithComments(written))
+}
+
+// deleteGeneratedRRset deletes the RRset generated by the operator in the zone,
+// an existing RRset not written by the operator instance is left untouched
+func deleteGeneratedRRset(ctx context.Context, PDNSClient PdnsClienter, zone, name string, rrType powerdns.RRType) error {
+	existing, err := getExternalRRset(ctx, PDNSClient, zone, name, rrType)
+	if err != nil || existing == nil || !isOwnedRRset(*existing, PDNSClient.OwnerID) {
+		return err
+	}
+	return PDNSClient.Records.Delete(ctx, zone, makeCanonical(name), rrType)
+}
+
+func recordsContent(rrset powerdns.RRset) []string {
+	content := make([]string, 0, len(rrset.Records))
+	for _, r := range rrset.Records {
+		content = append(content, ptr.Deref(r.Content, ""))
+	}
+	return content
+}
+
+// parentDomain returns the domain name without its first label, an empty string for a top-level domain
+func parentDomain(name string) string {
+	_, parent, found := strings.Cut(strings.TrimSuffix(name, "."), ".")
+	if !found {
+		return ""
+	}
+	return parent
+}
+
+// parentZonesIndexer indexes the Zones/ClusterZones by the domains they are a subdomain of,
+// to find the children of a parent zone
+func parentZonesIndexer(rawObj client.Object) []string {
+	var parents []string
+	for parent := parentDomain(rawObj.GetName()); parent != ""; parent = parentDomain(parent) {
+		parents = append(parents, parent)
+	}
+	return parents
+}
+
+// isSubdomain returns true if name is equal to, or a subdomain of, domain
+func isSubdomain(name, domain string) bool {
+	name = strings.ToLower(makeCanonical(name))
+	domain = strings.ToLower(makeCanonical(domain))
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
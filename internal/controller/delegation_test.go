@@ -0,0 +1,371 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// delegationBackend is an in-memory PowerDNS backend keeping records per zone,
+// the shared mock cannot distinguish a delegation in the parent zone from the apex of the child zone
+type delegationBackend map[string]map[string]powerdns.RRset
+
+func (b delegationBackend) Get(_ context.Context, domain, name string, recordType *powerdns.RRType) ([]powerdns.RRset, error) {
+	rr, ok := b[makeCanonical(domain)][makeCanonical(name)+"/"+string(*recordType)]
+	if !ok {
+		return nil, nil
+	}
+	return []powerdns.RRset{rr}, nil
+}
+
+func (b delegationBackend) Change(_ context.Context, domain, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...func(*powerdns.RRset)) error {
+	rr := powerdns.RRset{Name: ptr.To(makeCanonical(name)), Type: ptr.To(recordType), TTL: ptr.To(ttl)}
+	for _, c := range content {
+		rr.Records = append(rr.Records, powerdns.Record{Content: ptr.To(c)})
+	}
+	for _, option := range options {
+		option(&rr)
+	}
+	b[makeCanonical(domain)][makeCanonical(name)+"/"+string(recordType)] = rr
+	return nil
+}
+
+func (b delegationBackend) Delete(_ context.Context, domain, name string, recordType powerdns.RRType) error {
+	delete(b[makeCanonical(domain)], makeCanonical(name)+"/"+string(recordType))
+	return nil
+}
+
+func (b delegationBackend) zone(domain string) *powerdns.Zone {
+	zone := &powerdns.Zone{Name: ptr.To(makeCanonical(domain))}
+	for _, rr := range b[makeCanonical(domain)] {
+		zone.RRsets = append(zone.RRsets, rr)
+	}
+	return zone
+}
+
+// summary returns the "name/type ttl records" entries of the zone
+func (b delegationBackend) summary(domain string) []string {
+	result := []string{}
+	for key, rr := range b[makeCanonical(domain)] {
+		result = append(result, fmt.Sprintf("%s %d %s", key, *rr.TTL, strings.Join(recordsContent(rr), " ")))
+	}
+	slices.Sort(result)
+	return result
+}
+
+type delegationZones struct{ delegationBackend }
+
+func (z delegationZones) Get(_ context.Context, domain string) (*powerdns.Zone, error) {
+	return z.zone(domain), nil
+}
+func (z delegationZones) Delete(context.Context, string) error                 { return nil }
+func (z delegationZones) Change(context.Context, string, *powerdns.Zone) error { return nil }
+func (z delegationZones) Add(_ context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	return zone, nil
+}
+
+func TestDelegateZone(t *testing.T) {
+	ctx := context.Background()
+	child := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a.example.org", Namespace: "team-a"},
+		Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1.team-a.example.org", "ns.example.net"}},
+	}
+	newBackend := func() delegationBackend {
+		return delegationBackend{
+			"example.org.": {
+				"www.example.org./A":            {Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.1")}}},
+				"ns2.team-a.example.org./AAAA":  {Name: ptr.To("ns2.team-a.example.org."), Type: ptr.To(powerdns.RRTypeAAAA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("2001:db8::2")}}, Comments: []powerdns.Comment{generatedComment("", "")}},
+				"ns3.team-a.example.org./A":     {Name: ptr.To("ns3.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.3")}}},
+				"app.team-a.example.org./CNAME": {Name: ptr.To("app.team-a.example.org."), Type: ptr.To(powerdns.RRTypeCNAME), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("www.example.org.")}}},
+			},
+			"team-a.example.org.": {
+				"ns1.team-a.example.org./A": {Name: ptr.To("ns1.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.10")}}},
+			},
+		}
+	}
+	notManaged := func(string, powerdns.RRType) (bool, error) { return false, nil }
+
+	var testCases = []struct {
+		description string
+		delegate    bool
+		isManaged   func(string, powerdns.RRType) (bool, error)
+		want        []string
+	}{
+		{
+			"delegation with glue and stale glue removal",
+			true,
+			notManaged,
+			[]string{
+				"app.team-a.example.org./CNAME 300 www.example.org.",
+				"ns1.team-a.example.org./A 600 192.0.2.10",
+				"ns3.team-a.example.org./A 300 192.0.2.3",
+				"team-a.example.org./NS 1500 ns1.team-a.example.org. ns.example.net.",
+				"www.example.org./A 300 192.0.2.1",
+			},
+		},
+		{
+			"delegation managed by RRsets",
+			true,
+			func(name string, rrType powerdns.RRType) (bool, error) {
+				return rrType == powerdns.RRTypeNS || name == "ns2.team-a.example.org.", nil
+			},
+			[]string{
+				"app.team-a.example.org./CNAME 300 www.example.org.",
+				"ns1.team-a.example.org./A 600 192.0.2.10",
+				"ns2.team-a.example.org./AAAA 300 2001:db8::2",
+				"ns3.team-a.example.org./A 300 192.0.2.3",
+				"www.example.org./A 300 192.0.2.1",
+			},
+		},
+		{
+			"delegation removal",
+			false,
+			notManaged,
+			[]string{
+				"app.team-a.example.org./CNAME 300 www.example.org.",
+				"ns3.team-a.example.org./A 300 192.0.2.3",
+				"www.example.org./A 300 192.0.2.1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			backend := newBackend()
+			client := PdnsClienter{Records: backend, Zones: delegationZones{backend}}
+			// ns2 is a former nameserver, whose glue record was written by the operator
+			formerGlue := []string{"ns2.team-a.example.org."}
+			glue, err := delegateZone(ctx, "example.org", child, client, formerGlue, tc.isManaged)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.delegate {
+				if err := undelegateZone(ctx, "example.org", child, client, glue, tc.isManaged); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if got := backend.summary("example.org"); !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if got := backend.summary("team-a.example.org"); len(got) != 1 {
+				t.Errorf("child zone should not be modified, got %v", got)
+			}
+		})
+	}
+}
+
+func TestDelegateZoneManualRecords(t *testing.T) {
+	ctx := context.Background()
+	child := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a.example.org", Namespace: "team-a"},
+		Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1.team-a.example.org"}},
+	}
+	backend := delegationBackend{
+		"example.org.": {
+			"team-a.example.org./NS":    {Name: ptr.To("team-a.example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("ns.example.net.")}}},
+			"ns1.team-a.example.org./A": {Name: ptr.To("ns1.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.1")}}},
+		},
+		"team-a.example.org.": {
+			"ns1.team-a.example.org./A": {Name: ptr.To("ns1.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.10")}}},
+		},
+	}
+	want := backend.summary("example.org")
+	client := PdnsClienter{Records: backend, Zones: delegationZones{backend}}
+	notManaged := func(string, powerdns.RRType) (bool, error) { return false, nil }
+
+	if _, err := delegateZone(ctx, "example.org", child, client, nil, notManaged); !isForeignRRsetError(err) {
+		t.Errorf("got error %v, want a ForeignRRsetError", err)
+	}
+	if err := undelegateZone(ctx, "example.org", child, client, nil, notManaged); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := backend.summary("example.org"); !cmp.Equal(got, want) {
+		t.Errorf("manual records should be left untouched, got %v, want %v", got, want)
+	}
+}
+
+func TestDelegateZoneOtherInstance(t *testing.T) {
+	ctx := context.Background()
+	child := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a.example.org", Namespace: "team-a"},
+		Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1.team-a.example.org"}},
+	}
+	backend := delegationBackend{
+		"example.org.": {
+			"team-a.example.org./NS":    {Name: ptr.To("team-a.example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("ns.example.net.")}}, Comments: []powerdns.Comment{generatedComment("", "cluster-b")}},
+			"ns2.team-a.example.org./A": {Name: ptr.To("ns2.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.2")}}, Comments: []powerdns.Comment{generatedComment("", "cluster-b")}},
+		},
+		"team-a.example.org.": {
+			"ns1.team-a.example.org./A": {Name: ptr.To("ns1.team-a.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.10")}}},
+		},
+	}
+	want := backend.summary("example.org")
+	client := PdnsClienter{Records: backend, Zones: delegationZones{backend}, OwnerID: "cluster-a"}
+	notManaged := func(string, powerdns.RRType) (bool, error) { return false, nil }
+
+	if _, err := delegateZone(ctx, "example.org", child, client, []string{"ns2.team-a.example.org."}, notManaged); !isForeignOwnerError(err) {
+		t.Errorf("got error %v, want a ForeignOwnerError", err)
+	}
+	if err := undelegateZone(ctx, "example.org", child, client, []string{"ns2.team-a.example.org."}, notManaged); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := backend.summary("example.org"); !cmp.Equal(got, want) {
+		t.Errorf("records of the other instance should be left untouched, got %v, want %v", got, want)
+	}
+}
+
+func TestReconcileDelegationMove(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	zone := func(name string) *dnsv1alpha2.Zone {
+		return &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1." + name}},
+			Status:     dnsv1alpha2.ZoneStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS)},
+		}
+	}
+	child := zone("app.dev.example.org")
+	// The zone was delegated from example.org before dev.example.org was created
+	child.Status.Delegation = &dnsv1alpha2.DelegationStatus{Parent: "example.org.", Glue: []string{"ns1.app.dev.example.org."}}
+	zoneIndexer := func(obj client.Object) []string { return []string{obj.GetName()} }
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(zone("example.org"), zone("dev.example.org"), child).
+		WithIndex(&dnsv1alpha2.Zone{}, "Zone.Entry.Name", zoneIndexer).
+		WithIndex(&dnsv1alpha2.ClusterZone{}, "ClusterZone.Entry.Name", zoneIndexer).
+		WithIndex(&dnsv1alpha2.RRset{}, "RRset.Entry.Name", func(client.Object) []string { return nil }).
+		WithIndex(&dnsv1alpha2.ClusterRRset{}, "ClusterRRset.Entry.Name", func(client.Object) []string { return nil }).
+		Build()
+
+	generated := []powerdns.Comment{generatedComment("", "")}
+	backend := delegationBackend{
+		"example.org.": {
+			"www.example.org./A":              {Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.1")}}},
+			"app.dev.example.org./NS":         {Name: ptr.To("app.dev.example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(1500)), Records: []powerdns.Record{{Content: ptr.To("ns1.app.dev.example.org.")}}, Comments: generated},
+			"ns1.app.dev.example.org./A":      {Name: ptr.To("ns1.app.dev.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.10")}}, Comments: generated},
+			"ns1.app.dev.example.org./AAAA":   {Name: ptr.To("ns1.app.dev.example.org."), Type: ptr.To(powerdns.RRTypeAAAA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("2001:db8::10")}}},
+			"legacy.app.dev.example.org./TXT": {Name: ptr.To("legacy.app.dev.example.org."), Type: ptr.To(powerdns.RRTypeTXT), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To("\"legacy\"")}}},
+		},
+		"dev.example.org.": {},
+		"app.dev.example.org.": {
+			"ns1.app.dev.example.org./A": {Name: ptr.To("ns1.app.dev.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(600)), Records: []powerdns.Record{{Content: ptr.To("192.0.2.10")}}},
+		},
+	}
+	pdnsClient := PdnsClienter{Records: backend, Zones: delegationZones{backend}}
+
+	delegation, err := reconcileDelegation(ctx, child, cl, pdnsClient, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &dnsv1alpha2.DelegationStatus{Parent: "dev.example.org.", Glue: []string{"ns1.app.dev.example.org."}}
+	if !cmp.Equal(delegation, want) {
+		t.Errorf("got delegation %+v, want %+v", delegation, want)
+	}
+	// The records not written by the operator are left in the former parent zone
+	wantFormer := []string{
+		"legacy.app.dev.example.org./TXT 300 \"legacy\"",
+		"ns1.app.dev.example.org./AAAA 600 2001:db8::10",
+		"www.example.org./A 300 192.0.2.1",
+	}
+	if got := backend.summary("example.org"); !cmp.Equal(got, wantFormer) {
+		t.Errorf("got former parent %v, want %v", got, wantFormer)
+	}
+	wantParent := []string{
+		"app.dev.example.org./NS 1500 ns1.app.dev.example.org.",
+		"ns1.app.dev.example.org./A 600 192.0.2.10",
+	}
+	if got := backend.summary("dev.example.org"); !cmp.Equal(got, wantParent) {
+		t.Errorf("got parent %v, want %v", got, wantParent)
+	}
+
+	// The delegation recorded in the status is removed along with the zone
+	child.Status.Delegation = delegation
+	if err := deleteDelegation(ctx, child, cl, pdnsClient, logr.Discard()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := backend.summary("dev.example.org"); len(got) != 0 {
+		t.Errorf("got parent %v, want no delegation", got)
+	}
+}
+
+func TestParentDomain(t *testing.T) {
+	var testCases = []struct {
+		name string
+		want string
+	}{
+		{"team-a.example.org", "example.org"},
+		{"team-a.example.org.", "example.org"},
+		{"example.org", "org"},
+		{"org", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parentDomain(tc.name); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParentZonesIndexer(t *testing.T) {
+	var testCases = []struct {
+		name string
+		want []string
+	}{
+		{"team-a.example.org", []string{"example.org", "org"}},
+		{"org", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parentZonesIndexer(&dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: tc.name}})
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsSubdomain(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		domain string
+		want   bool
+	}{
+		{"ns1.team-a.example.org.", "team-a.example.org", true},
+		{"Team-A.example.org", "team-a.example.org.", true},
+		{"ns1.myteam-a.example.org", "team-a.example.org", false},
+		{"example.org", "team-a.example.org", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSubdomain(tc.name, tc.domain); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,497 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dkim"
+)
+
+const (
+	// DKIM_KEY_LABEL is set on the RRsets publishing the keys of a DKIMKey and on its Secret, its value is the name of the DKIMKey
+	DKIM_KEY_LABEL = "dns.cav.enablers.ob/dkim-key"
+	// DKIM_RECORDS_FINALIZER_NAME deletes the RRsets of a DKIMKey along with it, they are also owned by their zone
+	// and would not be garbage collected
+	DKIM_RECORDS_FINALIZER_NAME = "dns.cav.enablers.ob/dkim-records"
+	// DKIM_SECRET_SELECTOR and DKIM_SECRET_PRIVATE_KEY are the keys of the Secret holding the selector and the private key of the current key
+	DKIM_SECRET_SELECTOR    = "selector"
+	DKIM_SECRET_PRIVATE_KEY = "private.key"
+	// DKIM_SECRET_NEXT_SELECTOR and DKIM_SECRET_NEXT_PRIVATE_KEY are the keys of the Secret holding the next key,
+	// until its record is published
+	DKIM_SECRET_NEXT_SELECTOR    = "next.selector"
+	DKIM_SECRET_NEXT_PRIVATE_KEY = "next.private.key"
+	// DKIM_DEFAULT_GRACE_PERIOD is the period the record of a rotated key is kept published, if not set
+	DKIM_DEFAULT_GRACE_PERIOD = 7 * 24 * time.Hour
+
+	DKIMKeyReasonSynced              = "DKIMKeySynced"
+	DKIMKeyMessageSynced             = "DKIM records published"
+	DKIMKeyReasonSecretConflict      = "SecretConflict"
+	DKIMKeyReasonKeyGenerationFailed = "KeyGenerationFailed"
+)
+
+// DKIMKeyReconciler reconciles a DKIMKey object
+type DKIMKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the Secrets missing from the cache, which only holds the Secrets selected by DKIMSecretSelector
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=dkimkeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=dkimkeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=dkimkeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+func (r *DKIMKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile DKIMKey", "DKIMKey.Name", req.Name)
+
+	key := &dnsv1alpha2.DKIMKey{}
+	if err := r.Get(ctx, req.NamespacedName, key); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The Secret is garbage collected along with the DKIMKey, the RRsets are deleted by the finalizer
+	if !key.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(key, DKIM_RECORDS_FINALIZER_NAME) {
+			if _, err := reconcileOwnedRRsets(ctx, r.Client, key, DKIM_KEY_LABEL, nil); err != nil {
+				log.Error(err, "Failed to delete DKIM RRsets")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(key, DKIM_RECORDS_FINALIZER_NAME)
+			if err := r.Update(ctx, key); err != nil {
+				log.Error(err, "Failed to remove finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	if !controllerutil.ContainsFinalizer(key, DKIM_RECORDS_FINALIZER_NAME) {
+		controllerutil.AddFinalizer(key, DKIM_RECORDS_FINALIZER_NAME)
+		if err := r.Update(ctx, key); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	now := time.Now().UTC()
+	secret, err := r.getSecret(ctx, key)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			return r.setStatus(ctx, key, FAILED_STATUS, DKIMKeyReasonSecretConflict, err.Error())
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The keys are recovered from the RRsets if the status is lost, their records are not unpublished without grace period
+	if len(key.Status.Keys) == 0 {
+		if err := r.recoverKeys(ctx, key, secret, now); err != nil {
+			log.Error(err, "Failed to recover DKIM keys")
+			return ctrl.Result{}, err
+		}
+	}
+
+	original := key.DeepCopy()
+	// The next key is dropped if the Secret no longer holds it, or if it no longer matches the spec
+	if next := nextDKIMKey(key); next != nil && (!dkimKeyMatchesSpec(key, *next) ||
+		!dkimSecretHolds(secret, DKIM_SECRET_NEXT_SELECTOR, DKIM_SECRET_NEXT_PRIVATE_KEY, *next) &&
+			!dkimSecretHolds(secret, DKIM_SECRET_SELECTOR, DKIM_SECRET_PRIVATE_KEY, *next)) {
+		selector := next.Selector
+		key.Status.Keys = slices.DeleteFunc(key.Status.Keys, func(k dnsv1alpha2.DKIMKeyPublication) bool { return k.Selector == selector })
+	}
+	// Records of rotated keys are unpublished after their grace period
+	key.Status.Keys = slices.DeleteFunc(key.Status.Keys, func(k dnsv1alpha2.DKIMKeyPublication) bool {
+		return k.ExpirationTime != nil && !now.Before(k.ExpirationTime.Time)
+	})
+	if len(key.Status.Keys) != len(original.Status.Keys) {
+		if err := r.Status().Patch(ctx, key, client.MergeFrom(original)); err != nil {
+			log.Error(err, "unable to patch DKIMKey status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// A new key is generated when there is none, when it no longer matches the spec, or when its rotation is due
+	current := currentDKIMKey(key)
+	if nextDKIMKey(key) == nil && (current == nil || !dkimSecretHolds(secret, DKIM_SECRET_SELECTOR, DKIM_SECRET_PRIVATE_KEY, *current) || dkimKeyOutdated(key, *current, now)) {
+		if err := r.generate(ctx, key, secret, now); err != nil {
+			log.Error(err, "Failed to generate DKIM key")
+			return r.setStatus(ctx, key, FAILED_STATUS, DKIMKeyReasonKeyGenerationFailed, err.Error())
+		}
+		log.Info("DKIM key generated", "Selector", nextDKIMKey(key).Selector)
+	}
+
+	desired := make(map[string]dnsv1alpha2.RRsetSpec)
+	for _, k := range key.Status.Keys {
+		desired[key.Name+"-"+k.Selector] = dkimRRsetSpec(key, k)
+	}
+	rrsets, err := reconcileOwnedRRsets(ctx, r.Client, key, DKIM_KEY_LABEL, desired)
+	if err != nil {
+		log.Error(err, "Failed to reconcile DKIM RRsets")
+		return ctrl.Result{}, err
+	}
+
+	// The next key is activated once its record is synchronized and cached records expired
+	var activation time.Duration
+	if next := nextDKIMKey(key); next != nil {
+		if activationTime := dkimKeyActivationTime(key, rrsets, *next); activationTime != nil {
+			if activation = activationTime.Sub(now); activation <= 0 {
+				if err := r.activate(ctx, key, now); err != nil {
+					log.Error(err, "Failed to activate DKIM key")
+					return ctrl.Result{}, err
+				}
+				log.Info("DKIM key activated", "Selector", ptr.Deref(key.Status.Selector, ""))
+			}
+		}
+	}
+
+	// The DKIMKey is synchronized once all its records are
+	status, reason, message := ownedRRsetsStatus(rrsets, DKIMKeyReasonSynced, DKIMKeyMessageSynced)
+	if _, err := r.setStatus(ctx, key, status, reason, message); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeue := nextDKIMKeyEvent(key, now)
+	if activation > 0 && (requeue == 0 || activation < requeue) {
+		requeue = activation
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DKIMKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.DKIMKey{}).
+		// RRsets are controlled by their zone, the DKIMKey is one of their owners
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &dnsv1alpha2.DKIMKey{})).
+		// Only the Secrets selected by DKIMSecretSelector are cached and watched, see the cache options of the manager
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+// DKIMSecretSelector selects the Secrets of the DKIMKeys, the other Secrets are not cached
+func DKIMSecretSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement(DKIM_KEY_LABEL, selection.Exists, nil)
+	return labels.NewSelector().Add(*requirement)
+}
+
+// getSecret returns the Secret of the DKIMKey, nil if it does not exist yet.
+// An AlreadyExists error is returned if the Secret exists and is not owned by the DKIMKey.
+func (r *DKIMKeyReconciler) getSecret(ctx context.Context, key *dnsv1alpha2.DKIMKey) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	name := ptr.Deref(key.Spec.SecretName, key.Name)
+	err := r.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: name}, secret)
+	if err == nil && metav1.IsControlledBy(secret, key) {
+		return secret, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	// A Secret without the label is not cached: it is not managed by the operator,
+	// or it was created before the label was set
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: name}, secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(secret, key) {
+		return nil, errors.NewAlreadyExists(corev1.Resource("secrets"), fmt.Sprintf("%s is not managed by DKIMKey %s, it", name, key.Name))
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[DKIM_KEY_LABEL] = key.Name
+	if err := r.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// generate generates a new key and publishes it in the status of the DKIMKey as the next key.
+// Its private key is stored in the next keys of the Secret, mail servers keep signing with the current key until it is activated.
+func (r *DKIMKeyReconciler) generate(ctx context.Context, key *dnsv1alpha2.DKIMKey, secret *corev1.Secret, now time.Time) error {
+	algorithm, bits := dkimKeyAlgorithm(key)
+	privateKey, err := dkim.GenerateKey(algorithm, int(bits))
+	if err != nil {
+		return err
+	}
+	_, publicKey, err := dkim.PublicKey(privateKey)
+	if err != nil {
+		return err
+	}
+	if algorithm != dkim.RSA {
+		bits = 0
+	}
+	selector := fmt.Sprintf("%s-%d", key.Spec.Selector, now.Unix())
+
+	// The Secret is written first: a next key is dropped if the Secret does not hold it
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ptr.Deref(key.Spec.SecretName, key.Name),
+				Namespace: key.Namespace,
+				Labels:    map[string]string{DKIM_KEY_LABEL: key.Name},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{},
+		}
+		if err := ctrl.SetControllerReference(key, secret, r.Scheme); err != nil {
+			return err
+		}
+		secret.Data[DKIM_SECRET_NEXT_SELECTOR] = []byte(selector)
+		secret.Data[DKIM_SECRET_NEXT_PRIVATE_KEY] = privateKey
+		if err := r.Create(ctx, secret); err != nil {
+			return err
+		}
+	} else {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[DKIM_SECRET_NEXT_SELECTOR] = []byte(selector)
+		secret.Data[DKIM_SECRET_NEXT_PRIVATE_KEY] = privateKey
+		if err := r.Update(ctx, secret); err != nil {
+			return err
+		}
+	}
+
+	original := key.DeepCopy()
+	key.Status.Keys = slices.DeleteFunc(key.Status.Keys, func(k dnsv1alpha2.DKIMKeyPublication) bool { return k.Selector == selector })
+	key.Status.Keys = append(key.Status.Keys, dnsv1alpha2.DKIMKeyPublication{
+		Selector:     selector,
+		Algorithm:    algorithm,
+		KeySize:      bits,
+		PublicKey:    publicKey,
+		CreationTime: metav1.NewTime(now),
+	})
+	return r.Status().Patch(ctx, key, client.MergeFrom(original))
+}
+
+// activate switches the Secret to the next key, and makes it the current key.
+// The previous keys are kept published for the grace period.
+func (r *DKIMKeyReconciler) activate(ctx context.Context, key *dnsv1alpha2.DKIMKey, now time.Time) error {
+	next := *nextDKIMKey(key)
+	secret, err := r.getSecret(ctx, key)
+	if err != nil {
+		return err
+	}
+	// The Secret may already hold the next key if the status could not be patched
+	if !dkimSecretHolds(secret, DKIM_SECRET_SELECTOR, DKIM_SECRET_PRIVATE_KEY, next) {
+		if !dkimSecretHolds(secret, DKIM_SECRET_NEXT_SELECTOR, DKIM_SECRET_NEXT_PRIVATE_KEY, next) {
+			return fmt.Errorf("secret no longer holds the key %s", next.Selector)
+		}
+		secret.Data[DKIM_SECRET_SELECTOR] = secret.Data[DKIM_SECRET_NEXT_SELECTOR]
+		secret.Data[DKIM_SECRET_PRIVATE_KEY] = secret.Data[DKIM_SECRET_NEXT_PRIVATE_KEY]
+		delete(secret.Data, DKIM_SECRET_NEXT_SELECTOR)
+		delete(secret.Data, DKIM_SECRET_NEXT_PRIVATE_KEY)
+		if err := r.Update(ctx, secret); err != nil {
+			return err
+		}
+	}
+
+	original := key.DeepCopy()
+	for i := range key.Status.Keys {
+		if key.Status.Keys[i].ExpirationTime == nil && key.Status.Keys[i].Selector != next.Selector {
+			key.Status.Keys[i].ExpirationTime = ptr.To(metav1.NewTime(now.Add(dkimGracePeriod(key))))
+		}
+	}
+	key.Status.Selector = &next.Selector
+	return r.Status().Patch(ctx, key, client.MergeFrom(original))
+}
+
+// recoverKeys publishes in the status of the DKIMKey the keys of its RRsets.
+// The keys held by the Secret are the current and the next keys, the other ones are rotated keys.
+func (r *DKIMKeyReconciler) recoverKeys(ctx context.Context, key *dnsv1alpha2.DKIMKey, secret *corev1.Secret, now time.Time) error {
+	rrsets, err := listOwnedRRsets(ctx, r.Client, key, DKIM_KEY_LABEL)
+	if err != nil {
+		return err
+	}
+	original := key.DeepCopy()
+	for _, rrset := range rrsets {
+		selector, ok := strings.CutSuffix(rrset.Spec.Name, "._domainkey")
+		if !ok || !rrset.DeletionTimestamp.IsZero() || len(rrset.Spec.Records) != 1 {
+			continue
+		}
+		algorithm, publicKey, bits, err := dkim.ParseRecord(rrset.Spec.Records[0])
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to recover DKIM key", "RRset.Name", rrset.Name)
+			continue
+		}
+		k := dnsv1alpha2.DKIMKeyPublication{
+			Selector:     selector,
+			Algorithm:    algorithm,
+			KeySize:      bits,
+			PublicKey:    publicKey,
+			CreationTime: rrset.CreationTimestamp,
+		}
+		switch {
+		case dkimSecretHolds(secret, DKIM_SECRET_SELECTOR, DKIM_SECRET_PRIVATE_KEY, k):
+			key.Status.Selector = &k.Selector
+		case dkimSecretHolds(secret, DKIM_SECRET_NEXT_SELECTOR, DKIM_SECRET_NEXT_PRIVATE_KEY, k):
+		default:
+			k.ExpirationTime = ptr.To(metav1.NewTime(now.Add(dkimGracePeriod(key))))
+		}
+		key.Status.Keys = append(key.Status.Keys, k)
+	}
+	if len(key.Status.Keys) == 0 {
+		return nil
+	}
+	slices.SortFunc(key.Status.Keys, func(a, b dnsv1alpha2.DKIMKeyPublication) int {
+		return a.CreationTime.Compare(b.CreationTime.Time)
+	})
+	log.FromContext(ctx).Info("DKIM keys recovered from the RRsets", "Keys", len(key.Status.Keys))
+	return r.Status().Patch(ctx, key, client.MergeFrom(original))
+}
+
+// setStatus patches the status of the DKIMKey
+func (r *DKIMKeyReconciler) setStatus(ctx context.Context, key *dnsv1alpha2.DKIMKey, status, reason, message string) (ctrl.Result, error) {
+	original := key.DeepCopy()
+	key.Status.SyncStatus = &status
+	key.Status.ObservedGeneration = &key.Generation
+	key.Status.NextRotationTime = nil
+	if current := currentDKIMKey(key); current != nil && key.Spec.RotationPeriod != nil {
+		key.Status.NextRotationTime = ptr.To(metav1.NewTime(current.CreationTime.Add(key.Spec.RotationPeriod.Duration)))
+	}
+	conditionStatus := metav1.ConditionTrue
+	if status != SUCCEEDED_STATUS {
+		conditionStatus = metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&key.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Patch(ctx, key, client.MergeFrom(original)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to patch DKIMKey status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// dkimKeyAlgorithm returns the algorithm and the size of the keys of the DKIMKey
+func dkimKeyAlgorithm(key *dnsv1alpha2.DKIMKey) (string, int32) {
+	return ptr.Deref(key.Spec.Algorithm, dkim.RSA), ptr.Deref(key.Spec.KeySize, 2048)
+}
+
+// currentDKIMKey returns the published key mails are signed with, nil if none
+func currentDKIMKey(key *dnsv1alpha2.DKIMKey) *dnsv1alpha2.DKIMKeyPublication {
+	for i, k := range key.Status.Keys {
+		if k.ExpirationTime == nil && k.Selector == ptr.Deref(key.Status.Selector, "") {
+			return &key.Status.Keys[i]
+		}
+	}
+	return nil
+}
+
+// nextDKIMKey returns the published key not activated yet, nil if none
+func nextDKIMKey(key *dnsv1alpha2.DKIMKey) *dnsv1alpha2.DKIMKeyPublication {
+	for i, k := range key.Status.Keys {
+		if k.ExpirationTime == nil && k.Selector != ptr.Deref(key.Status.Selector, "") {
+			return &key.Status.Keys[i]
+		}
+	}
+	return nil
+}
+
+// dkimSecretHolds returns True if the Secret holds the private key of the published key, under the given keys
+func dkimSecretHolds(secret *corev1.Secret, selectorKey, privateKeyKey string, k dnsv1alpha2.DKIMKeyPublication) bool {
+	if secret == nil || string(secret.Data[selectorKey]) != k.Selector {
+		return false
+	}
+	_, publicKey, err := dkim.PublicKey(secret.Data[privateKeyKey])
+	return err == nil && publicKey == k.PublicKey
+}
+
+// dkimKeyMatchesSpec returns True if the key matches the selector, the algorithm and the size of the keys of the DKIMKey
+func dkimKeyMatchesSpec(key *dnsv1alpha2.DKIMKey, k dnsv1alpha2.DKIMKeyPublication) bool {
+	algorithm, bits := dkimKeyAlgorithm(key)
+	return strings.HasPrefix(k.Selector, key.Spec.Selector+"-") && k.Algorithm == algorithm && (algorithm != dkim.RSA || k.KeySize == bits)
+}
+
+// dkimKeyOutdated returns True if the current key no longer matches the spec of the DKIMKey, or if its rotation is due
+func dkimKeyOutdated(key *dnsv1alpha2.DKIMKey, current dnsv1alpha2.DKIMKeyPublication, now time.Time) bool {
+	if !dkimKeyMatchesSpec(key, current) {
+		return true
+	}
+	return key.Spec.RotationPeriod != nil && !now.Before(current.CreationTime.Add(key.Spec.RotationPeriod.Duration))
+}
+
+// dkimKeyActivationTime returns the time the key can be activated, one TTL after its RRset is synchronized, nil if it is not
+func dkimKeyActivationTime(key *dnsv1alpha2.DKIMKey, rrsets []dnsv1alpha2.RRset, k dnsv1alpha2.DKIMKeyPublication) *time.Time {
+	for _, rrset := range rrsets {
+		if rrset.Name != key.Name+"-"+k.Selector || ptr.Deref(rrset.Status.SyncStatus, "") != SUCCEEDED_STATUS {
+			continue
+		}
+		condition := meta.FindStatusCondition(rrset.Status.Conditions, "Available")
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			return nil
+		}
+		return ptr.To(condition.LastTransitionTime.Add(time.Duration(key.Spec.TTL) * time.Second))
+	}
+	return nil
+}
+
+// dkimGracePeriod returns the period the record of a rotated key is kept published
+func dkimGracePeriod(key *dnsv1alpha2.DKIMKey) time.Duration {
+	if key.Spec.GracePeriod != nil {
+		return key.Spec.GracePeriod.Duration
+	}
+	return DKIM_DEFAULT_GRACE_PERIOD
+}
+
+// dkimRRsetSpec returns the spec of the RRset publishing a key of the DKIMKey
+func dkimRRsetSpec(key *dnsv1alpha2.DKIMKey, k dnsv1alpha2.DKIMKeyPublication) dnsv1alpha2.RRsetSpec {
+	return dnsv1alpha2.RRsetSpec{
+		Type:    "TXT",
+		Name:    k.Selector + "._domainkey",
+		TTL:     key.Spec.TTL,
+		Records: []string{dkim.Record(k.Algorithm, k.PublicKey)},
+		ZoneRef: key.Spec.ZoneRef,
+	}
+}
+
+// nextDKIMKeyEvent returns the duration until the next rotation or the next unpublication of a key, 0 if none
+func nextDKIMKeyEvent(key *dnsv1alpha2.DKIMKey, now time.Time) time.Duration {
+	var next time.Duration
+	earliest := func(t time.Time) {
+		if d := t.Sub(now); d > 0 && (next == 0 || d < next) {
+			next = d
+		}
+	}
+	if key.Status.NextRotationTime != nil {
+		earliest(key.Status.NextRotationTime.Time)
+	}
+	for _, k := range key.Status.Keys {
+		if k.ExpirationTime != nil {
+			earliest(k.ExpirationTime.Time)
+		}
+	}
+	return next
+}
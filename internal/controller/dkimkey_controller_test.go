@@ -0,0 +1,247 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dkim"
+)
+
+var _ = Describe("DKIMKey Controller", func() {
+	const (
+		zoneName      = "example16.org"
+		zoneNamespace = "example1"
+		dkimKeyName   = "mail"
+		secretName    = "example16-dkim"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+
+	Context("When creating a DKIMKey", func() {
+		It("should generate the key, publish its record and rotate it", Label("dkimkey"), func() {
+			ctx := context.Background()
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      zoneName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example16.org", "ns2.example16.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+
+			By("Creating the DKIMKey")
+			key := &dnsv1alpha2.DKIMKey{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dkimKeyName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.DKIMKeySpec{
+					ZoneRef:     dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Selector:    "mail",
+					Algorithm:   ptr.To(dkim.Ed25519),
+					GracePeriod: &metav1.Duration{Duration: 3 * time.Second},
+					SecretName:  ptr.To(secretName),
+					TTL:         2,
+				},
+			}
+			Expect(k8sClient.Create(ctx, key)).To(Succeed())
+			DeferCleanup(func() {
+				// Envtest does not garbage collect the Secret
+				Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: zoneNamespace}})).To(Succeed())
+			})
+
+			By("Getting the published key")
+			// The key is activated one TTL after its record is published
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return ptr.Deref(key.Status.Selector, "")
+			}, timeout, interval).ShouldNot(BeEmpty())
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return ptr.Deref(key.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(SUCCEEDED_STATUS))
+			Expect(key.Status.Keys).To(HaveLen(1))
+			first := key.Status.Keys[0]
+			Expect(ptr.Deref(key.Status.Selector, "")).To(Equal(first.Selector))
+			Expect(first.Selector).To(HavePrefix("mail-"))
+			Expect(first.Algorithm).To(Equal(dkim.Ed25519))
+			Expect(key.Status.NextRotationTime).To(BeNil())
+
+			By("Checking the Secret holds the private key")
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: zoneNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(string(secret.Data[DKIM_SECRET_SELECTOR])).To(Equal(first.Selector))
+			_, publicKey, err := dkim.PublicKey(secret.Data[DKIM_SECRET_PRIVATE_KEY])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(publicKey).To(Equal(first.PublicKey))
+			Expect(secret.Data).NotTo(HaveKey(DKIM_SECRET_NEXT_SELECTOR))
+			Expect(secret.Labels).To(HaveKeyWithValue(DKIM_KEY_LABEL, dkimKeyName))
+
+			By("Labelling again a Secret without the label, which is not cached")
+			delete(secret.Labels, DKIM_KEY_LABEL)
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			Eventually(func() map[string]string {
+				_ = k8sClient.Get(ctx, client.ObjectKey{Namespace: zoneNamespace, Name: secretName}, secret)
+				return secret.Labels
+			}, timeout, interval).Should(HaveKeyWithValue(DKIM_KEY_LABEL, dkimKeyName))
+
+			By("Checking the record is published")
+			firstName := first.Selector + "._domainkey." + zoneName
+			Expect(getMockedRecordsForType(firstName, "TXT")).To(Equal([]string{dkim.Record(dkim.Ed25519, first.PublicKey)}))
+			Expect(getMockedTTL(firstName, "TXT")).To(Equal(uint32(2)))
+
+			By("Rotating the key")
+			// Selectors are suffixed by the creation timestamp, in seconds
+			time.Sleep(time.Second)
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				key.Spec.RotationPeriod = &metav1.Duration{Duration: time.Second}
+				return k8sClient.Update(ctx, key)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() int {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return len(key.Status.Keys)
+			}, timeout, interval).Should(BeNumerically(">=", 2))
+			Expect(key.Status.Keys[0].Selector).To(Equal(first.Selector))
+			next := key.Status.Keys[1]
+
+			By("Checking the Secret holds the current key until the next one is published")
+			Expect(ptr.Deref(key.Status.Selector, "")).To(Equal(first.Selector))
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: zoneNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(string(secret.Data[DKIM_SECRET_SELECTOR])).To(Equal(first.Selector))
+			Expect(string(secret.Data[DKIM_SECRET_NEXT_SELECTOR])).To(Equal(next.Selector))
+			Eventually(func() []string {
+				return getMockedRecordsForType(next.Selector+"._domainkey."+zoneName, "TXT")
+			}, timeout, interval).Should(Equal([]string{dkim.Record(dkim.Ed25519, next.PublicKey)}))
+
+			By("Activating the next key")
+			Eventually(func() *metav1.Time {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return key.Status.Keys[0].ExpirationTime
+			}, timeout, interval).ShouldNot(BeNil())
+			Expect(getMockedRecordsForType(firstName, "TXT")).To(HaveLen(1))
+
+			By("Unpublishing the rotated key after its grace period")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				key.Spec.RotationPeriod = nil
+				return k8sClient.Update(ctx, key)
+			}, timeout, interval).Should(Succeed())
+			// A next key generated before the rotation period was unset is activated first
+			Eventually(func() []string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				var selectors []string
+				for _, k := range key.Status.Keys {
+					selectors = append(selectors, k.Selector)
+				}
+				return append(selectors, ptr.Deref(key.Status.Selector, ""))
+			}, timeout*3, interval).Should(Satisfy(func(selectors []string) bool {
+				return len(selectors) == 2 && selectors[0] == selectors[1]
+			}))
+			Eventually(func() []string {
+				return getMockedRecordsForType(firstName, "TXT")
+			}, timeout, interval).Should(BeEmpty())
+			current := key.Status.Keys[0]
+			Expect(current.Selector).NotTo(Equal(first.Selector))
+			Expect(getMockedRecordsForType(current.Selector+"._domainkey."+zoneName, "TXT")).To(Equal([]string{dkim.Record(dkim.Ed25519, current.PublicKey)}))
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: zoneNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(string(secret.Data[DKIM_SECRET_SELECTOR])).To(Equal(current.Selector))
+
+			By("Recovering the keys from the RRsets when the status is lost")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				original := key.DeepCopy()
+				key.Status.Keys = nil
+				key.Status.Selector = nil
+				return k8sClient.Status().Patch(ctx, key, client.MergeFrom(original))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return ptr.Deref(key.Status.Selector, "")
+			}, timeout, interval).Should(Equal(current.Selector))
+			Expect(key.Status.Keys).To(HaveLen(1))
+			Expect(key.Status.Keys[0].PublicKey).To(Equal(current.PublicKey))
+			Expect(key.Status.Keys[0].ExpirationTime).To(BeNil())
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: zoneNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(string(secret.Data[DKIM_SECRET_SELECTOR])).To(Equal(current.Selector))
+
+			By("Deleting the DKIMKey")
+			Expect(k8sClient.Delete(ctx, key)).To(Succeed())
+			Eventually(func() []string {
+				return getMockedRecordsForType(current.Selector+"._domainkey."+zoneName, "TXT")
+			}, timeout, interval).Should(BeEmpty())
+			Eventually(func() int {
+				var rrsets dnsv1alpha2.RRsetList
+				_ = k8sClient.List(ctx, &rrsets, client.InNamespace(zoneNamespace), client.MatchingLabels{DKIM_KEY_LABEL: dkimKeyName})
+				return len(rrsets.Items)
+			}, timeout, interval).Should(BeZero())
+		})
+
+		It("should not take over an existing Secret", Label("dkimkey"), func() {
+			ctx := context.Background()
+
+			By("Creating a Secret not managed by the operator, which is not cached")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "example16-foreign", Namespace: zoneNamespace},
+				StringData: map[string]string{"password": "secret"},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			})
+
+			By("Creating a DKIMKey with the name of the Secret")
+			key := &dnsv1alpha2.DKIMKey{
+				ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: zoneNamespace},
+				Spec: dnsv1alpha2.DKIMKeySpec{
+					ZoneRef:    dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Selector:   "mail",
+					SecretName: ptr.To(secret.Name),
+					TTL:        2,
+				},
+			}
+			Expect(k8sClient.Create(ctx, key)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, key)).To(Succeed())
+			})
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(key), key)
+				return ptr.Deref(key.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(FAILED_STATUS))
+			Expect(meta.FindStatusCondition(key.Status.Conditions, "Available").Reason).To(Equal(DKIMKeyReasonSecretConflict))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			Expect(secret.Labels).NotTo(HaveKey(DKIM_KEY_LABEL))
+			Expect(secret.Data).To(Equal(map[string][]byte{"password": []byte("secret")}))
+		})
+	})
+})
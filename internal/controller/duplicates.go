@@ -0,0 +1,188 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// Several Zones/ClusterZones (RRsets/ClusterRRsets) may declare the same DNS entry, only one of them is synchronized:
+// the oldest one, whatever the order they are reconciled in. The other ones are Failed until it is deleted.
+// The claim indexes hold all the objects declaring a DNS entry, whatever their status.
+
+// zoneClaimIndexer indexes a Zone/ClusterZone by its DNS name
+func zoneClaimIndexer(rawObj client.Object) []string {
+	return []string{rawObj.GetName()}
+}
+
+// rrsetClaimIndexer indexes a RRset/ClusterRRset by its DNS name and type
+func rrsetClaimIndexer(rawObj client.Object) []string {
+	return []string{dnsv1alpha2.RRsetClaimKey(rawObj.(dnsv1alpha2.GenericRRset))}
+}
+
+// isOlder returns true if a was created before b, the UID breaks the tie between objects created within the same second
+func isOlder(a, b client.Object) bool {
+	createdA, createdB := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !createdA.Equal(&createdB) {
+		return createdA.Before(&createdB)
+	}
+	return a.GetUID() < b.GetUID()
+}
+
+// electedZone returns the Zone/ClusterZone synchronizing the DNS name of the zone, the zone itself if it has no duplicate
+func electedZone(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client) (client.Object, error) {
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Claim": gz.GetName()}); err != nil {
+		return nil, err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Entry.Claim": gz.GetName()}); err != nil {
+		return nil, err
+	}
+	var elected client.Object = gz
+	for i := range zones.Items {
+		if isOlder(&zones.Items[i], elected) {
+			elected = &zones.Items[i]
+		}
+	}
+	for i := range clusterZones.Items {
+		if isOlder(&clusterZones.Items[i], elected) {
+			elected = &clusterZones.Items[i]
+		}
+	}
+	return elected, nil
+}
+
+// electedRRset returns the RRset/ClusterRRset synchronizing the DNS name and type of the RRset, the RRset itself if it has no duplicate
+func electedRRset(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Client) (client.Object, error) {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets, client.MatchingFields{"RRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(gr)}); err != nil {
+		return nil, err
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(gr)}); err != nil {
+		return nil, err
+	}
+	var elected client.Object = gr
+	for i := range rrsets.Items {
+		if isOlder(&rrsets.Items[i], elected) {
+			elected = &rrsets.Items[i]
+		}
+	}
+	for i := range clusterRRsets.Items {
+		if isOlder(&clusterRRsets.Items[i], elected) {
+			elected = &clusterRRsets.Items[i]
+		}
+	}
+	return elected, nil
+}
+
+// objectReference returns the kind and name of an object, along with its namespace if any, e.g. "Zone example1/example.org"
+func objectReference(obj client.Object) string {
+	var kind string
+	switch obj.(type) {
+	case *dnsv1alpha2.Zone:
+		kind = "Zone"
+	case *dnsv1alpha2.ClusterZone:
+		kind = "ClusterZone"
+	case *dnsv1alpha2.RRset:
+		kind = "RRset"
+	case *dnsv1alpha2.ClusterRRset:
+		kind = "ClusterRRset"
+	}
+	if obj.GetNamespace() == "" {
+		return kind + " " + obj.GetName()
+	}
+	return kind + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// findDuplicatedZones enqueues the Zones declaring the same DNS name as a Zone/ClusterZone,
+// one of them is elected when the synchronized one is deleted
+func (r *ZoneReconciler) findDuplicatedZones(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Claim": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Zones")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, z := range zones.Items {
+		if z.UID != obj.GetUID() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+		}
+	}
+	return requests
+}
+
+// findDuplicatedClusterZones enqueues the ClusterZones declaring the same DNS name as a Zone/ClusterZone,
+// one of them is elected when the synchronized one is deleted
+func (r *ClusterZoneReconciler) findDuplicatedClusterZones(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ClusterZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"ClusterZone.Entry.Claim": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterZones")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, z := range zones.Items {
+		if z.UID != obj.GetUID() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+		}
+	}
+	return requests
+}
+
+// findDuplicatedRRsets enqueues the RRsets declaring the same DNS name and type as a RRset/ClusterRRset,
+// one of them is elected when the synchronized one is deleted
+func (r *RRsetReconciler) findDuplicatedRRsets(ctx context.Context, obj client.Object) []reconcile.Request {
+	gr, ok := obj.(dnsv1alpha2.GenericRRset)
+	if !ok {
+		return nil
+	}
+	var rrsets dnsv1alpha2.RRsetList
+	if err := r.List(ctx, &rrsets, client.MatchingFields{"RRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(gr)}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list RRsets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rr := range rrsets.Items {
+		if rr.UID != obj.GetUID() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+		}
+	}
+	return requests
+}
+
+// findDuplicatedClusterRRsets enqueues the ClusterRRsets declaring the same DNS name and type as a RRset/ClusterRRset,
+// one of them is elected when the synchronized one is deleted
+func (r *ClusterRRsetReconciler) findDuplicatedClusterRRsets(ctx context.Context, obj client.Object) []reconcile.Request {
+	gr, ok := obj.(dnsv1alpha2.GenericRRset)
+	if !ok {
+		return nil
+	}
+	var rrsets dnsv1alpha2.ClusterRRsetList
+	if err := r.List(ctx, &rrsets, client.MatchingFields{"ClusterRRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(gr)}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterRRsets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rr := range rrsets.Items {
+		if rr.UID != obj.GetUID() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,154 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/healthcheck"
+)
+
+// targetsCheck is the last check of the addresses of a RRset
+type targetsCheck struct {
+	// last is the time the last check started
+	last    time.Time
+	running bool
+	// results of the last check, until they are reported in the status of the RRset
+	results map[string]error
+	// completed counts the completed checks, the results of a check are only consumed once reported
+	completed int
+}
+
+// targetsChecks runs the checks of the addresses of the RRsets outside the reconciliation loop, not to block a worker:
+// a reconciliation starts the due check and returns, the RRset is reconciled again once the check is completed.
+// A RRset is reconciled on every change, including the updates of its own status,
+// and its addresses must not be checked more often than the interval of its health check
+var targetsChecks = struct {
+	sync.Mutex
+	checks map[types.UID]*targetsCheck
+}{checks: make(map[types.UID]*targetsCheck)}
+
+// rrsetsChecked and clusterRRsetsChecked receive the RRsets/ClusterRRsets whose check is completed,
+// they are watched by their controllers. The events are dropped and logged when the channels are full,
+// the RRsets are reconciled again after the interval of their health check anyway.
+var (
+	rrsetsChecked        = make(chan event.GenericEvent, 1024)
+	clusterRRsetsChecked = make(chan event.GenericEvent, 1024)
+)
+
+// checkTargetsHealth returns the health of the addresses of the RRset, from the results of their last check,
+// the delay before their next check, and the function consuming the results once the health is reported in the status.
+// The check is started in the background if the interval of the health check elapsed since the last one.
+func checkTargetsHealth(ctx context.Context, gr dnsv1alpha2.GenericRRset) ([]dnsv1alpha2.TargetHealth, time.Duration, func()) {
+	check := gr.GetSpec().HealthCheck
+	if check == nil {
+		forgetTargetsHealth(gr)
+		return nil, 0, func() {}
+	}
+	interval := healthcheck.Interval(check)
+	now := time.Now()
+
+	targetsChecks.Lock()
+	tc, checked := targetsChecks.checks[gr.GetUID()]
+	if !checked {
+		tc = &targetsCheck{}
+		targetsChecks.checks[gr.GetUID()] = tc
+	}
+	if !tc.running && (!checked || now.Sub(tc.last) >= interval) {
+		tc.last = now
+		tc.running = true
+		go probeTargets(log.FromContext(ctx), gr.GetUID(), checkedObject(gr), tc, check.DeepCopy(), slices.Clone(gr.GetSpec().Records))
+	}
+	results := tc.results
+	completed := tc.completed
+	last := tc.last
+	targetsChecks.Unlock()
+
+	// The results are kept if the status is not patched, the next reconciliation reports them
+	consume := func() {
+		targetsChecks.Lock()
+		if tc.completed == completed {
+			tc.results = nil
+		}
+		targetsChecks.Unlock()
+	}
+	targets := healthcheck.Update(check, gr.GetSpec().Records, gr.GetStatus().HealthChecks, results, metav1.NewTime(now.UTC()))
+	return targets, max(interval-now.Sub(last), time.Second), consume
+}
+
+// probeTargets checks the addresses of the RRset and enqueues it, unless its health is forgotten meanwhile
+func probeTargets(log logr.Logger, uid types.UID, obj client.Object, tc *targetsCheck, check *dnsv1alpha2.HealthCheck, addresses []string) {
+	results := healthcheck.ProbeAll(context.Background(), check, addresses)
+
+	targetsChecks.Lock()
+	current := targetsChecks.checks[uid] == tc
+	tc.running = false
+	tc.results = results
+	tc.completed++
+	targetsChecks.Unlock()
+	if !current {
+		return
+	}
+
+	channel := rrsetsChecked
+	if _, ok := obj.(*dnsv1alpha2.ClusterRRset); ok {
+		channel = clusterRRsetsChecked
+	}
+	select {
+	case channel <- event.GenericEvent{Object: obj}:
+	default:
+		log.Info("Health check completion dropped, the RRset is reconciled after the interval of its health check")
+	}
+}
+
+// checkedObject returns the object enqueued once the check of the addresses of the RRset is completed
+func checkedObject(gr dnsv1alpha2.GenericRRset) client.Object {
+	if _, ok := gr.(*dnsv1alpha2.ClusterRRset); ok {
+		return &dnsv1alpha2.ClusterRRset{ObjectMeta: metav1.ObjectMeta{Name: gr.GetName()}}
+	}
+	return &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: gr.GetName(), Namespace: gr.GetNamespace()}}
+}
+
+// forgetTargetsHealth forgets the last check of the addresses of the RRset
+func forgetTargetsHealth(gr dnsv1alpha2.GenericRRset) {
+	targetsChecks.Lock()
+	delete(targetsChecks.checks, gr.GetUID())
+	targetsChecks.Unlock()
+}
+
+// publishedRecords returns the records of the RRset without the ones of its unpublished addresses.
+// All the records are published rather than none.
+func publishedRecords(gr dnsv1alpha2.GenericRRset) []string {
+	if gr.GetSpec().HealthCheck == nil {
+		return gr.GetSpec().Records
+	}
+	records := slices.DeleteFunc(slices.Clone(gr.GetSpec().Records), func(record string) bool {
+		return slices.ContainsFunc(gr.GetStatus().HealthChecks, func(t dnsv1alpha2.TargetHealth) bool {
+			return t.Address == record && !t.Published
+		})
+	})
+	if len(records) == 0 {
+		return gr.GetSpec().Records
+	}
+	return records
+}
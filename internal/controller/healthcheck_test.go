@@ -0,0 +1,110 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestCheckTargetsHealth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	rrset := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Name: "front", Namespace: "default", UID: "check-targets-health"},
+		Spec: dnsv1alpha2.RRsetSpec{
+			Type:    "A",
+			Name:    "front",
+			Records: []string{"127.0.0.1", "127.0.0.2"},
+			HealthCheck: &dnsv1alpha2.HealthCheck{
+				Type:               "TCP",
+				Port:               int32(listener.Addr().(*net.TCPAddr).Port),
+				Interval:           &metav1.Duration{Duration: time.Hour},
+				Timeout:            &metav1.Duration{Duration: 100 * time.Millisecond},
+				HealthyThreshold:   1,
+				UnhealthyThreshold: 1,
+				MinHealthy:         1,
+			},
+		},
+	}
+	defer forgetTargetsHealth(rrset)
+
+	// The check runs in the background, the addresses are healthy until it is completed
+	targets, next, consume := checkTargetsHealth(context.Background(), rrset)
+	if len(targets) != 2 || !targets[0].Healthy || !targets[1].Healthy {
+		t.Fatalf("got targets %+v, want healthy addresses", targets)
+	}
+	if next != time.Hour {
+		t.Errorf("got next check in %s, want %s", next, time.Hour)
+	}
+
+	// The results of the check are reported by the next reconciliation
+	deadline := time.Now().Add(5 * time.Second)
+	for targets[1].Healthy && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		rrset.Status.HealthChecks = targets
+		consume()
+		targets, _, consume = checkTargetsHealth(context.Background(), rrset)
+	}
+	if !targets[0].Healthy || targets[1].Healthy || targets[1].Published {
+		t.Fatalf("got targets %+v, want the second address unhealthy and unpublished", targets)
+	}
+
+	// The results are kept until the status is patched
+	pending := func() bool {
+		targetsChecks.Lock()
+		defer targetsChecks.Unlock()
+		return targetsChecks.checks[rrset.UID].results != nil
+	}
+	if again, _, _ := checkTargetsHealth(context.Background(), rrset); !pending() || again[1].Healthy {
+		t.Errorf("got targets %+v, want the results reported again until they are consumed", again)
+	}
+	consume()
+	if pending() {
+		t.Error("got the results kept once consumed")
+	}
+	rrset.Status.HealthChecks = targets
+	if got := publishedRecords(rrset); !slices.Equal(got, []string{"127.0.0.1"}) {
+		t.Errorf("got published records %v, want the healthy address", got)
+	}
+}
+
+func TestPublishedRecords(t *testing.T) {
+	rrset := &dnsv1alpha2.RRset{
+		Spec: dnsv1alpha2.RRsetSpec{
+			Records:     []string{"192.0.2.1", "192.0.2.2"},
+			HealthCheck: &dnsv1alpha2.HealthCheck{Type: "TCP", Port: 80},
+		},
+	}
+
+	// A status written before the minimum of healthy addresses was enforced never empties the RRset
+	rrset.Status.HealthChecks = []dnsv1alpha2.TargetHealth{{Address: "192.0.2.1"}, {Address: "192.0.2.2"}}
+	if got := publishedRecords(rrset); !slices.Equal(got, rrset.Spec.Records) {
+		t.Errorf("got published records %v, want all the records", got)
+	}
+
+	rrset.Status.HealthChecks[0].Published = true
+	if got := publishedRecords(rrset); !slices.Equal(got, []string{"192.0.2.1"}) {
+		t.Errorf("got published records %v, want the published address", got)
+	}
+}
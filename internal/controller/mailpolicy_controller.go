@@ -0,0 +1,161 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/mailpolicy"
+)
+
+const (
+	// MAIL_POLICY_LABEL is set on the RRsets rendered from a MailPolicy, its value is the name of the MailPolicy
+	MAIL_POLICY_LABEL = "dns.cav.enablers.ob/mail-policy"
+	// MAIL_RECORDS_FINALIZER_NAME deletes the RRsets of a MailPolicy along with it, they are also owned by their zone
+	// and would not be garbage collected
+	MAIL_RECORDS_FINALIZER_NAME = "dns.cav.enablers.ob/mail-records"
+
+	MailPolicyReasonSynced  = "MailPolicySynced"
+	MailPolicyMessageSynced = "Mail policy records published"
+)
+
+// MailPolicyReconciler reconciles a MailPolicy object
+type MailPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=mailpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=mailpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=mailpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *MailPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile MailPolicy", "MailPolicy.Name", req.Name)
+
+	policy := &dnsv1alpha2.MailPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(policy, MAIL_RECORDS_FINALIZER_NAME) {
+			if _, err := reconcileOwnedRRsets(ctx, r.Client, policy, MAIL_POLICY_LABEL, nil); err != nil {
+				log.Error(err, "Failed to delete mail policy RRsets")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(policy, MAIL_RECORDS_FINALIZER_NAME)
+			if err := r.Update(ctx, policy); err != nil {
+				log.Error(err, "Failed to remove finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	if !controllerutil.ContainsFinalizer(policy, MAIL_RECORDS_FINALIZER_NAME) {
+		controllerutil.AddFinalizer(policy, MAIL_RECORDS_FINALIZER_NAME)
+		if err := r.Update(ctx, policy); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	rrsets, err := reconcileOwnedRRsets(ctx, r.Client, policy, MAIL_POLICY_LABEL, mailPolicyRRsets(policy))
+	if err != nil {
+		log.Error(err, "Failed to reconcile mail policy RRsets")
+		return ctrl.Result{}, err
+	}
+
+	// The MailPolicy is synchronized once all its records are
+	status, reason, message := ownedRRsetsStatus(rrsets, MailPolicyReasonSynced, MailPolicyMessageSynced)
+	original := policy.DeepCopy()
+	policy.Status.SyncStatus = &status
+	policy.Status.ObservedGeneration = &policy.Generation
+	conditionStatus := metav1.ConditionTrue
+	if status != SUCCEEDED_STATUS {
+		conditionStatus = metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Patch(ctx, policy, client.MergeFrom(original)); err != nil {
+		log.Error(err, "unable to patch MailPolicy status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MailPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.MailPolicy{}).
+		// RRsets are controlled by their zone, the MailPolicy is one of their owners
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &dnsv1alpha2.MailPolicy{})).
+		Complete(r)
+}
+
+// mailPolicyRRsets returns the specs of the TXT RRsets rendered from the MailPolicy, indexed by the name of the RRsets
+func mailPolicyRRsets(policy *dnsv1alpha2.MailPolicy) map[string]dnsv1alpha2.RRsetSpec {
+	domain := ptr.Deref(policy.Spec.Domain, "")
+	// name returns the name of a record of the mail domain, relative to the zone
+	name := func(prefix string) string {
+		switch {
+		case domain == "" && prefix == "":
+			return makeCanonical(policy.Spec.ZoneRef.Name)
+		case domain == "":
+			return prefix
+		case prefix == "":
+			return domain
+		}
+		return prefix + "." + domain
+	}
+	rrset := func(recordName, content string) dnsv1alpha2.RRsetSpec {
+		return dnsv1alpha2.RRsetSpec{
+			Type:    "TXT",
+			Name:    name(recordName),
+			TTL:     policy.Spec.TTL,
+			Records: []string{content},
+			ZoneRef: policy.Spec.ZoneRef,
+		}
+	}
+
+	rrsets := make(map[string]dnsv1alpha2.RRsetSpec)
+	if policy.Spec.SPF != nil {
+		rrsets[policy.Name+"-spf"] = rrset("", mailpolicy.SPF(*policy.Spec.SPF))
+	}
+	if policy.Spec.DMARC != nil {
+		rrsets[policy.Name+"-dmarc"] = rrset(mailpolicy.DMARCName, mailpolicy.DMARC(*policy.Spec.DMARC))
+	}
+	if policy.Spec.MTASTS != nil {
+		rrsets[policy.Name+"-mta-sts"] = rrset(mailpolicy.MTASTSName, mailpolicy.MTASTS(*policy.Spec.MTASTS))
+	}
+	if policy.Spec.TLSRPT != nil {
+		rrsets[policy.Name+"-tls-rpt"] = rrset(mailpolicy.TLSRPTName, mailpolicy.TLSRPT(*policy.Spec.TLSRPT))
+	}
+	return rrsets
+}
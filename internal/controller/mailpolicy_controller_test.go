@@ -0,0 +1,123 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("MailPolicy Controller", func() {
+	const (
+		zoneName       = "example17.org"
+		zoneNamespace  = "example1"
+		mailPolicyName = "example17"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+
+	Context("When creating a MailPolicy", func() {
+		It("should render its policies into TXT records", Label("mailpolicy"), func() {
+			ctx := context.Background()
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      zoneName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example17.org", "ns2.example17.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+
+			By("Refusing a MailPolicy without any policy")
+			empty := &dnsv1alpha2.MailPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: zoneNamespace},
+				Spec:       dnsv1alpha2.MailPolicySpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}},
+			}
+			Expect(k8sClient.Create(ctx, empty)).NotTo(Succeed())
+
+			By("Creating the MailPolicy")
+			policy := &dnsv1alpha2.MailPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      mailPolicyName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.MailPolicySpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					// The records of the mocked PowerDNS are indexed by name only, the apex already holds the NS records
+					Domain: ptr.To("mail"),
+					SPF: &dnsv1alpha2.SPF{
+						Mechanisms: []dnsv1alpha2.SPFMechanism{
+							{Type: "mx"},
+							{Type: "include", Value: ptr.To("_spf.example.net")},
+						},
+						All: ptr.To("Fail"),
+					},
+					DMARC: &dnsv1alpha2.DMARC{
+						Policy:           "reject",
+						AggregateReports: []dnsv1alpha2.MailAddress{"dmarc@mail.example17.org"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			By("Getting the published records")
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)
+				return ptr.Deref(policy.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(SUCCEEDED_STATUS))
+			Expect(getMockedRecordsForType("mail."+zoneName, "TXT")).To(Equal([]string{`"v=spf1 mx include:_spf.example.net -all"`}))
+			Expect(getMockedRecordsForType("_dmarc.mail."+zoneName, "TXT")).To(Equal([]string{`"v=DMARC1; p=reject; rua=mailto:dmarc@mail.example17.org"`}))
+			Expect(getMockedTTL("_dmarc.mail."+zoneName, "TXT")).To(Equal(uint32(3600)))
+
+			By("Replacing the DMARC policy by a TLS-RPT policy")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)
+				policy.Spec.DMARC = nil
+				policy.Spec.TLSRPT = &dnsv1alpha2.TLSRPT{Reports: []dnsv1alpha2.TLSRPTDestination{"tls-rpt@example17.org"}}
+				return k8sClient.Update(ctx, policy)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return getMockedRecordsForType("_smtp._tls.mail."+zoneName, "TXT")
+			}, timeout, interval).Should(Equal([]string{`"v=TLSRPTv1; rua=mailto:tls-rpt@example17.org"`}))
+			Eventually(func() []string {
+				return getMockedRecordsForType("_dmarc.mail."+zoneName, "TXT")
+			}, timeout, interval).Should(BeEmpty())
+
+			By("Deleting the MailPolicy")
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+			Eventually(func() int {
+				var rrsets dnsv1alpha2.RRsetList
+				_ = k8sClient.List(ctx, &rrsets, client.InNamespace(zoneNamespace), client.MatchingLabels{MAIL_POLICY_LABEL: mailPolicyName})
+				return len(rrsets.Items)
+			}, timeout, interval).Should(BeZero())
+			Expect(getMockedRecordsForType("_smtp._tls.mail."+zoneName, "TXT")).To(BeEmpty())
+		})
+	})
+})
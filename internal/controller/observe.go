@@ -0,0 +1,164 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	MANAGE_MANAGEMENT_POLICY  = "Manage"
+	OBSERVE_MANAGEMENT_POLICY = "Observe"
+	// OBSERVE_PERIOD is the default period observed zones, and the RRsets referencing them, are read again from PowerDNS
+	OBSERVE_PERIOD = 1 * time.Minute
+)
+
+// isObserved returns True if the zone is only read from PowerDNS
+func isObserved(gz dnsv1alpha2.GenericZone) bool {
+	return ptr.Deref(gz.GetSpec().ManagementPolicy, MANAGE_MANAGEMENT_POLICY) == OBSERVE_MANAGEMENT_POLICY
+}
+
+// observeZoneReconcile fills the status of an observed zone from PowerDNS, without writing anything to it
+func observeZoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+	zoneRes, err := getZoneExternalResources(ctx, gz.GetName(), PDNSClient, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	syncStatus := ptr.To(SUCCEEDED_STATUS)
+	condition := metav1.Condition{
+		Type:               "Available",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionTrue,
+		Reason:             ZoneReasonObserved,
+		Message:            ZoneMessageObserved,
+	}
+	var soa *dnsv1alpha2.SOAStatus
+	if zoneRes.Name == nil {
+		syncStatus = ptr.To(FAILED_STATUS)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ZoneReasonNotFound
+		condition.Message = ZoneMessageNotFound
+	} else {
+		current, err := getSOA(ctx, gz, PDNSClient)
+		if err != nil {
+			log.Error(err, "Failed to get SOA record")
+			return ctrl.Result{}, err
+		}
+		if current != nil {
+			soa = &current.SOAStatus
+		}
+	}
+
+	members, err := catalogMembers(ctx, gz, cl)
+	if err != nil {
+		log.Error(err, "unable to find the members of the catalog")
+		return ctrl.Result{}, err
+	}
+	if err := patchZoneStatus(ctx, gz, zoneRes, members, soa, gz.GetStatus().Delegation, nil, syncStatus, cl, condition); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Update resource metrics
+	updateZonesMetrics(gz)
+
+	return ctrl.Result{RequeueAfter: settings.ObservePeriod}, nil
+}
+
+// observeRRsetReconcile reports in the status of a RRset referencing an observed zone the records of PowerDNS,
+// without writing anything to it
+func observeRRsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, lastUpdateTime *metav1.Time, scheme *runtime.Scheme, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+	name := getRRsetName(gr)
+	existing, err := getExternalRRset(ctx, PDNSClient, zone.GetName(), name, powerdns.RRType(gr.GetSpec().Type))
+	if err != nil {
+		log.Error(err, "Failed to get record")
+		return ctrl.Result{}, err
+	}
+
+	// Set OwnerReference
+	if err := ownObject(ctx, zone, gr, scheme, cl, log); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Conflict on RRSet owner reference, retrying")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to set owner reference")
+		return ctrl.Result{}, err
+	}
+
+	original := gr.Copy()
+	syncStatus := ptr.To(SUCCEEDED_STATUS)
+	condition := metav1.Condition{
+		Type:               "Available",
+		LastTransitionTime: *lastUpdateTime,
+		Status:             metav1.ConditionTrue,
+		Reason:             RrsetReasonObserved,
+		Message:            RrsetMessageObserved,
+	}
+	var records []string
+	if existing == nil {
+		syncStatus = ptr.To(FAILED_STATUS)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = RrsetReasonNotFound
+		condition.Message = RrsetMessageNotFound
+	} else {
+		records = recordsContent(*existing)
+	}
+	conditions := gr.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, condition)
+	gr.SetStatus(dnsv1alpha2.RRsetStatus{
+		LastUpdateTime:     lastUpdateTime,
+		DnsEntryName:       &name,
+		SyncStatus:         syncStatus,
+		ObservedGeneration: &gr.GetObjectMeta().Generation,
+		Conditions:         conditions,
+		PTRRecords:         gr.GetStatus().PTRRecords,
+		ObservedRecords:    records,
+	})
+	if err := cl.Status().Patch(ctx, gr, client.MergeFrom(original)); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return ctrl.Result{}, err
+	}
+
+	// Metrics calculation
+	updateRrsetsMetrics(name, gr)
+
+	return ctrl.Result{RequeueAfter: settings.ObservePeriod}, nil
+}
+
+// recordsCount returns the number of records of the zone of PowerDNS
+func recordsCount(zoneRes *powerdns.Zone) *int32 {
+	if zoneRes.Name == nil {
+		return nil
+	}
+	var count int32
+	for _, rrset := range zoneRes.RRsets {
+		count += int32(len(rrset.Records))
+	}
+	return &count
+}
@@ -0,0 +1,139 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// Records published by a resource through RRsets (DKIMKey, MailPolicy)
+const (
+	RecordsReasonPending = "RecordsPending"
+	RecordsReasonFailed  = "RecordsFailed"
+)
+
+// reconcileOwnedRRsets creates and updates the RRsets rendered by a resource, in its namespace, and deletes the ones no longer rendered.
+// The RRsets are labelled with label and owned by the resource, the zone they reference remains their controller:
+// they are not garbage collected along with the resource, which must delete them by reconciling an empty set.
+// It returns the RRsets of desired, sorted by name.
+func reconcileOwnedRRsets(ctx context.Context, cl client.Client, owner client.Object, label string, desired map[string]dnsv1alpha2.RRsetSpec) ([]dnsv1alpha2.RRset, error) {
+	log := log.FromContext(ctx)
+
+	existing, err := listOwnedRRsets(ctx, cl, owner, label)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var result []dnsv1alpha2.RRset
+	for _, name := range names {
+		spec := desired[name]
+		rrset, ok := existing[name]
+		delete(existing, name)
+
+		switch {
+		case !ok:
+			rrset = dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: owner.GetNamespace(), Labels: map[string]string{label: owner.GetName()}},
+				Spec:       spec,
+			}
+			if err := controllerutil.SetOwnerReference(owner, &rrset, cl.Scheme()); err != nil {
+				return nil, err
+			}
+			log.Info("Creating RRset", "RRset.Name", name)
+			if err := cl.Create(ctx, &rrset); err != nil {
+				return nil, err
+			}
+		case !rrset.DeletionTimestamp.IsZero():
+			// The RRset is created again once deleted
+		case rrset.Spec.Name != spec.Name || !equality.Semantic.DeepEqual(rrset.Spec.ZoneRef, spec.ZoneRef):
+			// The name of a RRset is immutable, the RRset is deleted to be created again
+			log.Info("Deleting renamed RRset", "RRset.Name", name)
+			if err := cl.Delete(ctx, &rrset); client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+		case !equality.Semantic.DeepEqual(rrset.Spec, spec):
+			rrset.Spec = spec
+			log.Info("Updating RRset", "RRset.Name", name)
+			if err := cl.Update(ctx, &rrset); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, rrset)
+	}
+
+	for name, rrset := range existing {
+		log.Info("Deleting RRset no longer rendered", "RRset.Name", name)
+		if err := cl.Delete(ctx, &rrset); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// listOwnedRRsets returns the RRsets labelled with label and owned by the resource, by name
+func listOwnedRRsets(ctx context.Context, cl client.Client, owner client.Object, label string) (map[string]dnsv1alpha2.RRset, error) {
+	var list dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &list, client.InNamespace(owner.GetNamespace()), client.MatchingLabels{label: owner.GetName()}); err != nil {
+		return nil, err
+	}
+	owned := make(map[string]dnsv1alpha2.RRset)
+	for _, rrset := range list.Items {
+		if slices.ContainsFunc(rrset.OwnerReferences, func(o metav1.OwnerReference) bool { return o.UID == owner.GetUID() }) {
+			owned[rrset.Name] = rrset
+		}
+	}
+	return owned, nil
+}
+
+// ownedRRsetsStatus returns the status of a resource publishing records through the RRsets:
+// Succeeded once all of them are, Failed if one of them is, Pending otherwise
+func ownedRRsetsStatus(rrsets []dnsv1alpha2.RRset, reason, message string) (string, string, string) {
+	status := SUCCEEDED_STATUS
+	var pending []string
+	for _, rrset := range rrsets {
+		switch ptr.Deref(rrset.Status.SyncStatus, "") {
+		case SUCCEEDED_STATUS:
+		case FAILED_STATUS:
+			message = fmt.Sprintf("RRset %s failed", rrset.Name)
+			if condition := meta.FindStatusCondition(rrset.Status.Conditions, "Available"); condition != nil {
+				message += ": " + condition.Message
+			}
+			return FAILED_STATUS, RecordsReasonFailed, message
+		default:
+			status = PENDING_STATUS
+			pending = append(pending, rrset.Name)
+		}
+	}
+	if status == PENDING_STATUS {
+		return status, RecordsReasonPending, "waiting for RRsets " + strings.Join(pending, ", ")
+	}
+	return status, reason, message
+}
@@ -0,0 +1,129 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestReconcileOwnedRRsets(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	owner := &dnsv1alpha2.MailPolicy{ObjectMeta: metav1.ObjectMeta{Name: "mail", Namespace: "default", UID: "mail-uid"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()
+
+	spec := func(name, content string) dnsv1alpha2.RRsetSpec {
+		return dnsv1alpha2.RRsetSpec{Type: "TXT", Name: name, TTL: 300, Records: []string{content}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}
+	}
+	list := func() map[string]dnsv1alpha2.RRsetSpec {
+		var rrsets dnsv1alpha2.RRsetList
+		if err := cl.List(ctx, &rrsets, client.MatchingLabels{MAIL_POLICY_LABEL: "mail"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := make(map[string]dnsv1alpha2.RRsetSpec)
+		for _, rrset := range rrsets.Items {
+			result[rrset.Name] = rrset.Spec
+		}
+		return result
+	}
+
+	var testCases = []struct {
+		description string
+		desired     map[string]dnsv1alpha2.RRsetSpec
+		want        map[string]dnsv1alpha2.RRsetSpec
+	}{
+		{
+			"create",
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("mail", `"v=spf1 -all"`), "mail-dmarc": spec("_dmarc.mail", `"v=DMARC1; p=none"`)},
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("mail", `"v=spf1 -all"`), "mail-dmarc": spec("_dmarc.mail", `"v=DMARC1; p=none"`)},
+		},
+		{
+			"update and delete",
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("mail", `"v=spf1 mx -all"`)},
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("mail", `"v=spf1 mx -all"`)},
+		},
+		{
+			// The name of a RRset is immutable, it is deleted to be created again by the next reconciliation
+			"rename",
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("newsletter", `"v=spf1 mx -all"`)},
+			map[string]dnsv1alpha2.RRsetSpec{},
+		},
+		{
+			"create again",
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("newsletter", `"v=spf1 mx -all"`)},
+			map[string]dnsv1alpha2.RRsetSpec{"mail-spf": spec("newsletter", `"v=spf1 mx -all"`)},
+		},
+		{
+			"delete all",
+			nil,
+			map[string]dnsv1alpha2.RRsetSpec{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrsets, err := reconcileOwnedRRsets(ctx, cl, owner, MAIL_POLICY_LABEL, tc.desired)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(rrsets) != len(tc.desired) {
+				t.Errorf("got %d RRsets, want %d", len(rrsets), len(tc.desired))
+			}
+			got := list()
+			if len(got) != len(tc.want) {
+				t.Fatalf("got RRsets %v, want %v", got, tc.want)
+			}
+			for name, want := range tc.want {
+				if got[name].Name != want.Name || got[name].Records[0] != want.Records[0] {
+					t.Errorf("got RRset %s %v, want %v", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestOwnedRRsetsStatus(t *testing.T) {
+	rrset := func(name, status string) dnsv1alpha2.RRset {
+		return dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(status)}}
+	}
+
+	var testCases = []struct {
+		description string
+		rrsets      []dnsv1alpha2.RRset
+		wantStatus  string
+		wantReason  string
+	}{
+		{"no RRset", nil, SUCCEEDED_STATUS, MailPolicyReasonSynced},
+		{"all succeeded", []dnsv1alpha2.RRset{rrset("a", SUCCEEDED_STATUS), rrset("b", SUCCEEDED_STATUS)}, SUCCEEDED_STATUS, MailPolicyReasonSynced},
+		{"pending", []dnsv1alpha2.RRset{rrset("a", SUCCEEDED_STATUS), rrset("b", "")}, PENDING_STATUS, RecordsReasonPending},
+		{"failed", []dnsv1alpha2.RRset{rrset("a", ""), rrset("b", FAILED_STATUS)}, FAILED_STATUS, RecordsReasonFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			status, reason, _ := ownedRRsetsStatus(tc.rrsets, MailPolicyReasonSynced, MailPolicyMessageSynced)
+			if status != tc.wantStatus || reason != tc.wantReason {
+				t.Errorf("got %s/%s, want %s/%s", status, reason, tc.wantStatus, tc.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
+)
+
+// OPERATOR_ACCOUNT is the default account of the comment set on the RRSets written by the operator, and of the zones it creates,
+// it marks the objects owned by the operator in PowerDNS. It is followed by "/<owner ID>" when an owner ID is set.
+// The account is configurable, see Settings.
+const OPERATOR_ACCOUNT = "powerdns-operator"
+
+// ForeignRRsetError is returned when a RRSet with the same name and type exists in PowerDNS
+// and was not created by the operator
+type ForeignRRsetError struct {
+	Name string
+	Type string
+}
+
+func (e *ForeignRRsetError) Error() string {
+	return fmt.Sprintf("RRset %s %s already exists in PowerDNS and is not managed by the operator", e.Name, e.Type)
+}
+
+// ForeignOwnerError is returned when an object of PowerDNS is owned by another operator instance
+type ForeignOwnerError struct {
+	Kind  string
+	Name  string
+	Owner string
+}
+
+func (e *ForeignOwnerError) Error() string {
+	owner := "without owner ID"
+	if e.Owner != "" {
+		owner = fmt.Sprintf("with owner ID %q", e.Owner)
+	}
+	return fmt.Sprintf("%s %s is managed by another operator instance (%s)", e.Kind, e.Name, owner)
+}
+
+// isForeignRRsetError returns True if err is a ForeignRRsetError
+func isForeignRRsetError(err error) bool {
+	var foreign *ForeignRRsetError
+	return errors.As(err, &foreign)
+}
+
+// isForeignOwnerError returns True if err is a ForeignOwnerError
+func isForeignOwnerError(err error) bool {
+	var foreign *ForeignOwnerError
+	return errors.As(err, &foreign)
+}
+
+// operatorAccount returns the account marking the objects owned by the operator instance of ownerID
+func operatorAccount(ownerID string) string {
+	if ownerID == "" {
+		return settings.Account
+	}
+	return settings.Account + "/" + ownerID
+}
+
+// isOperatorAccount returns True if the account marks an object owned by an operator instance, whatever its owner ID
+func isOperatorAccount(account string) bool {
+	return account == settings.Account || strings.HasPrefix(account, settings.Account+"/")
+}
+
+// foreignOwner returns the owner ID of the operator instance owning an object of PowerDNS marked with account,
+// and True if it is not the instance of ownerID.
+// An object marked by an instance without owner ID and already synchronized is adopted, so that an owner ID may be set afterwards.
+func foreignOwner(account, ownerID string, synchronized bool) (string, bool) {
+	if account == operatorAccount(ownerID) || (account == settings.Account && synchronized) {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(account, settings.Account), "/"), true
+}
+
+// rrsetAccount returns the operator account of the comments of the RRSet of PowerDNS, empty if none
+func rrsetAccount(externalRRset powerdns.RRset) string {
+	for _, c := range externalRRset.Comments {
+		if isOperatorAccount(ptr.Deref(c.Account, "")) {
+			return *c.Account
+		}
+	}
+	return ""
+}
+
+// isOwnedRRset returns True if the RRSet of PowerDNS holds the comment of the operator instance of ownerID
+func isOwnedRRset(externalRRset powerdns.RRset, ownerID string) bool {
+	return rrsetAccount(externalRRset) == operatorAccount(ownerID)
+}
+
+// checkRRsetOwnership returns an error if the operator instance of ownerID may not replace or delete the RRSet of PowerDNS:
+// it may if the RRSet is owned by this instance, was already synchronized by the RRset before ownership was recorded,
+// or if the RRset explicitly takes over a RRSet not owned by any operator instance.
+// A RRSet owned by another instance is never taken over.
+func checkRRsetOwnership(rrset dnsv1alpha2.GenericRRset, externalRRset powerdns.RRset, ownerID string) error {
+	synchronized := ptr.Deref(rrset.GetStatus().SyncStatus, "") == SUCCEEDED_STATUS
+	if account := rrsetAccount(externalRRset); account != "" {
+		if owner, foreign := foreignOwner(account, ownerID, synchronized); foreign {
+			return &ForeignOwnerError{Kind: "RRset", Name: fmt.Sprintf("%s %s", getRRsetName(rrset), rrset.GetSpec().Type), Owner: owner}
+		}
+		return nil
+	}
+	if synchronized || ptr.Deref(rrset.GetSpec().TakeOver, false) {
+		return nil
+	}
+	return &ForeignRRsetError{Name: getRRsetName(rrset), Type: rrset.GetSpec().Type}
+}
+
+// checkGeneratedRRsetOwnership returns an error if the operator instance of ownerID may not replace the RRSet of PowerDNS
+// with the records it generates, the delegation and glue records of a zone or the PTR records of a RRset:
+// it may if the RRSet is owned by this instance, or already holds the generated records
+func checkGeneratedRRsetOwnership(externalRRset powerdns.RRset, content []string, ownerID string) error {
+	name, rrType := ptr.Deref(externalRRset.Name, ""), ptr.Deref(externalRRset.Type, "")
+	generated := sameRecords(normalize.Records(string(rrType), recordsContent(externalRRset)), normalize.Records(string(rrType), content))
+	if account := rrsetAccount(externalRRset); account != "" {
+		if owner, foreign := foreignOwner(account, ownerID, generated); foreign {
+			return &ForeignOwnerError{Kind: "RRset", Name: fmt.Sprintf("%s %s", name, rrType), Owner: owner}
+		}
+		return nil
+	}
+	if generated {
+		return nil
+	}
+	return &ForeignRRsetError{Name: name, Type: string(rrType)}
+}
+
+// checkZoneOwnership returns an error if the zone of PowerDNS is owned by another operator instance than the one of ownerID.
+// Zones without the account of an operator are adopted.
+func checkZoneOwnership(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone, ownerID string) error {
+	account := ptr.Deref(externalZone.Account, "")
+	if !isOperatorAccount(account) {
+		return nil
+	}
+	synchronized := ptr.Deref(zone.GetStatus().SyncStatus, "") == SUCCEEDED_STATUS
+	if owner, foreign := foreignOwner(account, ownerID, synchronized); foreign {
+		return &ForeignOwnerError{Kind: "Zone", Name: zone.GetName(), Owner: owner}
+	}
+	return nil
+}
+
+// operatorComment returns the comment written along with the RRSet, the content is the comment of the RRset, if any
+func operatorComment(rrset dnsv1alpha2.GenericRRset, ownerID string) powerdns.Comment {
+	return generatedComment(ptr.Deref(rrset.GetSpec().Comment, ""), ownerID)
+}
+
+// generatedComment returns the comment written along with the RRSets generated by the operator, with the content
+func generatedComment(content, ownerID string) powerdns.Comment {
+	return powerdns.Comment{Content: ptr.To(content), Account: ptr.To(operatorAccount(ownerID))}
+}
@@ -0,0 +1,139 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestCheckRRsetOwnership(t *testing.T) {
+	externalRRset := func(account *string) powerdns.RRset {
+		rrset := powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA)}
+		if account != nil {
+			rrset.Comments = []powerdns.Comment{{Content: ptr.To(""), Account: account}}
+		}
+		return rrset
+	}
+	rrset := func(status string, takeOver bool) *dnsv1alpha2.RRset {
+		r := &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "example"},
+			Spec:       dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		}
+		if status != "" {
+			r.Status.SyncStatus = ptr.To(status)
+		}
+		if takeOver {
+			r.Spec.TakeOver = ptr.To(true)
+		}
+		return r
+	}
+
+	var testCases = []struct {
+		description string
+		rrset       *dnsv1alpha2.RRset
+		account     *string
+		ownerID     string
+		want        string
+	}{
+		{"owned", rrset("", false), ptr.To("powerdns-operator"), "", ""},
+		{"owned with owner ID", rrset("", false), ptr.To("powerdns-operator/cluster-a"), "cluster-a", ""},
+		{"not marked", rrset("", false), nil, "", "foreign-rrset"},
+		{"other account", rrset("", false), ptr.To("admin"), "", "foreign-rrset"},
+		{"not marked, already synchronized", rrset(SUCCEEDED_STATUS, false), nil, "", ""},
+		{"not marked, taken over", rrset("", true), nil, "", ""},
+		{"other owner ID", rrset("", false), ptr.To("powerdns-operator/cluster-b"), "cluster-a", "foreign-owner"},
+		{"other owner ID, already synchronized", rrset(SUCCEEDED_STATUS, false), ptr.To("powerdns-operator/cluster-b"), "cluster-a", "foreign-owner"},
+		{"other owner ID, taken over", rrset("", true), ptr.To("powerdns-operator/cluster-b"), "cluster-a", "foreign-owner"},
+		{"other account, taken over", rrset("", true), ptr.To("admin"), "", ""},
+		{"owner ID set afterwards", rrset(SUCCEEDED_STATUS, false), ptr.To("powerdns-operator"), "cluster-a", ""},
+		{"no owner ID, other instance", rrset("", false), ptr.To("powerdns-operator"), "cluster-a", "foreign-owner"},
+		{"owner ID removed", rrset(SUCCEEDED_STATUS, false), ptr.To("powerdns-operator/cluster-a"), "", "foreign-owner"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := checkRRsetOwnership(tc.rrset, externalRRset(tc.account), tc.ownerID)
+			got := ""
+			if isForeignRRsetError(err) {
+				got = "foreign-rrset"
+			}
+			if isForeignOwnerError(err) {
+				got = "foreign-owner"
+			}
+			if got != tc.want {
+				t.Errorf("got %q (%v), want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckZoneOwnership(t *testing.T) {
+	var testCases = []struct {
+		description  string
+		account      string
+		ownerID      string
+		synchronized bool
+		foreign      bool
+	}{
+		{"no account", "", "cluster-a", false, false},
+		{"other account", "admin", "cluster-a", false, false},
+		{"owned", "powerdns-operator/cluster-a", "cluster-a", false, false},
+		{"other owner ID", "powerdns-operator/cluster-b", "cluster-a", true, true},
+		{"no owner ID, other instance", "powerdns-operator", "cluster-a", false, true},
+		{"owner ID set afterwards", "powerdns-operator", "cluster-a", true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "example"}}
+			if tc.synchronized {
+				zone.Status.SyncStatus = ptr.To(SUCCEEDED_STATUS)
+			}
+			err := checkZoneOwnership(zone, &powerdns.Zone{Account: ptr.To(tc.account)}, tc.ownerID)
+			if isForeignOwnerError(err) != tc.foreign {
+				t.Errorf("got %v, want foreign %v", err, tc.foreign)
+			}
+		})
+	}
+}
+
+func TestForeignOwnerError(t *testing.T) {
+	err := &ForeignOwnerError{Kind: "Zone", Name: "example.org", Owner: "cluster-b"}
+	want := `Zone example.org is managed by another operator instance (with owner ID "cluster-b")`
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestConfiguredAccount(t *testing.T) {
+	defer Configure(DefaultSettings())
+	s := DefaultSettings()
+	s.Account = "dns-team"
+	Configure(s)
+
+	if got := operatorAccount("cluster-a"); got != "dns-team/cluster-a" {
+		t.Errorf("got account %s, want dns-team/cluster-a", got)
+	}
+	if isOperatorAccount(OPERATOR_ACCOUNT) {
+		t.Errorf("the default account should no longer mark the objects of the operator")
+	}
+	owner, foreign := foreignOwner("dns-team/cluster-b", "cluster-a", false)
+	if !foreign || owner != "cluster-b" {
+		t.Errorf("got owner %q and foreign %t, want cluster-b and true", owner, foreign)
+	}
+}
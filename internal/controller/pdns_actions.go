@@ -0,0 +1,114 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+)
+
+// pdnsActionsClienter runs the operational endpoints of the PowerDNS API on a zone,
+// each call returns the result message of the PowerDNS API
+type pdnsActionsClienter interface {
+	Notify(ctx context.Context, domain string) (string, error)
+	Rectify(ctx context.Context, domain string) (string, error)
+	AxfrRetrieve(ctx context.Context, domain string) (string, error)
+	FlushCache(ctx context.Context, domain string) (string, error)
+}
+
+// ActionsClient implements the zone actions with a go-powerdns client.
+// Rectify is not provided by go-powerdns, it is requested directly with the same settings.
+type ActionsClient struct {
+	client     *powerdns.Client
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewActionsClient returns an ActionsClient using the PowerDNS client c, authenticated with apiKey
+func NewActionsClient(c *powerdns.Client, apiKey string) *ActionsClient {
+	return &ActionsClient{client: c, apiKey: apiKey, httpClient: http.DefaultClient}
+}
+
+func (a *ActionsClient) Notify(ctx context.Context, domain string) (string, error) {
+	result, err := a.client.Zones.Notify(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(result.Result, ""), nil
+}
+
+func (a *ActionsClient) AxfrRetrieve(ctx context.Context, domain string) (string, error) {
+	result, err := a.client.Zones.AxfrRetrieve(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(result.Result, ""), nil
+}
+
+func (a *ActionsClient) FlushCache(ctx context.Context, domain string) (string, error) {
+	result, err := a.client.Servers.CacheFlush(ctx, a.client.VHost, domain)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s, %d entries flushed", ptr.Deref(result.Result, ""), ptr.Deref(result.Count, 0)), nil
+}
+
+func (a *ActionsClient) Rectify(ctx context.Context, domain string) (string, error) {
+	u := url.URL{
+		Scheme: a.client.Scheme,
+		Host:   net.JoinHostPort(a.client.Hostname, a.client.Port),
+		Path:   fmt.Sprintf("/api/v1/servers/%s/zones/%s/rectify", a.client.VHost, makeCanonical(domain)),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "go-powerdns")
+	req.Header.Set("X-API-Key", a.apiKey)
+	for key, value := range a.client.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// PowerDNS answers {"result": "..."} on success and {"error": "..."} on failure
+	var result struct {
+		Result string `json:"result"`
+		Error  string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &result)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := result.Error
+		if message == "" {
+			message = strings.TrimSpace(string(body))
+		}
+		return "", &powerdns.Error{Status: resp.Status, StatusCode: resp.StatusCode, Message: message}
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+)
+
+func TestRectify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodPut:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/api/v1/servers/localhost/zones/example.org./rectify":
+			_, _ = w.Write([]byte(`{"result": "Rectified"}`))
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error": "Could not find domain"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewActionsClient(powerdns.New(server.URL, ""), "secret")
+
+	var testCases = []struct {
+		domain string
+		want   string
+		status int
+	}{
+		{"example.org", "Rectified", 0},
+		{"example.org.", "Rectified", 0},
+		{"example.com", "", http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.domain, func(t *testing.T) {
+			got, err := client.Rectify(context.Background(), tc.domain)
+			if tc.status != 0 {
+				pdnsErr, ok := err.(*powerdns.Error)
+				if !ok || pdnsErr.StatusCode != tc.status || pdnsErr.Message != "Could not find domain" {
+					t.Fatalf("got error %v, want status %d", err, tc.status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/pdnsfake"
+)
+
+// writeRequests returns the number of requests modifying the PowerDNS API
+func writeRequests(s *pdnsfake.Server) int {
+	count := 0
+	for _, r := range s.Requests() {
+		if r.Method != http.MethodGet {
+			count++
+		}
+	}
+	return count
+}
+
+// TestExternalResourcesWithFakeAPI runs the synchronization of the external resources
+// through the real go-powerdns client, against the fake PowerDNS API
+func TestExternalResourcesWithFakeAPI(t *testing.T) {
+	server := pdnsfake.New()
+	defer server.Close()
+	c := server.Client()
+	pdnsClient := PdnsClienter{
+		Records:  c.Records,
+		Zones:    c.Zones,
+		Metadata: c.Metadata,
+		Actions:  NewActionsClient(c, pdnsfake.APIKey),
+	}
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	zone := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "example"},
+		Spec: dnsv1alpha2.ZoneSpec{
+			Kind:           NATIVE_KIND_ZONE,
+			Nameservers:    []string{"ns1.example.org", "ns2.example.org"},
+			NameserversTTL: ptr.To(uint32(600)),
+			SOAEditAPI:     ptr.To("DEFAULT"),
+		},
+	}
+	rrset := func(name, rrType string, records ...string) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "example"},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    name,
+				Type:    rrType,
+				TTL:     300,
+				Records: records,
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"},
+			},
+		}
+	}
+
+	t.Run("zone creation", func(t *testing.T) {
+		zoneRes, err := getZoneExternalResources(ctx, "example.org", pdnsClient, log)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		status, message, _, _, err := zoneExternalResourcesReconcile(ctx, zoneRes, zone, pdnsClient, log)
+		if err != nil || status != nil {
+			t.Fatalf("got status %v (%s), %v", status, message, err)
+		}
+		created := server.Zone("example.org")
+		if created == nil || *created.Account != operatorAccount("") {
+			t.Fatalf("zone not created as owned: %+v", created)
+		}
+		ns := server.RRset("example.org", "example.org", powerdns.RRTypeNS)
+		if ns == nil || *ns.TTL != 600 || len(ns.Records) != 2 {
+			t.Errorf("unexpected NS RRset %+v", ns)
+		}
+	})
+
+	t.Run("zone already synchronized", func(t *testing.T) {
+		writes := writeRequests(server)
+		zoneRes, err := getZoneExternalResources(ctx, "example.org", pdnsClient, log)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, _, _, err := zoneExternalResourcesReconcile(ctx, zoneRes, zone, pdnsClient, log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := writeRequests(server) - writes; got != 0 {
+			t.Errorf("got %d write requests, want none", got)
+		}
+	})
+
+	t.Run("RRset creation and update", func(t *testing.T) {
+		www := rrset("www", "A", "192.0.2.1", "192.0.2.2")
+		changed, err := createOrUpdateRrsetExternalResources(ctx, zone, www, pdnsClient)
+		if err != nil || !changed {
+			t.Fatalf("got %t, %v, want RRset created", changed, err)
+		}
+		// PowerDNS answers the records in its own order and form
+		changed, err = createOrUpdateRrsetExternalResources(ctx, zone, rrset("www", "A", "192.0.2.2", "192.0.2.1"), pdnsClient)
+		if err != nil || changed {
+			t.Errorf("got %t, %v, want RRset unchanged", changed, err)
+		}
+		www.Spec.Comment = ptr.To("front")
+		changed, err = createOrUpdateRrsetExternalResources(ctx, zone, www, pdnsClient)
+		if err != nil || !changed {
+			t.Fatalf("got %t, %v, want RRset updated", changed, err)
+		}
+		got := server.RRset("example.org", "www.example.org", powerdns.RRTypeA)
+		if len(got.Comments) != 1 || *got.Comments[0].Content != "front" || *got.Comments[0].Account != operatorAccount("") {
+			t.Errorf("unexpected comments %+v", got.Comments)
+		}
+	})
+
+	t.Run("foreign RRset", func(t *testing.T) {
+		err := server.AddRRset("example.org", powerdns.RRset{
+			Name:    ptr.To("manual.example.org."),
+			Type:    ptr.To(powerdns.RRTypeTXT),
+			TTL:     ptr.To(uint32(300)),
+			Records: []powerdns.Record{{Content: ptr.To(`"manual"`)}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		manual := rrset("manual", "TXT", `"managed"`)
+		if _, err := createOrUpdateRrsetExternalResources(ctx, zone, manual, pdnsClient); !isForeignRRsetError(err) {
+			t.Fatalf("got error %v, want foreign RRset", err)
+		}
+		if err := deleteRrsetExternalResources(ctx, zone, manual, pdnsClient, log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if server.RRset("example.org", "manual.example.org", powerdns.RRTypeTXT) == nil {
+			t.Fatalf("foreign RRset deleted")
+		}
+		manual.Spec.TakeOver = ptr.To(true)
+		if _, err := createOrUpdateRrsetExternalResources(ctx, zone, manual, pdnsClient); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := server.RRset("example.org", "manual.example.org", powerdns.RRTypeTXT)
+		if !isOwnedRRset(*got, "") || *got.Records[0].Content != `"managed"` {
+			t.Errorf("RRset not taken over: %+v", got)
+		}
+	})
+
+	t.Run("LUA RRset", func(t *testing.T) {
+		front := rrset("front", LUA_RECORD_TYPE, `A "pickrandom({'192.0.2.1', '192.0.2.2'})"`)
+		if _, err := createOrUpdateRrsetExternalResources(ctx, zone, front, pdnsClient); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := server.Metadata("example.org", METADATA_ENABLE_LUA_RECORDS); !slices.Equal(got, []string{"1"}) {
+			t.Errorf("got metadata %v, want LUA records enabled", got)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		server.FailNext(http.MethodPatch, http.StatusInternalServerError, "Backend error")
+		_, err := createOrUpdateRrsetExternalResources(ctx, zone, rrset("api", "A", "192.0.2.3"), pdnsClient)
+		if err == nil || err.Error() != "Backend error" {
+			t.Errorf("got error %v, want Backend error", err)
+		}
+	})
+
+	t.Run("zone action", func(t *testing.T) {
+		result, err := pdnsClient.Actions.Rectify(ctx, "example.org")
+		if err != nil || result != "Rectified" {
+			t.Errorf("got %q, %v", result, err)
+		}
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		if err := deleteRrsetExternalResources(ctx, zone, rrset("www", "A"), pdnsClient, log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if server.RRset("example.org", "www.example.org", powerdns.RRTypeA) != nil {
+			t.Errorf("RRset not deleted")
+		}
+		if err := deleteZoneExternalResources(ctx, zone, pdnsClient, log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if server.Zone("example.org") != nil {
+			t.Errorf("zone not deleted")
+		}
+		// The zone is already deleted
+		if err := deleteZoneExternalResources(ctx, zone, pdnsClient, log); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
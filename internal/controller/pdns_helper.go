@@ -14,11 +14,13 @@ package controller
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/joeig/go-powerdns/v3"
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/lua"
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
 	"k8s.io/utils/ptr"
 )
 
@@ -26,6 +28,9 @@ const (
 	FAILED_STATUS    = "Failed"
 	PENDING_STATUS   = "Pending"
 	SUCCEEDED_STATUS = "Succeeded"
+
+	LUA_RECORD_TYPE             = "LUA"
+	METADATA_ENABLE_LUA_RECORDS = powerdns.MetadataKind("ENABLE-LUA-RECORDS")
 )
 
 type pdnsRecordsClienter interface {
@@ -41,30 +46,47 @@ type pdnsZonesClienter interface {
 	Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error)
 }
 
+type pdnsMetadataClienter interface {
+	Get(ctx context.Context, domain string, kind powerdns.MetadataKind) (*powerdns.Metadata, error)
+	Set(ctx context.Context, domain string, kind powerdns.MetadataKind, values []string) (*powerdns.Metadata, error)
+}
+
 type PdnsClienter struct {
-	Records pdnsRecordsClienter
-	Zones   pdnsZonesClienter
+	Records  pdnsRecordsClienter
+	Zones    pdnsZonesClienter
+	Metadata pdnsMetadataClienter
+	Actions  pdnsActionsClienter
+	// OwnerID identifies the operator instance among the ones sharing the PowerDNS server, see operatorAccount
+	OwnerID string
 }
 
 // zoneIsIdenticalToExternalZone return True, True if respectively kind, soa_edit_api and catalog are identical
-// and nameservers are identical between Zone and External Resource
-func zoneIsIdenticalToExternalZone(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone, ns []string) (bool, bool) {
+// and nameservers (in any order) and their TTL, if specified, are identical between Zone and External Resource
+func zoneIsIdenticalToExternalZone(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone, ns []string, nsTTL uint32) (bool, bool) {
 	zoneCatalog := makeCanonical(ptr.Deref(zone.GetSpec().Catalog, ""))
 	externalZoneCatalog := ptr.Deref(externalZone.Catalog, "")
 	zoneSOAEditAPI := ptr.Deref(zone.GetSpec().SOAEditAPI, "")
 	externalZoneSOAEditAPI := ptr.Deref(externalZone.SOAEditAPI, "")
-	return zone.GetSpec().Kind == string(*externalZone.Kind) && zoneCatalog == externalZoneCatalog && zoneSOAEditAPI == externalZoneSOAEditAPI, reflect.DeepEqual(zone.GetSpec().Nameservers, ns)
+	nsTTLIdentical := zone.GetSpec().NameserversTTL == nil || *zone.GetSpec().NameserversTTL == nsTTL
+	return zone.GetSpec().Kind == string(*externalZone.Kind) && zoneCatalog == externalZoneCatalog && zoneSOAEditAPI == externalZoneSOAEditAPI, sameRecords(normalize.Records(string(powerdns.RRTypeNS), zone.GetSpec().Nameservers), normalize.Records(string(powerdns.RRTypeNS), ns)) && nsTTLIdentical
+}
+
+// sameRecords returns True if both lists hold the same records, in any order
+func sameRecords(a, b []string) bool {
+	sortedA := slices.Clone(a)
+	slices.Sort(sortedA)
+	sortedB := slices.Clone(b)
+	slices.Sort(sortedB)
+	return slices.Equal(slices.Compact(sortedA), slices.Compact(sortedB))
 }
 
-// rrsetIsIdenticalToExternalRRset return True if Comments, Name, Type, TTL and Records are identical between RRSet and External Resource
+// rrsetIsIdenticalToExternalRRset return True if Comments, Name, Type, TTL and Records (in any order) are identical between RRSet and External Resource.
+// Records are normalized according to their type beforehand, as PowerDNS returns them in a canonical form.
 func rrsetIsIdenticalToExternalRRset(rrset dnsv1alpha2.GenericRRset, externalRecord powerdns.RRset) bool {
+	// The operator writes an empty comment along with a RRSet without comment, see operatorComment
 	commentsIdentical := true
 	if len(externalRecord.Comments) != 0 {
-		if rrset.GetSpec().Comment != nil {
-			commentsIdentical = *rrset.GetSpec().Comment == *(externalRecord.Comments[0].Content)
-		} else {
-			commentsIdentical = false
-		}
+		commentsIdentical = ptr.Deref(rrset.GetSpec().Comment, "") == ptr.Deref(externalRecord.Comments[0].Content, "")
 	} else {
 		if rrset.GetSpec().Comment != nil {
 			commentsIdentical = false
@@ -75,8 +97,33 @@ func rrsetIsIdenticalToExternalRRset(rrset dnsv1alpha2.GenericRRset, externalRec
 	for _, r := range externalRecord.Records {
 		externalRecordsSlice = append(externalRecordsSlice, *r.Content)
 	}
+	records, err := rrsetRecords(rrset)
+	if err != nil {
+		return false
+	}
 	name := getRRsetName(rrset)
-	return name == *externalRecord.Name && rrset.GetSpec().Type == string(*externalRecord.Type) && rrset.GetSpec().TTL == *(externalRecord.TTL) && commentsIdentical && reflect.DeepEqual(rrset.GetSpec().Records, externalRecordsSlice)
+	return name == *externalRecord.Name && rrset.GetSpec().Type == string(*externalRecord.Type) && rrset.GetSpec().TTL == *(externalRecord.TTL) && commentsIdentical && sameRecords(normalize.Records(rrset.GetSpec().Type, records), normalize.Records(rrset.GetSpec().Type, externalRecordsSlice))
+}
+
+// rrsetRecords returns the records of the RRSet, built from its LUA specification if any,
+// without the records withdrawn by its health check
+func rrsetRecords(rrset dnsv1alpha2.GenericRRset) ([]string, error) {
+	if rrset.GetSpec().Lua != nil {
+		content, err := lua.Content(rrset.GetSpec().Lua)
+		if err != nil {
+			return nil, err
+		}
+		return []string{content}, nil
+	}
+	if rrset.GetSpec().Type == LUA_RECORD_TYPE {
+		for _, r := range rrset.GetSpec().Records {
+			if err := lua.ValidateRecord(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// The records of the unhealthy addresses are withdrawn
+	return publishedRecords(rrset), nil
 }
 
 func makeCanonical(in string) string {
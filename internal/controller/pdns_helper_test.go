@@ -168,11 +168,62 @@ func TestZoneIsIdenticalToExternalZone(t *testing.T) {
 			false,
 			true,
 		},
+		{
+			"Identical Zones with NS in another order",
+			&dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        MASTER_KIND_ZONE,
+					Nameservers: nameservers,
+					Catalog:     &catalog,
+					SOAEditAPI:  &soaEditApi,
+				},
+			},
+			&powerdns.Zone{
+				ID:         &name,
+				Name:       &name,
+				Kind:       &kind,
+				Catalog:    &catalog,
+				SOAEditAPI: &soaEditApi,
+			},
+			[]string{nameservers[1], nameservers[0]},
+			true,
+			true,
+		},
+		{
+			"Different Zones on NS TTL",
+			&dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:           MASTER_KIND_ZONE,
+					Nameservers:    nameservers,
+					NameserversTTL: ptr.To(uint32(3600)),
+					Catalog:        &catalog,
+					SOAEditAPI:     &soaEditApi,
+				},
+			},
+			&powerdns.Zone{
+				ID:         &name,
+				Name:       &name,
+				Kind:       &kind,
+				Catalog:    &catalog,
+				SOAEditAPI: &soaEditApi,
+			},
+			nameservers,
+			true,
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			zone, ns := zoneIsIdenticalToExternalZone(tc.genericZone, tc.externalZone, tc.nameservers)
+			zone, ns := zoneIsIdenticalToExternalZone(tc.genericZone, tc.externalZone, tc.nameservers, DEFAULT_TTL_FOR_NS_RECORDS)
 			if !cmp.Equal(zone, tc.zonesIdentical) {
 				t.Errorf("ZONE: got %v, want %v", zone, tc.zonesIdentical)
 			}
@@ -418,6 +469,43 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Identical RRsets with records in another order",
+			&dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					Name:    recordName,
+					Type:    recordType1,
+					TTL:     recordTtl1,
+					Records: records,
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: zoneName,
+						Kind: "Zone",
+					},
+				},
+			},
+			&powerdns.RRset{
+				Name: &fqdnName,
+				Type: (*powerdns.RRType)(&recordType1),
+				TTL:  &recordTtl1,
+				Records: []powerdns.Record{
+					{
+						Content:  &recordContent2,
+						Disabled: ptr.To(false),
+						SetPTR:   ptr.To(false),
+					},
+					{
+						Content:  &recordContent1,
+						Disabled: ptr.To(false),
+						SetPTR:   ptr.To(false),
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -21,6 +21,20 @@ var (
 		},
 		[]string{"fqdn", "type", "status", "name"},
 	)
+	rrsetsTargetsHealthMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rrsets_target_health",
+			Help: "Health of the addresses of RRsets with a health check, 1 if healthy, 0 otherwise",
+		},
+		[]string{"fqdn", "type", "address", "name", "namespace"},
+	)
+	clusterRrsetsTargetsHealthMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterrrsets_target_health",
+			Help: "Health of the addresses of ClusterRRsets with a health check, 1 if healthy, 0 otherwise",
+		},
+		[]string{"fqdn", "type", "address", "name"},
+	)
 	zonesStatusesMetric = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "zones_status",
@@ -47,6 +61,17 @@ func updateRrsetsMetrics(fqdn string, gr dnsv1alpha2.GenericRRset) {
 			"name":      gr.GetName(),
 			"namespace": gr.GetNamespace(),
 		}).Set(1)
+		// The addresses no longer checked are removed
+		rrsetsTargetsHealthMetric.DeletePartialMatch(map[string]string{"name": gr.GetName(), "namespace": gr.GetNamespace()})
+		for _, target := range gr.GetStatus().HealthChecks {
+			rrsetsTargetsHealthMetric.With(map[string]string{
+				"fqdn":      fqdn,
+				"type":      gr.GetSpec().Type,
+				"address":   target.Address,
+				"name":      gr.GetName(),
+				"namespace": gr.GetNamespace(),
+			}).Set(healthValue(target))
+		}
 
 	case *dnsv1alpha2.ClusterRRset:
 		clusterRrsetsStatusesMetric.With(map[string]string{
@@ -55,9 +80,27 @@ func updateRrsetsMetrics(fqdn string, gr dnsv1alpha2.GenericRRset) {
 			"status": *gr.GetStatus().SyncStatus,
 			"name":   gr.GetName(),
 		}).Set(1)
+		clusterRrsetsTargetsHealthMetric.DeletePartialMatch(map[string]string{"name": gr.GetName()})
+		for _, target := range gr.GetStatus().HealthChecks {
+			clusterRrsetsTargetsHealthMetric.With(map[string]string{
+				"fqdn":    fqdn,
+				"type":    gr.GetSpec().Type,
+				"address": target.Address,
+				"name":    gr.GetName(),
+			}).Set(healthValue(target))
+		}
 	}
 
 }
+
+// healthValue returns the value of the health metric of an address
+func healthValue(target dnsv1alpha2.TargetHealth) float64 {
+	if target.Healthy {
+		return 1
+	}
+	return 0
+}
+
 func removeRrsetMetrics(gr dnsv1alpha2.GenericRRset) {
 	switch gr.(type) {
 	case *dnsv1alpha2.RRset:
@@ -72,6 +115,18 @@ func removeRrsetMetrics(gr dnsv1alpha2.GenericRRset) {
 				"name": gr.GetName(),
 			},
 		)
+		rrsetsTargetsHealthMetric.DeletePartialMatch(
+			map[string]string{
+				"namespace": gr.GetNamespace(),
+				"name":      gr.GetName(),
+			},
+		)
+	case *dnsv1alpha2.ClusterRRset:
+		clusterRrsetsTargetsHealthMetric.DeletePartialMatch(
+			map[string]string{
+				"name": gr.GetName(),
+			},
+		)
 	}
 }
 
@@ -146,6 +201,17 @@ func getClusterZoneMetricWithLabels(zoneStatus, zoneName string) float64 {
 	}))
 }
 
+//nolint:unparam
+func getRrsetTargetHealthMetric(rrsetFQDN, rrsetType, address, rrsetName, rrsetNamespace string) float64 {
+	return testutil.ToFloat64(rrsetsTargetsHealthMetric.With(prometheus.Labels{
+		"fqdn":      rrsetFQDN,
+		"type":      rrsetType,
+		"address":   address,
+		"name":      rrsetName,
+		"namespace": rrsetNamespace,
+	}))
+}
+
 func countRrsetsMetrics() int {
 	return testutil.CollectAndCount(rrsetsStatusesMetric)
 }
@@ -0,0 +1,235 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+
+	"github.com/joeig/go-powerdns/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/tracing"
+)
+
+const (
+	zoneAttributeKey     = attribute.Key("dns.zone")
+	rrsetAttributeKey    = attribute.Key("dns.rrset")
+	typeAttributeKey     = attribute.Key("dns.type")
+	metadataAttributeKey = attribute.Key("dns.metadata.kind")
+)
+
+// startSpan starts a span from the global tracer provider.
+// The provider is looked up on each call so that it can be replaced (e.g. by tests)
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracing.TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err (if any) on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// zoneAttributes returns the span attributes describing a Zone/ClusterZone
+func zoneAttributes(zone dnsv1alpha2.GenericZone) []attribute.KeyValue {
+	return []attribute.KeyValue{zoneAttributeKey.String(makeCanonical(zone.GetName()))}
+}
+
+// rrsetAttributes returns the span attributes describing a RRset/ClusterRRset
+func rrsetAttributes(rrset dnsv1alpha2.GenericRRset) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		zoneAttributeKey.String(makeCanonical(rrset.GetSpec().ZoneRef.Name)),
+		rrsetAttributeKey.String(getRRsetName(rrset)),
+		typeAttributeKey.String(rrset.GetSpec().Type),
+	}
+}
+
+// objectAttributes returns the span attributes describing a Kubernetes object
+func objectAttributes(obj client.Object) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("k8s.object.name", obj.GetName()),
+		attribute.String("k8s.object.namespace", obj.GetNamespace()),
+	}
+	switch o := obj.(type) {
+	case dnsv1alpha2.GenericZone:
+		attrs = append(attrs, zoneAttributes(o)...)
+	case dnsv1alpha2.GenericRRset:
+		attrs = append(attrs, rrsetAttributes(o)...)
+	}
+	return attrs
+}
+
+// NewTracedPdnsClienter wraps every PowerDNS client call in a span
+func NewTracedPdnsClienter(c PdnsClienter) PdnsClienter {
+	return PdnsClienter{
+		Records:  tracedRecordsClient{c.Records},
+		Zones:    tracedZonesClient{c.Zones},
+		Metadata: tracedMetadataClient{c.Metadata},
+		Actions:  tracedActionsClient{c.Actions},
+		OwnerID:  c.OwnerID,
+	}
+}
+
+type tracedRecordsClient struct {
+	next pdnsRecordsClienter
+}
+
+func (t tracedRecordsClient) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) (err error) {
+	ctx, span := startSpan(ctx, "Records.Delete", zoneAttributeKey.String(domain), rrsetAttributeKey.String(name), typeAttributeKey.String(string(recordType)))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, domain, name, recordType)
+}
+
+func (t tracedRecordsClient) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...func(*powerdns.RRset)) (err error) {
+	ctx, span := startSpan(ctx, "Records.Change", zoneAttributeKey.String(domain), rrsetAttributeKey.String(name), typeAttributeKey.String(string(recordType)))
+	defer func() { endSpan(span, err) }()
+	return t.next.Change(ctx, domain, name, recordType, ttl, content, options...)
+}
+
+func (t tracedRecordsClient) Get(ctx context.Context, domain, name string, recordType *powerdns.RRType) (_ []powerdns.RRset, err error) {
+	attrs := []attribute.KeyValue{zoneAttributeKey.String(domain), rrsetAttributeKey.String(name)}
+	if recordType != nil {
+		attrs = append(attrs, typeAttributeKey.String(string(*recordType)))
+	}
+	ctx, span := startSpan(ctx, "Records.Get", attrs...)
+	defer func() { endSpan(span, err) }()
+	return t.next.Get(ctx, domain, name, recordType)
+}
+
+type tracedZonesClient struct {
+	next pdnsZonesClienter
+}
+
+func (t tracedZonesClient) Get(ctx context.Context, domain string) (_ *powerdns.Zone, err error) {
+	ctx, span := startSpan(ctx, "Zones.Get", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.Get(ctx, domain)
+}
+
+func (t tracedZonesClient) Delete(ctx context.Context, domain string) (err error) {
+	ctx, span := startSpan(ctx, "Zones.Delete", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, domain)
+}
+
+func (t tracedZonesClient) Change(ctx context.Context, domain string, zone *powerdns.Zone) (err error) {
+	ctx, span := startSpan(ctx, "Zones.Change", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.Change(ctx, domain, zone)
+}
+
+func (t tracedZonesClient) Add(ctx context.Context, zone *powerdns.Zone) (_ *powerdns.Zone, err error) {
+	ctx, span := startSpan(ctx, "Zones.Add", zoneAttributeKey.String(ptr.Deref(zone.Name, "")))
+	defer func() { endSpan(span, err) }()
+	return t.next.Add(ctx, zone)
+}
+
+type tracedMetadataClient struct {
+	next pdnsMetadataClienter
+}
+
+func (t tracedMetadataClient) Get(ctx context.Context, domain string, kind powerdns.MetadataKind) (_ *powerdns.Metadata, err error) {
+	ctx, span := startSpan(ctx, "Metadata.Get", zoneAttributeKey.String(domain), metadataAttributeKey.String(string(kind)))
+	defer func() { endSpan(span, err) }()
+	return t.next.Get(ctx, domain, kind)
+}
+
+func (t tracedMetadataClient) Set(ctx context.Context, domain string, kind powerdns.MetadataKind, values []string) (_ *powerdns.Metadata, err error) {
+	ctx, span := startSpan(ctx, "Metadata.Set", zoneAttributeKey.String(domain), metadataAttributeKey.String(string(kind)))
+	defer func() { endSpan(span, err) }()
+	return t.next.Set(ctx, domain, kind, values)
+}
+
+type tracedActionsClient struct {
+	next pdnsActionsClienter
+}
+
+func (t tracedActionsClient) Notify(ctx context.Context, domain string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Actions.Notify", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.Notify(ctx, domain)
+}
+
+func (t tracedActionsClient) Rectify(ctx context.Context, domain string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Actions.Rectify", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.Rectify(ctx, domain)
+}
+
+func (t tracedActionsClient) AxfrRetrieve(ctx context.Context, domain string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Actions.AxfrRetrieve", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.AxfrRetrieve(ctx, domain)
+}
+
+func (t tracedActionsClient) FlushCache(ctx context.Context, domain string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "Actions.FlushCache", zoneAttributeKey.String(domain))
+	defer func() { endSpan(span, err) }()
+	return t.next.FlushCache(ctx, domain)
+}
+
+// NewTracedClient wraps the Kubernetes client calls issued by the reconcilers
+// (Get, List, Update and status patches) in spans
+func NewTracedClient(c client.Client) client.Client {
+	return tracedClient{c}
+}
+
+type tracedClient struct {
+	client.Client
+}
+
+func (t tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) (err error) {
+	ctx, span := startSpan(ctx, "Client.Get", attribute.String("k8s.object.name", key.Name), attribute.String("k8s.object.namespace", key.Namespace))
+	defer func() { endSpan(span, err) }()
+	return t.Client.Get(ctx, key, obj, opts...)
+}
+
+func (t tracedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	ctx, span := startSpan(ctx, "Client.List")
+	defer func() { endSpan(span, err) }()
+	return t.Client.List(ctx, list, opts...)
+}
+
+func (t tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := startSpan(ctx, "Client.Update", objectAttributes(obj)...)
+	defer func() { endSpan(span, err) }()
+	return t.Client.Update(ctx, obj, opts...)
+}
+
+func (t tracedClient) Status() client.SubResourceWriter {
+	return tracedStatusWriter{t.Client.Status()}
+}
+
+type tracedStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (t tracedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) (err error) {
+	ctx, span := startSpan(ctx, "Status.Patch", objectAttributes(obj)...)
+	defer func() { endSpan(span, err) }()
+	return t.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func (t tracedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) (err error) {
+	ctx, span := startSpan(ctx, "Status.Update", objectAttributes(obj)...)
+	defer func() { endSpan(span, err) }()
+	return t.SubResourceWriter.Update(ctx, obj, opts...)
+}
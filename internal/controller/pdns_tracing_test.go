@@ -0,0 +1,133 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// setupInMemoryTracing registers a global tracer provider which synchronously records spans
+// in memory, so tests can inspect them with GetSpans().
+// The returned function restores the previous global tracer provider.
+func setupInMemoryTracing() (*tracetest.InMemoryExporter, func()) {
+	previous := otel.GetTracerProvider()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+
+	return exporter, func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	}
+}
+
+func TestTracedPdnsClienter(t *testing.T) {
+	var (
+		zoneName  = "example.org"
+		namespace = "example"
+
+		rrsetName    = "test2"
+		rrsetType    = "A"
+		rrsetTTL     = uint32(1500)
+		rrsetRecords = []string{"1.1.1.3", "2.2.2.4"}
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: []string{"ns1.example.org"}}}
+	rrset := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "test2.example.org", Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType, Name: rrsetName, TTL: rrsetTTL, Records: rrsetRecords}}
+
+	var testCases = []struct {
+		description string
+		call        func(PdnsClienter) error
+		want        []string
+		attributes  map[attribute.Key]string
+		failed      bool
+	}{
+		{
+			"Zone retrieval",
+			func(c PdnsClienter) error {
+				_, err := getZoneExternalResources(ctx, zoneName, c, log)
+				return err
+			},
+			[]string{"Zones.Get"},
+			map[attribute.Key]string{zoneAttributeKey: zoneName},
+			false,
+		},
+		{
+			"RRset creation",
+			func(c PdnsClienter) error {
+				_, err := createOrUpdateRrsetExternalResources(ctx, zone, rrset, c)
+				return err
+			},
+			[]string{"Records.Get", "Records.Change"},
+			map[attribute.Key]string{zoneAttributeKey: zoneName, rrsetAttributeKey: "test2.example.org.", typeAttributeKey: rrsetType},
+			false,
+		},
+		{
+			"communication error",
+			func(c PdnsClienter) error {
+				_, err := getZoneExternalResources(ctx, FAKE_SITE, c, log)
+				return err
+			},
+			[]string{"Zones.Get"},
+			map[attribute.Key]string{zoneAttributeKey: FAKE_SITE},
+			true,
+		},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+	exporter, teardownTracing := setupInMemoryTracing()
+	defer teardownTracing()
+	tracedClient := NewTracedPdnsClienter(PDNSClient)
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			exporter.Reset()
+			_ = tc.call(tracedClient)
+
+			spans := exporter.GetSpans()
+			names := []string{}
+			for _, s := range spans {
+				names = append(names, s.Name)
+			}
+			if !cmp.Equal(names, tc.want) {
+				t.Errorf("got %v, want %v", names, tc.want)
+			}
+			if len(spans) == 0 {
+				t.Fatalf("no span recorded")
+			}
+			last := spans[len(spans)-1]
+			for _, a := range last.Attributes {
+				if want, ok := tc.attributes[a.Key]; ok && a.Value.AsString() != want {
+					t.Errorf("attribute %s: got %v, want %v", a.Key, a.Value.AsString(), want)
+				}
+			}
+			if failed := last.Status.Code == codes.Error; failed != tc.failed {
+				t.Errorf("failed: got %v, want %v", failed, tc.failed)
+			}
+		})
+	}
+}
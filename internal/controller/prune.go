@@ -0,0 +1,230 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
+)
+
+const (
+	ADDITIVE_RECORDS_POLICY      = "Additive"
+	AUTHORITATIVE_RECORDS_POLICY = "Authoritative"
+	// PRUNE_PERIOD is the default period zones with the "Authoritative" records policy are reconciled again,
+	// RRSets may be added to PowerDNS outside of Kubernetes at any time
+	PRUNE_PERIOD = 5 * time.Minute
+)
+
+// isAuthoritative returns True if the RRSets of the zone not declared in Kubernetes must be pruned
+func isAuthoritative(gz dnsv1alpha2.GenericZone) bool {
+	return ptr.Deref(gz.GetSpec().RecordsPolicy, ADDITIVE_RECORDS_POLICY) == AUTHORITATIVE_RECORDS_POLICY
+}
+
+// reconcilePrune deletes the RRSets of a zone with the "Authoritative" records policy which are not declared in Kubernetes.
+// In report only mode, nothing is deleted and the RRSets are returned as "<name> <type>".
+func reconcilePrune(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) ([]string, error) {
+	if !isAuthoritative(gz) {
+		return nil, nil
+	}
+
+	zone, err := PDNSClient.Zones.Get(ctx, gz.GetName())
+	if err != nil {
+		return nil, err
+	}
+	declared, err := declaredRRsets(ctx, gz, cl)
+	if err != nil {
+		return nil, err
+	}
+	children, err := childZones(ctx, gz, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmanaged []string
+	for _, rrset := range staleRRsets(gz, zone.RRsets, declared, children, PDNSClient.OwnerID) {
+		if gz.GetSpec().Prune != nil && gz.GetSpec().Prune.ReportOnly {
+			unmanaged = append(unmanaged, fmt.Sprintf("%s %s", *rrset.Name, *rrset.Type))
+			continue
+		}
+		log.Info("Deleting RRset not declared in Kubernetes", "RRset.Name", *rrset.Name, "RRset.Type", *rrset.Type)
+		if err := PDNSClient.Records.Delete(ctx, gz.GetName(), *rrset.Name, *rrset.Type); err != nil {
+			return nil, err
+		}
+	}
+	return unmanaged, nil
+}
+
+// declaredRRsets returns the "<name>/<type>" keys of the RRSets declared in the zone by RRsets and ClusterRRsets,
+// including the PTR records they generate. The RRsets denied access to their zone declare nothing.
+func declaredRRsets(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client) (map[string]bool, error) {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets); err != nil {
+		return nil, err
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets); err != nil {
+		return nil, err
+	}
+	all := make([]dnsv1alpha2.GenericRRset, 0, len(rrsets.Items)+len(clusterRRsets.Items))
+	for i := range rrsets.Items {
+		all = append(all, &rrsets.Items[i])
+	}
+	for i := range clusterRRsets.Items {
+		all = append(all, &clusterRRsets.Items[i])
+	}
+
+	declared := map[string]bool{}
+	for _, rr := range all {
+		if isDeniedRRset(rr) {
+			continue
+		}
+		if referencesZone(rr, gz) {
+			declared[rrsetKey(getRRsetName(rr), rr.GetSpec().Type)] = true
+		}
+		if !ptr.Deref(rr.GetSpec().ManagePTR, false) {
+			continue
+		}
+		for _, address := range rr.GetSpec().Records {
+			if name, ok := reverseName(address); ok && isSubdomain(name, gz.GetName()) {
+				declared[rrsetKey(name, string(powerdns.RRTypePTR))] = true
+			}
+		}
+	}
+	return declared, nil
+}
+
+// referencesZone returns True if the zone reference of the RRset/ClusterRRset matches the kind, namespace and name of the zone
+func referencesZone(rr dnsv1alpha2.GenericRRset, gz dnsv1alpha2.GenericZone) bool {
+	if rr.GetSpec().ZoneRef.Kind != zoneKind(gz) || makeCanonical(rr.GetSpec().ZoneRef.Name) != makeCanonical(gz.GetName()) {
+		return false
+	}
+	namespace := ""
+	if rrset, ok := rr.(*dnsv1alpha2.RRset); ok && zoneKind(gz) == "Zone" {
+		namespace = zoneRefNamespace(rrset)
+	}
+	return namespace == gz.GetNamespace()
+}
+
+// isDeniedRRset returns True if the RRset/ClusterRRset is forbidden to use its zone, or violates its ZonePolicy
+func isDeniedRRset(rr dnsv1alpha2.GenericRRset) bool {
+	condition := meta.FindStatusCondition(rr.GetStatus().Conditions, "Available")
+	return condition != nil && (condition.Reason == RrsetReasonForbidden || condition.Reason == RrsetReasonPolicyViolation)
+}
+
+// childZones returns the names of the Zones/ClusterZones which are subdomains of the zone,
+// their delegation records in the zone are managed by the operator
+func childZones(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client) ([]string, error) {
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones); err != nil {
+		return nil, err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones); err != nil {
+		return nil, err
+	}
+	var children []string
+	for _, z := range zones.Items {
+		if z.Name != strings.TrimSuffix(gz.GetName(), ".") && isSubdomain(z.Name, gz.GetName()) {
+			children = append(children, z.Name)
+		}
+	}
+	for _, z := range clusterZones.Items {
+		if z.Name != strings.TrimSuffix(gz.GetName(), ".") && isSubdomain(z.Name, gz.GetName()) {
+			children = append(children, z.Name)
+		}
+	}
+	return children, nil
+}
+
+// staleRRsets returns, sorted by name and type, the RRSets of the zone not accounted for by Kubernetes:
+// the SOA and NS records of the zone, the declared RRSets, the delegation records of the child zones,
+// the RRSets of the allowlist and the RRSets owned by another operator instance are kept
+func staleRRsets(gz dnsv1alpha2.GenericZone, rrsets []powerdns.RRset, declared map[string]bool, children []string, ownerID string) []powerdns.RRset {
+	var stale []powerdns.RRset
+	for _, rrset := range rrsets {
+		name := makeCanonical(ptr.Deref(rrset.Name, ""))
+		rrType := ptr.Deref(rrset.Type, "")
+		switch {
+		case strings.EqualFold(name, makeCanonical(gz.GetName())) && (rrType == powerdns.RRTypeSOA || rrType == powerdns.RRTypeNS):
+		case declared[rrsetKey(name, string(rrType))]:
+		case isDelegationRecord(name, rrType, children):
+		case isAllowlisted(gz.GetSpec().Prune, name, rrType):
+		case isForeignRRset(rrset, ownerID):
+		default:
+			stale = append(stale, rrset)
+		}
+	}
+	slices.SortFunc(stale, func(a, b powerdns.RRset) int {
+		return strings.Compare(*a.Name+"/"+string(*a.Type), *b.Name+"/"+string(*b.Type))
+	})
+	return stale
+}
+
+// isDelegationRecord returns True if the RRSet is the NS or DS record delegating a child zone, or one of its glue records
+func isDelegationRecord(name string, rrType powerdns.RRType, children []string) bool {
+	for _, child := range children {
+		if !isSubdomain(name, child) {
+			continue
+		}
+		if strings.EqualFold(name, makeCanonical(child)) && (rrType == powerdns.RRTypeNS || rrType == powerdns.RRTypeDS) {
+			return true
+		}
+		if slices.Contains(glueTypes, rrType) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowlisted returns True if the RRSet matches an entry of the allowlist
+func isAllowlisted(prune *dnsv1alpha2.Prune, name string, rrType powerdns.RRType) bool {
+	if prune == nil {
+		return false
+	}
+	fqdn := strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, entry := range prune.Allowlist {
+		if entry.Type != nil && !strings.EqualFold(*entry.Type, string(rrType)) {
+			continue
+		}
+		if policy.MatchPattern(entry.Name, fqdn) {
+			return true
+		}
+	}
+	return false
+}
+
+// isForeignRRset returns True if the RRSet is owned by another operator instance than the one of ownerID
+func isForeignRRset(rrset powerdns.RRset, ownerID string) bool {
+	account := rrsetAccount(rrset)
+	if account == "" {
+		return false
+	}
+	_, foreign := foreignOwner(account, ownerID, false)
+	return foreign
+}
+
+// rrsetKey returns the key identifying a RRSet by its name and type, case insensitive
+func rrsetKey(name, rrType string) string {
+	return strings.ToLower(makeCanonical(name)) + "/" + strings.ToUpper(rrType)
+}
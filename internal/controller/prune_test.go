@@ -0,0 +1,237 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcilePrune(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+
+	rrset := func(name string, rrType powerdns.RRType, content string) powerdns.RRset {
+		return powerdns.RRset{Name: ptr.To(name), Type: ptr.To(rrType), TTL: ptr.To(uint32(300)), Records: []powerdns.Record{{Content: ptr.To(content)}}}
+	}
+	newBackend := func() delegationBackend {
+		foreign := rrset("other.example.org.", powerdns.RRTypeA, "192.0.2.4")
+		foreign.Comments = []powerdns.Comment{{Content: ptr.To(""), Account: ptr.To(operatorAccount("other"))}}
+		return delegationBackend{
+			"example.org.": {
+				"example.org./SOA":              rrset("example.org.", powerdns.RRTypeSOA, "ns1.example.org. hostmaster.example.org. 2024010101 10800 3600 604800 3600"),
+				"example.org./NS":               rrset("example.org.", powerdns.RRTypeNS, "ns1.example.org."),
+				"www.example.org./A":            rrset("www.example.org.", powerdns.RRTypeA, "192.0.2.1"),
+				"stale.example.org./A":          rrset("stale.example.org.", powerdns.RRTypeA, "192.0.2.2"),
+				"legacy.example.org./TXT":       rrset("legacy.example.org.", powerdns.RRTypeTXT, "\"legacy\""),
+				"legacy.example.org./A":         rrset("legacy.example.org.", powerdns.RRTypeA, "192.0.2.3"),
+				"other.example.org./A":          foreign,
+				"team-a.example.org./NS":        rrset("team-a.example.org.", powerdns.RRTypeNS, "ns1.team-a.example.org."),
+				"ns1.team-a.example.org./A":     rrset("ns1.team-a.example.org.", powerdns.RRTypeA, "192.0.2.10"),
+				"app.team-a.example.org./CNAME": rrset("app.team-a.example.org.", powerdns.RRTypeCNAME, "www.example.org."),
+			},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "default"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"192.0.2.1", "2001:db8::1"}, ManagePTR: ptr.To(true), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		},
+		&dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a.example.org", Namespace: "default"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1.team-a.example.org"}},
+		},
+	).Build()
+	allowlist := []dnsv1alpha2.PruneAllowlistEntry{{Name: "legacy.example.org", Type: ptr.To("TXT")}}
+
+	var testCases = []struct {
+		description    string
+		recordsPolicy  *string
+		prune          *dnsv1alpha2.Prune
+		wantUnmanaged  []string
+		wantRemaining  int
+		wantDeleted    []string
+		wantNotDeleted []string
+	}{
+		{
+			"additive zone",
+			nil,
+			nil,
+			nil,
+			10,
+			nil,
+			[]string{"stale.example.org./A"},
+		},
+		{
+			"authoritative zone",
+			ptr.To(AUTHORITATIVE_RECORDS_POLICY),
+			&dnsv1alpha2.Prune{Allowlist: allowlist},
+			nil,
+			7,
+			[]string{"stale.example.org./A", "legacy.example.org./A", "app.team-a.example.org./CNAME"},
+			[]string{"legacy.example.org./TXT", "other.example.org./A", "team-a.example.org./NS", "ns1.team-a.example.org./A"},
+		},
+		{
+			"authoritative zone in report only mode",
+			ptr.To(AUTHORITATIVE_RECORDS_POLICY),
+			&dnsv1alpha2.Prune{ReportOnly: true, Allowlist: allowlist},
+			[]string{"app.team-a.example.org. CNAME", "legacy.example.org. A", "stale.example.org. A"},
+			10,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			backend := newBackend()
+			client := PdnsClienter{Records: backend, Zones: delegationZones{backend}, OwnerID: "mine"}
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "default"},
+				Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns1.example.org"}, RecordsPolicy: tc.recordsPolicy, Prune: tc.prune},
+			}
+			unmanaged, err := reconcilePrune(ctx, zone, cl, client, logr.Discard())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(unmanaged, tc.wantUnmanaged) {
+				t.Errorf("got unmanaged %v, want %v", unmanaged, tc.wantUnmanaged)
+			}
+			if got := len(backend["example.org."]); got != tc.wantRemaining {
+				t.Errorf("got %d remaining RRsets, want %d: %v", got, tc.wantRemaining, backend.summary("example.org"))
+			}
+			for _, key := range tc.wantDeleted {
+				if _, ok := backend["example.org."][key]; ok {
+					t.Errorf("%s should be deleted", key)
+				}
+			}
+			for _, key := range tc.wantNotDeleted {
+				if _, ok := backend["example.org."][key]; !ok {
+					t.Errorf("%s should not be deleted", key)
+				}
+			}
+		})
+	}
+}
+
+func TestIsAllowlisted(t *testing.T) {
+	prune := &dnsv1alpha2.Prune{Allowlist: []dnsv1alpha2.PruneAllowlistEntry{
+		{Name: "*.legacy.example.org"},
+		{Name: "_acme-challenge.example.org.", Type: ptr.To("txt")},
+	}}
+
+	var testCases = []struct {
+		name   string
+		rrType powerdns.RRType
+		want   bool
+	}{
+		{"www.legacy.example.org.", powerdns.RRTypeA, true},
+		{"a.b.Legacy.example.org.", powerdns.RRTypeCNAME, true},
+		{"legacy.example.org.", powerdns.RRTypeA, false},
+		{"_acme-challenge.example.org.", powerdns.RRTypeTXT, true},
+		{"_acme-challenge.example.org.", powerdns.RRTypeA, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+"/"+string(tc.rrType), func(t *testing.T) {
+			if got := isAllowlisted(prune, tc.name, tc.rrType); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+	if isAllowlisted(nil, "www.legacy.example.org.", powerdns.RRTypeA) {
+		t.Errorf("no RRset should be allowlisted without prune options")
+	}
+}
+
+func TestDeclaredRRsets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	forbidden := []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse, Reason: RrsetReasonForbidden}}
+	violation := []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse, Reason: RrsetReasonPolicyViolation}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "default"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"192.0.2.1", "198.51.100.1"}, ManagePTR: ptr.To(true), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-b"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "api", Records: []string{"192.0.2.2"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone", Namespace: ptr.To("default")}},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "team-b"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "team-b", Records: []string{"192.0.2.3"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-kind", Namespace: "default"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "cluster", Records: []string{"192.0.2.4"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "forbidden", Namespace: "default"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "forbidden", Records: []string{"192.0.2.5"}, ManagePTR: ptr.To(true), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			Status:     dnsv1alpha2.RRsetStatus{Conditions: forbidden},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "violation", Namespace: "default"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "TXT", Name: "violation", Records: []string{"\"v\""}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			Status:     dnsv1alpha2.RRsetStatus{Conditions: violation},
+		},
+		&dnsv1alpha2.ClusterRRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "ptr"},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "PTR", Name: "10", Records: []string{"mail.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "2.0.192.in-addr.arpa", Kind: "ClusterZone"}},
+		},
+	).Build()
+
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		want        map[string]bool
+	}{
+		{
+			"Zone",
+			&dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "default"}},
+			map[string]bool{"www.example.org./A": true, "api.example.org./A": true},
+		},
+		{
+			"ClusterZone with the same name",
+			&dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}},
+			map[string]bool{"cluster.example.org./A": true},
+		},
+		{
+			"reverse ClusterZone",
+			&dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Name: "2.0.192.in-addr.arpa"}},
+			map[string]bool{"1.2.0.192.in-addr.arpa./PTR": true, "10.2.0.192.in-addr.arpa./PTR": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			declared, err := declaredRRsets(context.Background(), tc.zone, cl)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(declared, tc.want) {
+				t.Errorf("got %v, want %v", declared, tc.want)
+			}
+		})
+	}
+}
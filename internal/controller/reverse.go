@@ -0,0 +1,255 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// reconcilePTRRecords creates or updates the PTR records of the addresses of the RRset,
+// deletes the ones it no longer generates and returns the status of the generated PTR records.
+// A PTR record already pointing to another name, managed by a RRset or not written by the operator instance,
+// is reported as a conflict and left untouched.
+func reconcilePTRRecords(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) ([]dnsv1alpha2.PTRRecordStatus, error) {
+	previous := gr.GetStatus().PTRRecords
+	var result []dnsv1alpha2.PTRRecordStatus
+
+	if ptr.Deref(gr.GetSpec().ManagePTR, false) {
+		target := getRRsetName(gr)
+		// The addresses withdrawn by the health check get no PTR record
+		for _, address := range publishedRecords(gr) {
+			name, ok := reverseName(address)
+			if !ok {
+				continue
+			}
+			zone, err := findClosestZone(ctx, name, cl)
+			if err != nil {
+				return nil, err
+			}
+			if zone == nil {
+				log.Info("No reverse zone found, skipping PTR record", "Address", address, "PTR.Name", name)
+				continue
+			}
+
+			status := dnsv1alpha2.PTRRecordStatus{Address: address, Name: name, Zone: zone.GetName()}
+			owned := slices.ContainsFunc(previous, func(p dnsv1alpha2.PTRRecordStatus) bool {
+				return p.Conflict == nil && p.Name == status.Name && p.Zone == status.Zone
+			})
+			conflict, err := ptrRecordConflict(ctx, gr, zone, name, target, owned, cl, PDNSClient)
+			if err != nil {
+				return nil, err
+			}
+			if conflict != "" {
+				status.Conflict = &conflict
+			} else if err := changeRRsetIfDifferent(ctx, PDNSClient, zone.GetName(), name, powerdns.RRTypePTR, ptr.To(gr.GetSpec().TTL), []string{target}, ptr.Deref(gr.GetSpec().Comment, "")); err != nil {
+				return nil, err
+			}
+			result = append(result, status)
+		}
+	}
+
+	// Delete the PTR records which are no longer generated,
+	// a PTR record now in conflict is left to whatever took it over
+	for _, p := range previous {
+		stillGenerated := slices.ContainsFunc(result, func(r dnsv1alpha2.PTRRecordStatus) bool {
+			return r.Name == p.Name && r.Zone == p.Zone
+		})
+		if p.Conflict != nil || stillGenerated {
+			continue
+		}
+		if err := deleteGeneratedRRset(ctx, PDNSClient, p.Zone, p.Name, powerdns.RRTypePTR); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// deletePTRRecords deletes the PTR records generated for the RRset
+func deletePTRRecords(ctx context.Context, gr dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter) error {
+	for _, p := range gr.GetStatus().PTRRecords {
+		if p.Conflict != nil {
+			continue
+		}
+		if err := deleteGeneratedRRset(ctx, PDNSClient, p.Zone, p.Name, powerdns.RRTypePTR); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ptrRecordConflict returns the reason why the RRset may not manage the PTR record, or an empty string if it may
+func ptrRecordConflict(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, name, target string, owned bool, cl client.Client, PDNSClient PdnsClienter) (string, error) {
+	// Nothing is written to an observed zone
+	if isObserved(zone) {
+		return fmt.Sprintf("reverse zone %s is observed", zone.GetName()), nil
+	}
+	// A RRset may only write PTR records in a zone it could reference
+	if rrset, ok := gr.(*dnsv1alpha2.RRset); ok {
+		ptrRRset := &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rrset.Namespace},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Type:    string(powerdns.RRTypePTR),
+				Name:    name,
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: zone.GetName(), Kind: zoneKind(zone)},
+			},
+		}
+		denial, err := zoneAccessDenial(ctx, cl, ptrRRset, zone)
+		if err != nil || denial != "" {
+			return denial, err
+		}
+	}
+
+	managed, err := isManagedByRRset(ctx, cl, zone.GetName(), name, powerdns.RRTypePTR)
+	if err != nil {
+		return "", err
+	}
+	if managed {
+		return fmt.Sprintf("PTR record %s is managed by a RRset", name), nil
+	}
+	existing, err := getExternalRRset(ctx, PDNSClient, zone.GetName(), name, powerdns.RRTypePTR)
+	if err != nil || existing == nil {
+		return "", err
+	}
+	if err := checkGeneratedRRsetOwnership(*existing, []string{target}, PDNSClient.OwnerID); err != nil {
+		return err.Error(), nil
+	}
+	if !owned && !slices.Equal(recordsContent(*existing), []string{target}) {
+		return fmt.Sprintf("PTR record %s already points to %s", name, strings.Join(recordsContent(*existing), ", ")), nil
+	}
+	return "", nil
+}
+
+// ptrConflictIndexer indexes a RRset/ClusterRRset by the names of its PTR records in conflict
+func ptrConflictIndexer(rawObj client.Object) []string {
+	var names []string
+	for _, p := range rawObj.(dnsv1alpha2.GenericRRset).GetStatus().PTRRecords {
+		if p.Conflict != nil {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// heldPTRNames returns the names of the PTR records held by a RRset/ClusterRRset:
+// the PTR records it generates, or its own name for a PTR RRset
+func heldPTRNames(gr dnsv1alpha2.GenericRRset) []string {
+	var names []string
+	if gr.GetSpec().Type == string(powerdns.RRTypePTR) {
+		names = append(names, getRRsetName(gr))
+	}
+	for _, p := range gr.GetStatus().PTRRecords {
+		if p.Conflict == nil {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// ptrReleasedPredicate passes the RRsets/ClusterRRsets which may have released PTR records: the deleted ones
+// and the ones whose PTR records changed
+var ptrReleasedPredicate = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldRRset, okOld := e.ObjectOld.(dnsv1alpha2.GenericRRset)
+		newRRset, okNew := e.ObjectNew.(dnsv1alpha2.GenericRRset)
+		return okOld && okNew && !slices.Equal(heldPTRNames(oldRRset), heldPTRNames(newRRset))
+	},
+}
+
+// findPTRConflicts enqueues the RRsets whose PTR records are in conflict with the PTR records held by a RRset/ClusterRRset,
+// they take the PTR records over once released
+func (r *RRsetReconciler) findPTRConflicts(ctx context.Context, obj client.Object) []reconcile.Request {
+	gr, ok := obj.(dnsv1alpha2.GenericRRset)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, name := range heldPTRNames(gr) {
+		var rrsets dnsv1alpha2.RRsetList
+		if err := r.List(ctx, &rrsets, client.MatchingFields{"RRset.PTRConflict": name}); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list RRsets")
+			return nil
+		}
+		for _, rr := range rrsets.Items {
+			if rr.UID != obj.GetUID() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+			}
+		}
+	}
+	return requests
+}
+
+// findPTRConflicts enqueues the ClusterRRsets whose PTR records are in conflict with the PTR records held by a RRset/ClusterRRset,
+// they take the PTR records over once released
+func (r *ClusterRRsetReconciler) findPTRConflicts(ctx context.Context, obj client.Object) []reconcile.Request {
+	gr, ok := obj.(dnsv1alpha2.GenericRRset)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, name := range heldPTRNames(gr) {
+		var rrsets dnsv1alpha2.ClusterRRsetList
+		if err := r.List(ctx, &rrsets, client.MatchingFields{"ClusterRRset.PTRConflict": name}); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list ClusterRRsets")
+			return nil
+		}
+		for _, rr := range rrsets.Items {
+			if rr.UID != obj.GetUID() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+			}
+		}
+	}
+	return requests
+}
+
+// reverseName returns the canonical name of the PTR record of the address, false if it is not an IP address
+func reverseName(address string) (string, bool) {
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return "", false
+	}
+	if ip.Is4() {
+		b := ip.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", b[3], b[2], b[1], b[0]), true
+	}
+	b := ip.As16()
+	nibbles := make([]string, 0, 2*len(b))
+	for i := len(b) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, fmt.Sprintf("%x", b[i]&0x0f), fmt.Sprintf("%x", b[i]>>4))
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa.", true
+}
+
+func zoneKind(zone dnsv1alpha2.GenericZone) string {
+	if _, ok := zone.(*dnsv1alpha2.ClusterZone); ok {
+		return "ClusterZone"
+	}
+	return "Zone"
+}
@@ -0,0 +1,84 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReverseName(t *testing.T) {
+	var testCases = []struct {
+		address string
+		want    string
+		ok      bool
+	}{
+		{"192.0.2.10", "10.2.0.192.in-addr.arpa.", true},
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", true},
+		{"www.example.org.", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			got, ok := reverseName(tc.address)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("got %q, %v, want %q, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestFindPTRConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	rrset := func(name, namespace string, ptrRecords ...dnsv1alpha2.PTRRecordStatus) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name)},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: name, Records: []string{"192.0.2.10"}, ManagePTR: ptr.To(true), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org"}},
+			Status:     dnsv1alpha2.RRsetStatus{PTRRecords: ptrRecords},
+		}
+	}
+	owner := rrset("front", "team-a", dnsv1alpha2.PTRRecordStatus{Address: "192.0.2.10", Name: "10.2.0.192.in-addr.arpa."})
+	loser := rrset("back", "team-b", dnsv1alpha2.PTRRecordStatus{Address: "192.0.2.10", Name: "10.2.0.192.in-addr.arpa.", Conflict: ptr.To("held by team-a/front")})
+	other := rrset("other", "team-c", dnsv1alpha2.PTRRecordStatus{Address: "192.0.2.11", Name: "11.2.0.192.in-addr.arpa.", Conflict: ptr.To("held by team-d/other")})
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner, loser, other).
+		WithIndex(&dnsv1alpha2.RRset{}, "RRset.PTRConflict", ptrConflictIndexer).
+		Build()
+	r := &RRsetReconciler{Client: cl}
+
+	// Deleting the RRset holding the PTR record enqueues the RRset in conflict only
+	got := r.findPTRConflicts(context.Background(), owner)
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "back"}}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got requests %v, want %v", got, want)
+	}
+
+	// Releasing the PTR record passes the predicate, a status unchanged does not
+	released := owner.DeepCopy()
+	released.Status.PTRRecords = nil
+	if !ptrReleasedPredicate.Update(event.UpdateEvent{ObjectOld: owner, ObjectNew: released}) {
+		t.Error("got the release of the PTR record filtered out")
+	}
+	if ptrReleasedPredicate.Update(event.UpdateEvent{ObjectOld: owner, ObjectNew: owner.DeepCopy()}) {
+		t.Error("got an unchanged RRset passed")
+	}
+}
@@ -13,8 +13,10 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -23,12 +25,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
 )
 
 const (
@@ -36,10 +45,19 @@ const (
 	RrsetReasonSynchronizationFailed = "SynchronizationFailed"
 	RrsetReasonDuplicated            = "RrsetDuplicated"
 	RrsetReasonSynced                = "RrsetSynced"
-	RrsetMessageDuplicated           = "Already existing RRset with the same FQDN"
+	RrsetMessageDuplicated           = "Already existing RRset with the same FQDN, synchronized by "
 	RrsetMessageSyncSucceeded        = "RRset synced with PowerDNS instance"
 	RrsetMessageNonExistentZone      = "non-existent zone:"
 	RrsetMessageUnavailableZone      = "unavailable zone:"
+	RrsetReasonPolicyViolation       = "PolicyViolation"
+	RrsetReasonForbidden             = "Forbidden"
+	RrsetReasonPTRSyncFailed         = "PTRSynchronizationFailed"
+	RrsetReasonForeignRRset          = "ForeignRRset"
+	RrsetReasonForeignOwner          = "ForeignOwner"
+	RrsetReasonObserved              = "RrsetObserved"
+	RrsetMessageObserved             = "RRset observed from PowerDNS instance"
+	RrsetReasonNotFound              = "RrsetNotFound"
+	RrsetMessageNotFound             = "Observed RRset not found in PowerDNS instance"
 )
 
 // RRsetReconciler reconciles a RRset object
@@ -51,12 +69,15 @@ type RRsetReconciler struct {
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(rrsetsStatusesMetric)
+	metrics.Registry.MustRegister(rrsetsStatusesMetric, rrsetsTargetsHealthMetric)
 }
 
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonereferencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -113,7 +134,7 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	case "ClusterZone":
 		zone = &dnsv1alpha2.ClusterZone{}
 	}
-	err = r.Get(ctx, client.ObjectKey{Namespace: rrset.Namespace, Name: rrset.Spec.ZoneRef.Name}, zone)
+	err = r.Get(ctx, client.ObjectKey{Namespace: zoneRefNamespace(rrset), Name: rrset.Spec.ZoneRef.Name}, zone)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Zone not found, remove finalizer and requeue
@@ -163,6 +184,21 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			return ctrl.Result{}, err
 		}
 	}
+	// A Zone from another namespace must be granted, and a ClusterZone may restrict the namespaces allowed to reference it
+	// Forbidden RRsets never reach PowerDNS, only the records they synchronized before being forbidden are deleted along with them
+	denial, err := zoneAccessDenial(ctx, r.Client, rrset, zone)
+	if err != nil {
+		log.Error(err, "Failed to check access to the zone")
+		return ctrl.Result{}, err
+	}
+	if denial != "" {
+		return r.forbid(ctx, rrset, zone, isDeleted, denial)
+	}
+	// A RRset previously forbidden must be synchronized again
+	if condition := meta.FindStatusCondition(rrset.Status.Conditions, "Available"); condition != nil && condition.Reason == RrsetReasonForbidden {
+		isModified = true
+	}
+
 	// If a Zone/ClusterZone exists but is in Failed Status
 	zoneIsInFailedStatus := (zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS)
 	if zoneIsInFailedStatus {
@@ -199,12 +235,116 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
+	// ZonePolicies are checked again: the webhook may be disabled,
+	// or policies may have changed since the RRset was admitted
+	if !isDeleted {
+		policies, err := policy.ForNamespace(ctx, r.Client, rrset.Namespace)
+		if err != nil {
+			log.Error(err, "Failed to get ZonePolicies")
+			return ctrl.Result{}, err
+		}
+		if err := policy.CheckRRset(policies, rrset); err != nil {
+			original = rrset.DeepCopy()
+			rrset.Status.SyncStatus = ptr.To(FAILED_STATUS)
+			rrset.Status.ObservedGeneration = &rrset.Generation
+			meta.SetStatusCondition(&rrset.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+				Reason:             RrsetReasonPolicyViolation,
+				Message:            err.Error(),
+			})
+			if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+				log.Error(err, "unable to patch RRSet status")
+				return ctrl.Result{}, err
+			}
+			updateRrsetsMetrics(getRRsetName(rrset), rrset)
+			return ctrl.Result{}, nil
+		}
+		// A RRset previously denied by a policy must be synchronized again
+		if condition := meta.FindStatusCondition(rrset.Status.Conditions, "Available"); condition != nil && condition.Reason == RrsetReasonPolicyViolation {
+			isModified = true
+		}
+	}
+
 	return rrsetReconcile(ctx, rrset, zone, isModified, isDeleted, lastUpdateTime, r.Scheme, r.Client, r.PDNSClient, log)
 }
 
+// zoneAccessDenial returns the reason why the RRset may not reference the zone, or an empty string if it may
+func zoneAccessDenial(ctx context.Context, cl client.Reader, rrset *dnsv1alpha2.RRset, zone dnsv1alpha2.GenericZone) (string, error) {
+	switch z := zone.(type) {
+	case *dnsv1alpha2.Zone:
+		if z.Namespace == rrset.Namespace {
+			return "", nil
+		}
+		var grants dnsv1alpha2.ZoneReferenceGrantList
+		if err := cl.List(ctx, &grants, client.InNamespace(z.Namespace)); err != nil {
+			return "", err
+		}
+		if !policy.ReferenceGranted(grants.Items, rrset.Namespace, z.Name) {
+			return fmt.Sprintf("no ZoneReferenceGrant in namespace %s allows namespace %s to reference Zone %s", z.Namespace, rrset.Namespace, z.Name), nil
+		}
+	case *dnsv1alpha2.ClusterZone:
+		if z.Spec.AllowedNamespaces == nil {
+			return "", nil
+		}
+		namespace := &corev1.Namespace{}
+		if err := cl.Get(ctx, client.ObjectKey{Name: rrset.Namespace}, namespace); err != nil {
+			return "", err
+		}
+		if err := policy.CheckClusterZoneAccess(z, namespace, rrset); err != nil {
+			return err.Error(), nil
+		}
+	}
+	return "", nil
+}
+
+// forbid sets the RRset in Failed status with a Forbidden reason, or releases it if it is under deletion,
+// along with the records it synchronized while it was allowed
+func (r *RRsetReconciler) forbid(ctx context.Context, rrset *dnsv1alpha2.RRset, zone dnsv1alpha2.GenericZone, isDeleted bool, reason string) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	if isDeleted {
+		// The resources finalizer is only set once the RRset is allowed: a RRset forbidden after its synchronization
+		// (e.g. its grant has been removed) still owns its records, they must not be orphaned
+		if controllerutil.ContainsFinalizer(rrset, RESOURCES_FINALIZER_NAME) {
+			if err := deleteRrsetPublishedResources(ctx, rrset, zone, r.Client, r.PDNSClient, log); err != nil {
+				return ctrl.Result{}, err
+			}
+			forgetTargetsHealth(rrset)
+		}
+		controllerutil.RemoveFinalizer(rrset, RESOURCES_FINALIZER_NAME)
+		if controllerutil.RemoveFinalizer(rrset, METRICS_FINALIZER_NAME) {
+			removeRrsetMetrics(rrset)
+		}
+		if err := r.Update(ctx, rrset); err != nil {
+			log.Error(err, "Failed to remove finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	original := rrset.DeepCopy()
+	rrset.Status.SyncStatus = ptr.To(FAILED_STATUS)
+	rrset.Status.ObservedGeneration = &rrset.Generation
+	meta.SetStatusCondition(&rrset.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             RrsetReasonForbidden,
+		Message:            reason,
+	})
+	if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return ctrl.Result{}, err
+	}
+	updateRrsetsMetrics(getRRsetName(rrset), rrset)
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one RRset exists for DNS entry
+	// Synchronized RRsets are indexed by DNS entry, to find the RRsets managing a record
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.Entry.Name", func(rawObj client.Object) []string {
 		// grab the RRset object, extract its name...
 		var RRsetName string
@@ -215,7 +355,121 @@ func (r *RRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	// All the RRsets are indexed by DNS entry, to elect the one synchronized among duplicates
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.Entry.Claim", rrsetClaimIndexer); err != nil {
+		return err
+	}
+	// RRsets are indexed by their PTR records in conflict, to check them again when the PTR records are released
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.PTRConflict", ptrConflictIndexer); err != nil {
+		return err
+	}
+	// RRsets are indexed by the ClusterZone they reference, to check them again when its allowed namespaces change
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.ClusterZoneRef", func(rawObj client.Object) []string {
+		rrset := rawObj.(*dnsv1alpha2.RRset)
+		if rrset.Spec.ZoneRef.Kind != "ClusterZone" {
+			return nil
+		}
+		return []string{rrset.Spec.ZoneRef.Name}
+	}); err != nil {
+		return err
+	}
+	// RRsets referencing a Zone from another namespace are indexed by the namespace of the Zone,
+	// to check them again when the grants of this namespace change
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.CrossNamespaceZoneRef", func(rawObj client.Object) []string {
+		rrset := rawObj.(*dnsv1alpha2.RRset)
+		if namespace := zoneRefNamespace(rrset); namespace != rrset.Namespace {
+			return []string{namespace}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Only the changes of spec (and of synchronization status for zones) may change the outcome of the RRsets reconciliation,
+	// the other status updates (e.g. serial) are filtered out to avoid listing RRsets on each of them
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.RRset{}).
+		Watches(&dnsv1alpha2.ZonePolicy{}, handler.EnqueueRequestsFromMapFunc(r.findRRsetsForPolicy), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findRRsetsForClusterZone), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findCrossNamespaceRRsets), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ZoneReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.findCrossNamespaceRRsets), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedRRsets)).
+		Watches(&dnsv1alpha2.ClusterRRset{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedRRsets)).
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestsFromMapFunc(r.findPTRConflicts), builder.WithPredicates(ptrReleasedPredicate)).
+		Watches(&dnsv1alpha2.ClusterRRset{}, handler.EnqueueRequestsFromMapFunc(r.findPTRConflicts), builder.WithPredicates(ptrReleasedPredicate)).
+		// The health of the addresses is checked in the background, the RRset is reconciled once it is
+		WatchesRawSource(source.Channel(rrsetsChecked, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
+
+// findCrossNamespaceRRsets enqueues the RRsets referencing a Zone of the object namespace from another namespace,
+// as they are not owned by the Zone
+func (r *RRsetReconciler) findCrossNamespaceRRsets(ctx context.Context, obj client.Object) []reconcile.Request {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := r.List(ctx, &rrsets, client.MatchingFields{"RRset.CrossNamespaceZoneRef": obj.GetNamespace()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list RRsets")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, rr := range rrsets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+	}
+	return requests
+}
+
+// zoneChangedPredicate filters out the updates of a Zone/ClusterZone changing neither its spec nor its synchronization status
+var zoneChangedPredicate = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldZone, okOld := e.ObjectOld.(dnsv1alpha2.GenericZone)
+		newZone, okNew := e.ObjectNew.(dnsv1alpha2.GenericZone)
+		return okOld && okNew && ptr.Deref(oldZone.GetStatus().SyncStatus, "") != ptr.Deref(newZone.GetStatus().SyncStatus, "")
+	},
+})
+
+// zoneRefNamespace returns the namespace of the Zone referenced by the RRset
+func zoneRefNamespace(rrset *dnsv1alpha2.RRset) string {
+	if rrset.Spec.ZoneRef.Kind == "Zone" && ptr.Deref(rrset.Spec.ZoneRef.Namespace, "") != "" {
+		return *rrset.Spec.ZoneRef.Namespace
+	}
+	return rrset.Namespace
+}
+
+// findRRsetsForClusterZone enqueues the RRsets referencing a ClusterZone, so that a change of
+// its allowed namespaces also applies to RRsets it does not own (e.g. forbidden ones)
+func (r *RRsetReconciler) findRRsetsForClusterZone(ctx context.Context, obj client.Object) []reconcile.Request {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := r.List(ctx, &rrsets, client.MatchingFields{"RRset.ClusterZoneRef": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list RRsets")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, rr := range rrsets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+	}
+	return requests
+}
+
+// findRRsetsForPolicy enqueues the RRsets of the namespaces selected by a modified ZonePolicy,
+// the map function is called with both the old and the new policy on update
+func (r *RRsetReconciler) findRRsetsForPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	zonePolicy, ok := obj.(*dnsv1alpha2.ZonePolicy)
+	if !ok {
+		return nil
+	}
+	namespaces, err := policy.SelectedNamespaces(ctx, r.Client, zonePolicy)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list the namespaces of the ZonePolicy")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, namespace := range namespaces {
+		var rrsets dnsv1alpha2.RRsetList
+		if err := r.List(ctx, &rrsets, client.InNamespace(namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list RRsets")
+			return nil
+		}
+		for _, rr := range rrsets.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rr)})
+		}
+	}
+	return requests
+}
@@ -14,13 +14,18 @@ package controller
 
 import (
 	"context"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/joeig/go-powerdns/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -364,7 +369,7 @@ var _ = Describe("RRset Controller", func() {
 			recreationZoneRef := zoneName
 			recreationRecord := "127.0.0.3"
 
-			By("Creating a RRset directly in the mock")
+			By("Creating a RRset directly in the mock, as previously written by the operator")
 			writeToRecordsMap(makeCanonical(recreationResourceName), &powerdns.RRset{
 				Type: powerdns.RRTypePtr(powerdns.RRType(recreationResourceType)),
 				Name: &recreationResourceName,
@@ -373,7 +378,7 @@ var _ = Describe("RRset Controller", func() {
 					{Content: &recreationRecord},
 				},
 				Comments: []powerdns.Comment{
-					{Content: &recreationResourceComment},
+					{Content: &recreationResourceComment, Account: ptr.To(OPERATOR_ACCOUNT)},
 				},
 			})
 
@@ -432,6 +437,97 @@ var _ = Describe("RRset Controller", func() {
 		})
 	})
 
+	Context("When creating RRset over a RRset not created by the operator", func() {
+		It("should refuse it unless taken over", Label("rrset-foreign"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			foreignResourceName := "foreign.example2.org"
+			foreignResourceDNSName := "foreign"
+			foreignResourceType := "A"
+			foreignRecord := "192.0.2.1"
+			foreignTTL := uint32(600)
+			foreignComment := "created by hand"
+			rrsetRecord := "192.0.2.2"
+			rrsetLookupKey := types.NamespacedName{
+				Name:      foreignResourceName,
+				Namespace: resourceNamespace,
+			}
+			newRRset := func(takeOver bool) *dnsv1alpha2.RRset {
+				return &dnsv1alpha2.RRset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      foreignResourceName,
+						Namespace: resourceNamespace,
+					},
+					Spec: dnsv1alpha2.RRsetSpec{
+						ZoneRef: dnsv1alpha2.ZoneRef{
+							Name: zoneRef,
+							Kind: resourceZoneKind,
+						},
+						Type:     foreignResourceType,
+						Name:     foreignResourceDNSName,
+						TTL:      resourceTTL,
+						Records:  []string{rrsetRecord},
+						TakeOver: ptr.To(takeOver),
+					},
+				}
+			}
+			deleteRRset := func() {
+				resource := &dnsv1alpha2.RRset{}
+				Expect(k8sClient.Get(ctx, rrsetLookupKey, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, rrsetLookupKey, resource)
+					return errors.IsNotFound(err)
+				}, timeout, interval).Should(BeTrue())
+			}
+
+			By("Creating a RRset directly in the mock, as created by another tool")
+			writeToRecordsMap(makeCanonical(foreignResourceName), &powerdns.RRset{
+				Type: powerdns.RRTypePtr(powerdns.RRType(foreignResourceType)),
+				Name: ptr.To(makeCanonical(foreignResourceName)),
+				TTL:  &foreignTTL,
+				Records: []powerdns.Record{
+					{Content: &foreignRecord},
+				},
+				Comments: []powerdns.Comment{
+					{Content: &foreignComment},
+				},
+			})
+
+			By("Creating the RRset resource without takeOver")
+			Expect(k8sClient.Create(ctx, newRRset(false))).To(Succeed())
+			createdResource := &dnsv1alpha2.RRset{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetLookupKey, createdResource)
+				return err == nil && createdResource.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(createdResource.Status.Conditions, "Available").Reason).To(Equal(RrsetReasonForeignRRset))
+			Expect(getMockedRecordsForType(foreignResourceName, foreignResourceType)).To(Equal([]string{foreignRecord}), "The foreign RRset should not be modified")
+			Expect(getMockedComment(foreignResourceName, foreignResourceType)).To(Equal(foreignComment))
+
+			By("Deleting the refused RRset resource")
+			deleteRRset()
+			Expect(getMockedRecordsForType(foreignResourceName, foreignResourceType)).To(Equal([]string{foreignRecord}), "The foreign RRset should not be deleted")
+
+			By("Creating the RRset resource with takeOver")
+			Expect(k8sClient.Create(ctx, newRRset(true))).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetLookupKey, createdResource)
+				return err == nil && createdResource.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType(foreignResourceName, foreignResourceType)).To(Equal([]string{rrsetRecord}))
+			rrset, _ := readFromRecordsMap(makeCanonical(foreignResourceName))
+			Expect(isOwnedRRset(*rrset, "")).To(BeTrue(), "The RRset should be marked as owned by the operator")
+
+			By("Deleting the RRset resource taken over")
+			deleteRRset()
+			Eventually(func() bool {
+				_, found := readFromRecordsMap(makeCanonical(foreignResourceName))
+				return found
+			}, timeout, interval).Should(BeFalse(), "The RRset taken over should be deleted")
+		})
+	})
+
 	Context("When existing resource", func() {
 		It("should successfully modify a deleted rrset", Label("rrset-modification-after-deletion"), func() {
 			ic := countRrsetsMetrics()
@@ -614,7 +710,7 @@ var _ = Describe("RRset Controller", func() {
 
 			Expect(countRrsetsMetrics()-ic).To(Equal(1), "One more metric should have been created")
 			Expect(getRrsetMetricWithLabels(additionalResourceName+"."+zoneName+".", additionalResourceType, SUCCEEDED_STATUS, additionalResourceName, resourceNamespace)).To(Equal(1.0), "metric should be 1.0")
-			Expect(getMockedRecordsForType(DnsFqdn, additionalResourceType)).To(Equal(additionalResourceRecords))
+			Expect(getMockedRecordsForType(DnsFqdn, additionalResourceType)).To(Equal([]string{"2001:dc8:86a4::7a2f:2360:2341"}), "AAAA records should be stored compressed")
 			Expect(getMockedTTL(DnsFqdn, additionalResourceType)).To(Equal(resourceTTL))
 			Expect(getMockedComment(DnsFqdn, additionalResourceType)).To(Equal(additionalResourceComment))
 			Expect(createdResource.GetOwnerReferences()).NotTo(BeEmpty(), "RRset should have setOwnerReference")
@@ -682,7 +778,7 @@ var _ = Describe("RRset Controller", func() {
 
 			Expect(countRrsetsMetrics()-ic).To(Equal(1), "One more metric should have been created")
 			Expect(getRrsetMetricWithLabels(additionalResourceName+"."+zoneName+".", additionalResourceType, SUCCEEDED_STATUS, additionalResourceName, resourceNamespace)).To(Equal(1.0), "metric should be 1.0")
-			Expect(getMockedRecordsForType(DnsFqdn, additionalResourceType)).To(Equal(additionalResourceRecords))
+			Expect(getMockedRecordsForType(DnsFqdn, additionalResourceType)).To(Equal([]string{makeCanonical(resourceName)}), "CNAME records should be stored canonical")
 			Expect(getMockedTTL(DnsFqdn, additionalResourceType)).To(Equal(resourceTTL))
 			Expect(getMockedComment(DnsFqdn, additionalResourceType)).To(Equal(additionalResourceComment))
 			Expect(createdResource.GetOwnerReferences()).NotTo(BeEmpty(), "RRset should have setOwnerReference")
@@ -1125,6 +1221,76 @@ var _ = Describe("RRset Controller", func() {
 		})
 	})
 
+	Context("When creating RRset with records not in canonical form", func() {
+		It("should not modify the records on each reconciliation", Label("rrset-creation", "record-normalization"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			additionalResourceName := "normalization"
+			additionalResourceType := "TXT"
+			longString := strings.Repeat("a", 300)
+			additionalResourceRecords := []string{`"` + longString + `"`}
+			expectedRecords := []string{`"` + longString[:255] + `" "` + longString[255:] + `"`}
+
+			By("Creating the RRset resource")
+			additionalResource := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      additionalResourceName,
+					Namespace: resourceNamespace,
+				},
+			}
+			additionalResource.SetResourceVersion("")
+			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, additionalResource, func() error {
+				additionalResource.Spec = dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: zoneRef,
+						Kind: resourceZoneKind,
+					},
+					Type:    additionalResourceType,
+					Name:    additionalResourceName,
+					TTL:     resourceTTL,
+					Records: additionalResourceRecords,
+				}
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			additionalRRsetLookupKey := types.NamespacedName{
+				Name:      additionalResourceName,
+				Namespace: resourceNamespace,
+			}
+
+			By("Getting the created resource")
+			createdResource := &dnsv1alpha2.RRset{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, additionalRRsetLookupKey, createdResource)
+				return err == nil && createdResource.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			DnsFqdn := getRRsetName(createdResource)
+			Expect(getMockedRecordsForType(DnsFqdn, additionalResourceType)).To(Equal(expectedRecords), "TXT records should be stored split in 255 bytes strings")
+
+			By("Triggering a new reconciliation")
+			zone, _ := readFromZonesMap(makeCanonical(zoneName))
+			serial := *zone.Serial
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, additionalRRsetLookupKey, createdResource); err != nil {
+					return err
+				}
+				createdResource.SetAnnotations(map[string]string{"test/reconcile": "again"})
+				return k8sClient.Update(ctx, createdResource)
+			}, timeout, interval).Should(Succeed())
+			Consistently(func() uint32 {
+				zone, _ := readFromZonesMap(makeCanonical(zoneName))
+				return *zone.Serial
+			}, 2*time.Second, interval).Should(Equal(serial), "The serial of the zone should not be increased")
+
+			By("Cleaning up the RRset resource")
+			Expect(k8sClient.Delete(ctx, createdResource)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, additionalRRsetLookupKey, createdResource)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
 	Context("When creating a wrong RRset", func() {
 		It("should reconcile the resource with Failed status", Label("wrong-rrset", "wrong-type"), func() {
 			ic := countRrsetsMetrics()
@@ -1326,6 +1492,7 @@ var _ = Describe("RRset Controller", func() {
 			existingResourceRecords := []string{"1.2.3.4", "5.6.7.8"}
 			existingResourceComment := "This a duplicate RRset"
 			existingResourceTTL := uint32(300)
+			waitUntilOlderThanNow(ctx, types.NamespacedName{Name: resourceName, Namespace: resourceNamespace}, &dnsv1alpha2.RRset{})
 
 			By("Creating the RRset resource")
 			existingResource := &dnsv1alpha2.RRset{
@@ -1372,6 +1539,18 @@ var _ = Describe("RRset Controller", func() {
 			Expect(*createdResource.Status.SyncStatus).To(Equal(FAILED_STATUS), "RRset status should be 'Failed'")
 			Expect(createdResource.GetFinalizers()).To(ContainElement(RESOURCES_FINALIZER_NAME), "RRset should contain the finalizer")
 			Expect(createdResource.GetFinalizers()).To(ContainElement(METRICS_FINALIZER_NAME), "RRset should contain the metrics finalizer")
+			condition := meta.FindStatusCondition(createdResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(RrsetReasonDuplicated))
+			Expect(condition.Message).To(Equal(RrsetMessageDuplicated+"RRset "+resourceNamespace+"/"+resourceName), "the message should name the synchronized RRset")
+
+			By("Deleting the duplicated RRset")
+			Expect(k8sClient.Delete(ctx, createdResource)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, existingRRsetLookupKey, createdResource)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType(existingResourceDNSName+"."+zoneName, existingResourceType)).NotTo(BeEmpty(), "the RRset synchronized by the existing RRset should not be deleted")
 		})
 	})
 
@@ -1461,7 +1640,7 @@ var _ = Describe("RRset Controller", func() {
 			}
 			recreationZone.SetResourceVersion("")
 			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, recreationZone, func() error {
-				recreationZone.Spec = dnsv1alpha2.ZoneSpec{
+				recreationZone.Spec.ZoneSpec = dnsv1alpha2.ZoneSpec{
 					Kind:        recreationZoneKind,
 					Nameservers: recreationZoneNameservers,
 				}
@@ -1518,6 +1697,7 @@ var _ = Describe("RRset Controller", func() {
 				_, ok := readFromRecordsMap(makeCanonical(DnsFqdn))
 				return ok
 			}, timeout, interval).Should(BeTrue())
+			waitUntilOlderThanNow(ctx, recreationRRsetLookupKey, recreationResource)
 
 			By("Creating a ClusterRRset")
 			resource := &dnsv1alpha2.ClusterRRset{
@@ -1552,6 +1732,495 @@ var _ = Describe("RRset Controller", func() {
 				err := k8sClient.Get(ctx, typeNamespacedName, recreatedZone)
 				return err == nil && recreatedZone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
 			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(recreatedZone.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal(RrsetMessageDuplicated+"RRset "+recreationResourceNamespace+"/"+recreationResourceName), "the message should name the synchronized RRset")
+
+			By("Deleting the duplicated ClusterRRset")
+			Expect(k8sClient.Delete(ctx, recreatedZone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, recreatedZone)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromRecordsMap(makeCanonical(DnsFqdn))
+			Expect(found).To(BeTrue(), "the RRset synchronized by the existing RRset should not be deleted")
+		})
+	})
+
+	Context("When creating a RRset in a ClusterZone not allowing its namespace", func() {
+		It("should reconcile the resource with Failed status until the namespace is allowed", Label("rrset-creation", "allowed-namespaces"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			restrictedZoneName := "example8.org"
+			restrictedZoneKind := NATIVE_KIND_ZONE
+			restrictedZoneNameservers := []string{"ns1.example8.org", "ns2.example8.org"}
+
+			forbiddenResourceName := "forbidden.example8.org"
+			forbiddenResourceNamespace := "example6"
+			forbiddenResourceDNSName := "forbidden"
+
+			By("Creating a ClusterZone allowing another namespace")
+			restrictedZone := &dnsv1alpha2.ClusterZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: restrictedZoneName,
+				},
+				Spec: dnsv1alpha2.ClusterZoneSpec{
+					ZoneSpec: dnsv1alpha2.ZoneSpec{
+						Kind:        restrictedZoneKind,
+						Nameservers: restrictedZoneNameservers,
+					},
+					AllowedNamespaces: &dnsv1alpha2.AllowedNamespaces{
+						Names: []string{"example7"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, restrictedZone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, restrictedZone)).To(Succeed())
+			})
+			Eventually(func() bool {
+				_, found := readFromZonesMap(makeCanonical(restrictedZoneName))
+				return found
+			}, timeout, interval).Should(BeTrue())
+
+			By("Creating a RRset from a forbidden namespace")
+			forbiddenResource := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      forbiddenResourceName,
+					Namespace: forbiddenResourceNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: restrictedZoneName,
+						Kind: "ClusterZone",
+					},
+					Type:    "A",
+					Name:    forbiddenResourceDNSName,
+					TTL:     uint32(300),
+					Records: []string{"1.2.3.4"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, forbiddenResource)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, forbiddenResource))).To(Succeed())
+			})
+			forbiddenLookupKey := types.NamespacedName{
+				Name:      forbiddenResourceName,
+				Namespace: forbiddenResourceNamespace,
+			}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, forbiddenLookupKey, forbiddenResource)
+				return err == nil && forbiddenResource.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(forbiddenResource.Status.Conditions, "Available").Reason).To(Equal(RrsetReasonForbidden))
+			_, found := readFromRecordsMap(makeCanonical(forbiddenResourceName))
+			Expect(found).To(BeFalse(), "RRset should not be created in the backend")
+
+			By("Allowing the namespace in the ClusterZone")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: restrictedZoneName}, restrictedZone)).To(Succeed())
+			restrictedZone.Spec.AllowedNamespaces.Names = append(restrictedZone.Spec.AllowedNamespaces.Names, forbiddenResourceNamespace)
+			Expect(k8sClient.Update(ctx, restrictedZone)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, forbiddenLookupKey, forbiddenResource)
+				return err == nil && forbiddenResource.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			_, found = readFromRecordsMap(makeCanonical(forbiddenResourceName))
+			Expect(found).To(BeTrue(), "RRset should be created in the backend")
+
+			By("Forbidding the namespace again")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: restrictedZoneName}, restrictedZone)).To(Succeed())
+			restrictedZone.Spec.AllowedNamespaces.Names = []string{"example7"}
+			Expect(k8sClient.Update(ctx, restrictedZone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, forbiddenLookupKey, forbiddenResource)
+				return err == nil && forbiddenResource.Status.SyncStatus != nil && *forbiddenResource.Status.SyncStatus == FAILED_STATUS
+			}, timeout, interval).Should(BeTrue())
+			_, found = readFromRecordsMap(makeCanonical(forbiddenResourceName))
+			Expect(found).To(BeTrue(), "RRset should be left in the backend while forbidden")
+
+			By("Deleting the forbidden RRset")
+			Expect(k8sClient.Delete(ctx, forbiddenResource)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, forbiddenLookupKey, forbiddenResource)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found = readFromRecordsMap(makeCanonical(forbiddenResourceName))
+			Expect(found).To(BeFalse(), "RRset synchronized before being forbidden should be deleted from the backend")
+		})
+	})
+
+	Context("When creating a RRset referencing a Zone from another namespace", func() {
+		It("should reconcile the resource with Failed status until a ZoneReferenceGrant allows it", Label("rrset-creation", "zone-reference-grant"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			grantZoneName := "example9.org"
+			grantZoneNamespace := "example4"
+			grantZoneKind := NATIVE_KIND_ZONE
+			grantZoneNameservers := []string{"ns1.example9.org", "ns2.example9.org"}
+
+			crossResourceName := "cross.example9.org"
+			crossResourceNamespace := "example5"
+			crossResourceDNSName := "cross"
+
+			By("Creating a Zone")
+			grantZone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      grantZoneName,
+					Namespace: grantZoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        grantZoneKind,
+					Nameservers: grantZoneNameservers,
+				},
+			}
+			Expect(k8sClient.Create(ctx, grantZone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, grantZone)).To(Succeed())
+			})
+			Eventually(func() bool {
+				_, found := readFromZonesMap(makeCanonical(grantZoneName))
+				return found
+			}, timeout, interval).Should(BeTrue())
+
+			By("Creating a RRset referencing the Zone from another namespace")
+			crossResource := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      crossResourceName,
+					Namespace: crossResourceNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name:      grantZoneName,
+						Kind:      "Zone",
+						Namespace: ptr.To(grantZoneNamespace),
+					},
+					Type:    "A",
+					Name:    crossResourceDNSName,
+					TTL:     uint32(300),
+					Records: []string{"1.2.3.4"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, crossResource)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, crossResource)).To(Succeed())
+			})
+			crossLookupKey := types.NamespacedName{
+				Name:      crossResourceName,
+				Namespace: crossResourceNamespace,
+			}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, crossLookupKey, crossResource)
+				return err == nil && crossResource.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(crossResource.Status.Conditions, "Available").Reason).To(Equal(RrsetReasonForbidden))
+			_, found := readFromRecordsMap(makeCanonical(crossResourceName))
+			Expect(found).To(BeFalse(), "RRset should not be created in the backend")
+
+			By("Creating a ZoneReferenceGrant in the Zone namespace")
+			grant := &dnsv1alpha2.ZoneReferenceGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example5",
+					Namespace: grantZoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneReferenceGrantSpec{
+					From: []dnsv1alpha2.ZoneReferenceGrantFrom{{Namespace: crossResourceNamespace}},
+					To:   []dnsv1alpha2.ZoneReferenceGrantTo{{Name: ptr.To(grantZoneName)}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, grant)).To(Succeed())
+			})
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, crossLookupKey, crossResource)
+				return err == nil && crossResource.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			_, found = readFromRecordsMap(makeCanonical(crossResourceName))
+			Expect(found).To(BeTrue(), "RRset should be created in the backend")
+			Expect(crossResource.GetOwnerReferences()).To(BeEmpty(), "RRset should not be owned by a Zone from another namespace")
+		})
+	})
+
+	Context("When creating RRsets managing their PTR records", func() {
+		It("should generate the PTR records in the reverse Zone and report conflicts", Label("rrset-creation", "ptr-records"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			ptrNamespace := "example1"
+			forwardZoneName := "example12.org"
+			reverseZoneName := "2.0.192.in-addr.arpa"
+			ptrName := "10.2.0.192.in-addr.arpa."
+
+			By("Creating the forward and reverse Zones")
+			for _, name := range []string{forwardZoneName, reverseZoneName} {
+				zone := &dnsv1alpha2.Zone{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: ptrNamespace,
+					},
+					Spec: dnsv1alpha2.ZoneSpec{
+						Kind:        NATIVE_KIND_ZONE,
+						Nameservers: []string{"ns1.example12.org", "ns2.example12.org"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+				})
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(zone), zone)
+					return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+				}, timeout, interval).Should(BeTrue())
+			}
+
+			newRRset := func(name string) *dnsv1alpha2.RRset {
+				return &dnsv1alpha2.RRset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name + "." + forwardZoneName,
+						Namespace: ptrNamespace,
+					},
+					Spec: dnsv1alpha2.RRsetSpec{
+						ZoneRef: dnsv1alpha2.ZoneRef{
+							Name: forwardZoneName,
+							Kind: "Zone",
+						},
+						Type:      "A",
+						Name:      name,
+						TTL:       uint32(300),
+						Records:   []string{"192.0.2.10"},
+						ManagePTR: ptr.To(true),
+					},
+				}
+			}
+
+			By("Creating a RRset managing its PTR records")
+			wwwRRset := newRRset("www")
+			Expect(k8sClient.Create(ctx, wwwRRset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(wwwRRset), wwwRRset)
+				return err == nil && wwwRRset.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(wwwRRset.Status.PTRRecords).To(Equal([]dnsv1alpha2.PTRRecordStatus{{Address: "192.0.2.10", Name: ptrName, Zone: reverseZoneName}}))
+			Expect(getMockedRecordsForType(ptrName, "PTR")).To(Equal([]string{"www.example12.org."}))
+
+			By("Creating another RRset with the same address")
+			apiRRset := newRRset("api")
+			Expect(k8sClient.Create(ctx, apiRRset)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, apiRRset)).To(Succeed())
+			})
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(apiRRset), apiRRset)
+				return err == nil && apiRRset.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(apiRRset.Status.PTRRecords).To(HaveLen(1))
+			Expect(apiRRset.Status.PTRRecords[0].Conflict).NotTo(BeNil(), "PTR record should be reported in conflict")
+			Expect(getMockedRecordsForType(ptrName, "PTR")).To(Equal([]string{"www.example12.org."}))
+
+			By("Deleting the RRset owning the PTR record, the RRset in conflict takes it over")
+			Expect(k8sClient.Delete(ctx, wwwRRset)).To(Succeed())
+			Eventually(func() []string {
+				return getMockedRecordsForType(ptrName, "PTR")
+			}, timeout, interval).Should(Equal([]string{"api.example12.org."}))
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(apiRRset), apiRRset)
+				return err == nil && len(apiRRset.Status.PTRRecords) == 1 && apiRRset.Status.PTRRecords[0].Conflict == nil
+			}, timeout, interval).Should(BeTrue())
+
+			By("Creating a PTR record directly in the mock, as created by another tool")
+			manualPtrName := "11.2.0.192.in-addr.arpa."
+			writeToRecordsMap(makeCanonical(manualPtrName), &powerdns.RRset{
+				Type:    ptr.To(powerdns.RRTypePTR),
+				Name:    ptr.To(manualPtrName),
+				TTL:     ptr.To(uint32(300)),
+				Records: []powerdns.Record{{Content: ptr.To("manual.example12.org.")}},
+			})
+
+			By("Creating a RRset with the address of the manual PTR record")
+			mailRRset := newRRset("mail")
+			mailRRset.Spec.Records = []string{"192.0.2.11"}
+			Expect(k8sClient.Create(ctx, mailRRset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(mailRRset), mailRRset)
+				return err == nil && mailRRset.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(mailRRset.Status.PTRRecords).To(HaveLen(1))
+			Expect(mailRRset.Status.PTRRecords[0].Conflict).NotTo(BeNil(), "manual PTR record should be reported in conflict")
+			Expect(getMockedRecordsForType(manualPtrName, "PTR")).To(Equal([]string{"manual.example12.org."}))
+
+			By("Deleting the RRset, the manual PTR record is kept")
+			Expect(k8sClient.Delete(ctx, mailRRset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(mailRRset), mailRRset)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType(manualPtrName, "PTR")).To(Equal([]string{"manual.example12.org."}))
+			deleteFromRecordsMap(makeCanonical(manualPtrName))
+		})
+
+		It("should build the LUA record and enable LUA records on the Zone", Label("rrset-creation", "lua-records"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			luaNamespace := "example1"
+			luaZoneName := "example13.org"
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      luaZoneName,
+					Namespace: luaNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example13.org", "ns2.example13.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(zone), zone)
+				return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedMetadata(luaZoneName, METADATA_ENABLE_LUA_RECORDS)).To(BeEmpty())
+
+			By("Creating a structured LUA RRset")
+			luaRRset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "www." + luaZoneName,
+					Namespace: luaNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: luaZoneName,
+						Kind: "Zone",
+					},
+					Type: LUA_RECORD_TYPE,
+					Name: "www",
+					TTL:  uint32(300),
+					Lua: &dnsv1alpha2.LuaRecord{
+						Type:       "A",
+						PickRandom: []string{"192.0.2.1", "192.0.2.2"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, luaRRset)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, luaRRset)).To(Succeed())
+			})
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(luaRRset), luaRRset)
+				return err == nil && luaRRset.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType("www."+luaZoneName+".", LUA_RECORD_TYPE)).To(Equal([]string{`A "pickrandom({'192.0.2.1', '192.0.2.2'})"`}))
+			Expect(getMockedMetadata(luaZoneName, METADATA_ENABLE_LUA_RECORDS)).To(Equal([]string{"1"}))
+		})
+
+		It("should only publish the records of the healthy addresses", Label("rrset-creation", "health-check"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			healthNamespace := "example1"
+			healthZoneName := "example19.org"
+			healthyAddress := "127.0.0.1"
+			unhealthyAddress := "127.0.0.2"
+
+			By("Listening on the healthy address only")
+			listener, err := net.Listen("tcp", healthyAddress+":0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				_ = listener.Close()
+			})
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					_ = conn.Close()
+				}
+			}()
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      healthZoneName,
+					Namespace: healthNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example19.org", "ns2.example19.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+
+			By("Creating a health checked RRset")
+			healthRRset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "front." + healthZoneName,
+					Namespace: healthNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: healthZoneName,
+						Kind: "Zone",
+					},
+					Type:    "A",
+					Name:    "front",
+					TTL:     uint32(30),
+					Records: []string{healthyAddress, unhealthyAddress},
+					HealthCheck: &dnsv1alpha2.HealthCheck{
+						Type:               "TCP",
+						Port:               int32(listener.Addr().(*net.TCPAddr).Port),
+						Interval:           &metav1.Duration{Duration: time.Second},
+						HealthyThreshold:   1,
+						UnhealthyThreshold: 1,
+						MinHealthy:         1,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, healthRRset)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, healthRRset)).To(Succeed())
+			})
+
+			By("Getting the records of the healthy addresses")
+			fqdn := "front." + healthZoneName + "."
+			Eventually(func() []string {
+				return getMockedRecordsForType(fqdn, "A")
+			}, timeout, interval).Should(Equal([]string{healthyAddress}))
+			Eventually(func() []dnsv1alpha2.TargetHealth {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(healthRRset), healthRRset)
+				return healthRRset.Status.HealthChecks
+			}, timeout, interval).Should(HaveLen(2))
+			Expect(healthRRset.Status.HealthChecks[0].Healthy).To(BeTrue())
+			Expect(healthRRset.Status.HealthChecks[1].Healthy).To(BeFalse())
+			Expect(healthRRset.Status.HealthChecks[1].Published).To(BeFalse())
+			Expect(healthRRset.Status.HealthChecks[1].Message).NotTo(BeNil())
+			Expect(getRrsetTargetHealthMetric(fqdn, "A", healthyAddress, healthRRset.Name, healthNamespace)).To(Equal(1.0))
+			Expect(getRrsetTargetHealthMetric(fqdn, "A", unhealthyAddress, healthRRset.Name, healthNamespace)).To(Equal(0.0))
+
+			By("Requiring more healthy addresses than available")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(healthRRset), healthRRset)
+				healthRRset.Spec.HealthCheck.MinHealthy = 2
+				return k8sClient.Update(ctx, healthRRset)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return getMockedRecordsForType(fqdn, "A")
+			}, timeout, interval).Should(Equal([]string{healthyAddress, unhealthyAddress}))
+
+			By("Stopping the healthy address")
+			_ = listener.Close()
+			Eventually(func() bool {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(healthRRset), healthRRset)
+				return len(healthRRset.Status.HealthChecks) == 2 && !healthRRset.Status.HealthChecks[0].Healthy
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType(fqdn, "A")).To(Equal([]string{healthyAddress, unhealthyAddress}), "All records should be published when fewer addresses than the minimum are healthy")
 		})
 	})
 })
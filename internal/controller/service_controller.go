@@ -0,0 +1,297 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// SERVICE_ZONE_ANNOTATION enables the records of a headless Service, its value is the name of the zone they are published in
+	SERVICE_ZONE_ANNOTATION = "dns.cav.enablers.ob/zone"
+	// SERVICE_ZONE_KIND_ANNOTATION is the kind of the zone, "Zone" (in the namespace of the Service) or "ClusterZone", defaults to "Zone"
+	SERVICE_ZONE_KIND_ANNOTATION = "dns.cav.enablers.ob/zone-kind"
+	// SERVICE_HOSTNAME_ANNOTATION is the name of the Service in the zone, relative to the zone, defaults to the name of the Service
+	SERVICE_HOSTNAME_ANNOTATION = "dns.cav.enablers.ob/hostname"
+	// SERVICE_TTL_ANNOTATION is the DNS TTL of the records, in seconds, defaults to SERVICE_DEFAULT_TTL
+	SERVICE_TTL_ANNOTATION = "dns.cav.enablers.ob/ttl"
+	// SERVICE_DEFAULT_TTL is short, the records follow the pods
+	SERVICE_DEFAULT_TTL = 60
+
+	// SERVICE_LABEL is set on the RRsets generated from a Service, its value is the name of the Service
+	SERVICE_LABEL = "dns.cav.enablers.ob/service"
+	// SERVICE_RECORDS_FINALIZER_NAME deletes the RRsets of a Service along with it, they are also owned by their zone
+	// and would not be garbage collected
+	SERVICE_RECORDS_FINALIZER_NAME = "dns.cav.enablers.ob/service-records"
+
+	// ServiceReasonInvalidAnnotations is the reason of the Event reporting invalid annotations on a Service
+	ServiceReasonInvalidAnnotations = "InvalidAnnotations"
+
+	// SRV_PRIORITY and SRV_WEIGHT are the priority and weight of the SRV records, all the pods are equivalent
+	SRV_PRIORITY = 0
+	SRV_WEIGHT   = 10
+)
+
+// ServiceReconciler generates SRV and A/AAAA records from the EndpointSlices of annotated headless Services
+type ServiceReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	service := &corev1.Service{}
+	if err := r.Get(ctx, req.NamespacedName, service); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// The RRsets are deleted when the Service is deleted or no longer annotated
+	if !service.DeletionTimestamp.IsZero() || !isDiscoveredService(service) {
+		if controllerutil.ContainsFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME) {
+			log.Info("Deleting Service records", "Service.Name", service.Name)
+			if _, err := reconcileOwnedRRsets(ctx, r.Client, service, SERVICE_LABEL, nil); err != nil {
+				log.Error(err, "Failed to delete Service RRsets")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME)
+			if err := r.Update(ctx, service); err != nil {
+				log.Error(err, "Failed to remove finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	log.Info("Reconcile Service", "Service.Name", service.Name)
+
+	if !controllerutil.ContainsFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME) {
+		controllerutil.AddFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME)
+		if err := r.Update(ctx, service); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &endpointSlices, client.InNamespace(service.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: service.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	desired, err := serviceRRsets(service, endpointSlices.Items)
+	if err != nil {
+		// Invalid annotations are not retried, the Service is reconciled again once modified.
+		// The published records are kept until then
+		log.Info("Invalid Service annotations", "Service.Name", service.Name, "Error", err.Error())
+		r.Recorder.Event(service, corev1.EventTypeWarning, ServiceReasonInvalidAnnotations, err.Error())
+		return ctrl.Result{}, nil
+	}
+	if _, err := reconcileOwnedRRsets(ctx, r.Client, service, SERVICE_LABEL, desired); err != nil {
+		log.Error(err, "Failed to reconcile Service RRsets")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Only the annotated Services, and the Services whose records are to be deleted, are reconciled
+		For(&corev1.Service{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, ok := obj.GetAnnotations()[SERVICE_ZONE_ANNOTATION]
+			return ok || controllerutil.ContainsFinalizer(obj, SERVICE_RECORDS_FINALIZER_NAME)
+		}))).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceService)).
+		// RRsets are controlled by their zone, the Service is one of their owners
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &corev1.Service{})).
+		Complete(r)
+}
+
+// endpointSliceService returns the Service of an EndpointSlice, if its records are published
+func (r *ServiceReconciler) endpointSliceService(ctx context.Context, obj client.Object) []reconcile.Request {
+	name, ok := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if !ok {
+		return nil
+	}
+	service := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}, service); err != nil {
+		if !errors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "unable to get Service")
+		}
+		return nil
+	}
+	if !isDiscoveredService(service) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(service)}}
+}
+
+// ServiceCleaner deletes the records of the Services and their finalizer when the service discovery is disabled,
+// the ServiceReconciler is not run to delete them
+type ServiceCleaner struct {
+	client.Client
+	// APIReader lists the Services, they are not cached when the service discovery is disabled
+	APIReader client.Reader
+}
+
+// SetupWithManager runs the ServiceCleaner once the Manager is elected
+func (r *ServiceCleaner) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+// Start deletes the records of the Services still holding the finalizer, and the finalizer
+func (r *ServiceCleaner) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("service-cleaner")
+
+	var services corev1.ServiceList
+	if err := r.APIReader.List(ctx, &services); err != nil {
+		return err
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if !controllerutil.ContainsFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME) {
+			continue
+		}
+		log.Info("Deleting Service records, service discovery is disabled", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
+		if _, err := reconcileOwnedRRsets(ctx, r.Client, service, SERVICE_LABEL, nil); err != nil {
+			return err
+		}
+		controllerutil.RemoveFinalizer(service, SERVICE_RECORDS_FINALIZER_NAME)
+		if err := r.Update(ctx, service); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDiscoveredService returns True if records must be generated for the Service: an annotated headless Service
+func isDiscoveredService(service *corev1.Service) bool {
+	_, ok := service.Annotations[SERVICE_ZONE_ANNOTATION]
+	return ok && service.Spec.ClusterIP == corev1.ClusterIPNone
+}
+
+// serviceRRsets returns the specs of the RRsets generated from the EndpointSlices of the Service, indexed by the name of the RRsets:
+// an A and/or AAAA RRset "<pod>.<service>" per ready endpoint, and a SRV RRset "_<port>._<protocol>.<service>" per named port
+func serviceRRsets(service *corev1.Service, endpointSlices []discoveryv1.EndpointSlice) (map[string]dnsv1alpha2.RRsetSpec, error) {
+	zoneRef := dnsv1alpha2.ZoneRef{Name: service.Annotations[SERVICE_ZONE_ANNOTATION], Kind: "Zone"}
+	if kind, ok := service.Annotations[SERVICE_ZONE_KIND_ANNOTATION]; ok {
+		if kind != "Zone" && kind != "ClusterZone" {
+			return nil, fmt.Errorf("invalid %s annotation %q, one of Zone, ClusterZone expected", SERVICE_ZONE_KIND_ANNOTATION, kind)
+		}
+		zoneRef.Kind = kind
+	}
+	ttl := uint32(SERVICE_DEFAULT_TTL)
+	if value, ok := service.Annotations[SERVICE_TTL_ANNOTATION]; ok {
+		v, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation %q: %w", SERVICE_TTL_ANNOTATION, value, err)
+		}
+		ttl = uint32(v)
+	}
+	hostname := strings.TrimSuffix(service.Annotations[SERVICE_HOSTNAME_ANNOTATION], ".")
+	if hostname == "" {
+		hostname = service.Name
+	}
+	zone := makeCanonical(zoneRef.Name)
+
+	addresses := make(map[string][]string)
+	srvRecords := make(map[string][]string)
+	for _, endpointSlice := range endpointSlices {
+		rrType := "A"
+		switch endpointSlice.AddressType {
+		case discoveryv1.AddressTypeIPv4:
+		case discoveryv1.AddressTypeIPv6:
+			rrType = "AAAA"
+		default:
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			if !ptr.Deref(endpoint.Conditions.Ready, true) || len(endpoint.Addresses) == 0 {
+				continue
+			}
+			host := endpointHostname(endpoint)
+			key := host + "/" + rrType
+			addresses[key] = append(addresses[key], endpoint.Addresses...)
+			for _, port := range endpointSlice.Ports {
+				if ptr.Deref(port.Name, "") == "" || port.Port == nil {
+					continue
+				}
+				name := fmt.Sprintf("_%s._%s", *port.Name, strings.ToLower(string(ptr.Deref(port.Protocol, corev1.ProtocolTCP))))
+				record := fmt.Sprintf("%d %d %d %s.%s.%s", SRV_PRIORITY, SRV_WEIGHT, *port.Port, host, hostname, zone)
+				if !slices.Contains(srvRecords[name], record) {
+					srvRecords[name] = append(srvRecords[name], record)
+				}
+			}
+		}
+	}
+
+	rrsets := make(map[string]dnsv1alpha2.RRsetSpec)
+	for key, records := range addresses {
+		host, rrType, _ := strings.Cut(key, "/")
+		slices.Sort(records)
+		rrsets[fmt.Sprintf("%s-%s-%s", service.Name, host, strings.ToLower(rrType))] = dnsv1alpha2.RRsetSpec{
+			Type:    rrType,
+			Name:    host + "." + hostname,
+			TTL:     ttl,
+			Records: slices.Compact(records),
+			ZoneRef: zoneRef,
+		}
+	}
+	for name, records := range srvRecords {
+		slices.Sort(records)
+		rrsets[fmt.Sprintf("%s-srv-%s", service.Name, strings.ReplaceAll(strings.TrimPrefix(name, "_"), "._", "-"))] = dnsv1alpha2.RRsetSpec{
+			Type:    "SRV",
+			Name:    name + "." + hostname,
+			TTL:     ttl,
+			Records: records,
+			ZoneRef: zoneRef,
+		}
+	}
+	return rrsets, nil
+}
+
+// endpointHostname returns the name of the record of an endpoint: its hostname (pods of a StatefulSet),
+// the name of its pod, or its address
+func endpointHostname(endpoint discoveryv1.Endpoint) string {
+	if hostname := ptr.Deref(endpoint.Hostname, ""); hostname != "" {
+		return hostname
+	}
+	if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+		return endpoint.TargetRef.Name
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(endpoint.Addresses[0])
+}
@@ -0,0 +1,197 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("Service Controller", func() {
+	const (
+		zoneName      = "example18.org"
+		zoneNamespace = "example1"
+		serviceName   = "web"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+
+	endpoint := func(pod, address string, ready bool) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(ready)},
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod, Namespace: zoneNamespace},
+		}
+	}
+	serviceRRsetsCount := func(ctx context.Context) int {
+		var rrsets dnsv1alpha2.RRsetList
+		_ = k8sClient.List(ctx, &rrsets, client.InNamespace(zoneNamespace), client.MatchingLabels{SERVICE_LABEL: serviceName})
+		return len(rrsets.Items)
+	}
+
+	Context("When annotating a headless Service", func() {
+		It("should publish SRV and A records following its endpoints", Label("service"), func() {
+			ctx := context.Background()
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      zoneName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example18.org", "ns2.example18.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+
+			By("Creating the Service and its EndpointSlice")
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceName,
+					Namespace:   zoneNamespace,
+					Annotations: map[string]string{SERVICE_ZONE_ANNOTATION: zoneName, SERVICE_TTL_ANNOTATION: "30"},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: corev1.ClusterIPNone,
+					Ports:     []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, service)).To(Succeed())
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName + "-abcde",
+					Namespace: zoneNamespace,
+					Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					endpoint("web-0", "10.0.0.1", true),
+					endpoint("web-1", "10.0.0.2", true),
+					endpoint("web-2", "10.0.0.3", false),
+				},
+				Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080)), Protocol: ptr.To(corev1.ProtocolTCP)}},
+			}
+			Expect(k8sClient.Create(ctx, endpointSlice)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, endpointSlice)).To(Succeed())
+			})
+
+			By("Getting the records of the ready endpoints")
+			Eventually(func() []string {
+				return getMockedRecordsForType("_http._tcp.web."+zoneName, "SRV")
+			}, timeout, interval).Should(Equal([]string{
+				"0 10 8080 web-0.web.example18.org.",
+				"0 10 8080 web-1.web.example18.org.",
+			}))
+			Eventually(func() []string {
+				return getMockedRecordsForType("web-1.web."+zoneName, "A")
+			}, timeout, interval).Should(Equal([]string{"10.0.0.2"}))
+			Expect(getMockedTTL("web-1.web."+zoneName, "A")).To(Equal(uint32(30)))
+			Expect(getMockedRecordsForType("web-2.web."+zoneName, "A")).To(BeEmpty())
+			Expect(serviceRRsetsCount(ctx)).To(Equal(3))
+
+			By("Removing an endpoint")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(endpointSlice), endpointSlice)
+				endpointSlice.Endpoints = endpointSlice.Endpoints[:1]
+				return k8sClient.Update(ctx, endpointSlice)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return getMockedRecordsForType("_http._tcp.web."+zoneName, "SRV")
+			}, timeout, interval).Should(Equal([]string{"0 10 8080 web-0.web.example18.org."}))
+			Eventually(func() []string {
+				return getMockedRecordsForType("web-1.web."+zoneName, "A")
+			}, timeout, interval).Should(BeEmpty())
+
+			By("Setting an invalid annotation, the records are kept")
+			Eventually(func() error {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service)
+				service.Annotations[SERVICE_TTL_ANNOTATION] = "soon"
+				return k8sClient.Update(ctx, service)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() bool {
+				var events corev1.EventList
+				_ = k8sClient.List(ctx, &events, client.InNamespace(zoneNamespace))
+				return slices.ContainsFunc(events.Items, func(e corev1.Event) bool {
+					return e.InvolvedObject.UID == service.UID && e.Reason == ServiceReasonInvalidAnnotations
+				})
+			}, timeout, interval).Should(BeTrue())
+			Expect(serviceRRsetsCount(ctx)).To(Equal(2))
+			Expect(getMockedRecordsForType("_http._tcp.web."+zoneName, "SRV")).To(Equal([]string{"0 10 8080 web-0.web.example18.org."}))
+
+			By("Deleting the Service")
+			Expect(k8sClient.Delete(ctx, service)).To(Succeed())
+			Eventually(func() int {
+				return serviceRRsetsCount(ctx)
+			}, timeout, interval).Should(BeZero())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service))
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType("web-0.web."+zoneName, "A")).To(BeEmpty())
+		})
+	})
+})
+
+func TestServiceCleaner(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:       "web",
+		Namespace:  "default",
+		UID:        "web-uid",
+		Finalizers: []string{SERVICE_RECORDS_FINALIZER_NAME},
+	}}
+	rrset := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{
+		Name:            "web-srv-http-tcp",
+		Namespace:       "default",
+		Labels:          map[string]string{SERVICE_LABEL: "web"},
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Service", Name: "web", UID: "web-uid"}},
+	}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service, rrset).Build()
+
+	if err := (&ServiceCleaner{Client: cl, APIReader: cl}).Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(rrset), rrset); !errors.IsNotFound(err) {
+		t.Errorf("got %v, want the RRset of the Service deleted", err)
+	}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(service), service); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(service.Finalizers) != 0 {
+		t.Errorf("got finalizers %v, want none", service.Finalizers)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import "time"
+
+// Settings apply to all the reconcilers of the operator instance
+type Settings struct {
+	// NameserversTTL is the TTL of the NS records of the zones which do not set one
+	NameserversTTL uint32
+	// Account marks the objects of PowerDNS owned by the operator, see operatorAccount
+	Account string
+	// PrunePeriod is the period zones with the "Authoritative" records policy are reconciled again
+	PrunePeriod time.Duration
+	// ObservePeriod is the period observed zones, and the RRsets referencing them, are read again from PowerDNS
+	ObservePeriod time.Duration
+}
+
+// DefaultSettings returns the settings of an operator instance without configuration
+func DefaultSettings() Settings {
+	return Settings{
+		NameserversTTL: DEFAULT_TTL_FOR_NS_RECORDS,
+		Account:        OPERATOR_ACCOUNT,
+		PrunePeriod:    PRUNE_PERIOD,
+		ObservePeriod:  OBSERVE_PERIOD,
+	}
+}
+
+// settings are set once, before the reconcilers are started
+var settings = DefaultSettings()
+
+// Configure sets the settings of the reconcilers, it must be called before they are started
+func Configure(s Settings) {
+	settings = s
+}
@@ -0,0 +1,134 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// soaRecord is a parsed SOA record
+type soaRecord struct {
+	dnsv1alpha2.SOAStatus
+	Serial uint32
+}
+
+// parseSOA parses the content of a SOA record: "mname rname serial refresh retry expire minimum"
+func parseSOA(content string, ttl uint32) (*soaRecord, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 7 {
+		return nil, fmt.Errorf("invalid SOA record %q", content)
+	}
+	var values [5]uint32
+	for i, f := range fields[2:] {
+		v, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SOA record %q: %w", content, err)
+		}
+		values[i] = uint32(v)
+	}
+	return &soaRecord{
+		SOAStatus: dnsv1alpha2.SOAStatus{
+			Primary: fields[0],
+			Contact: fields[1],
+			Refresh: values[1],
+			Retry:   values[2],
+			Expire:  values[3],
+			Minimum: values[4],
+			TTL:     ttl,
+		},
+		Serial: values[0],
+	}, nil
+}
+
+// String returns the content of the SOA record
+func (s *soaRecord) String() string {
+	return fmt.Sprintf("%s %s %d %d %d %d %d", s.Primary, s.Contact, s.Serial, s.Refresh, s.Retry, s.Expire, s.Minimum)
+}
+
+// soaContact returns the mailbox in the SOA format, e.g. "hostmaster@example.org" becomes "hostmaster.example.org."
+// The dots of the local part of an e-mail address are escaped.
+func soaContact(contact string) string {
+	if local, domain, ok := strings.Cut(contact, "@"); ok {
+		contact = strings.ReplaceAll(local, ".", "\\.") + "." + domain
+	}
+	return makeCanonical(contact)
+}
+
+// desiredSOA returns the SOA record expected from the spec of the zone, the fields not set are kept from the current record
+func desiredSOA(spec *dnsv1alpha2.SOA, current soaRecord) soaRecord {
+	desired := current
+	if spec.Primary != nil {
+		desired.Primary = makeCanonical(*spec.Primary)
+	}
+	if spec.Contact != nil {
+		desired.Contact = soaContact(*spec.Contact)
+	}
+	desired.Refresh = ptr.Deref(spec.Refresh, current.Refresh)
+	desired.Retry = ptr.Deref(spec.Retry, current.Retry)
+	desired.Expire = ptr.Deref(spec.Expire, current.Expire)
+	desired.Minimum = ptr.Deref(spec.Minimum, current.Minimum)
+	desired.TTL = ptr.Deref(spec.TTL, current.TTL)
+	return desired
+}
+
+// getSOA returns the SOA record of the zone, nil if not found
+func getSOA(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter) (*soaRecord, error) {
+	rrsets, err := PDNSClient.Records.Get(ctx, zone.GetName(), zone.GetName(), ptr.To(powerdns.RRTypeSOA))
+	if err != nil {
+		return nil, err
+	}
+	// Comments and records of other RRSets may be included, see zoneExternalResourcesReconcile
+	for _, rr := range rrsets {
+		if ptr.Deref(rr.Name, "") == makeCanonical(zone.GetName()) && ptr.Deref(rr.Type, "") == powerdns.RRTypeSOA && len(rr.Records) > 0 {
+			return parseSOA(ptr.Deref(rr.Records[0].Content, ""), ptr.Deref(rr.TTL, 0))
+		}
+	}
+	return nil, nil
+}
+
+// reconcileSOA updates the SOA record of the zone if it differs from its spec, and returns the resulting SOA record.
+// The serial of the current record is sent back unchanged: PowerDNS increases it according to SOA-EDIT-API,
+// and it is never compared, so that the operator does not fight over it.
+func reconcileSOA(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) (*dnsv1alpha2.SOAStatus, error) {
+	current, err := getSOA(ctx, zone, PDNSClient)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	spec := zone.GetSpec().SOA
+	if spec == nil {
+		return &current.SOAStatus, nil
+	}
+
+	desired := desiredSOA(spec, *current)
+	if desired.SOAStatus == current.SOAStatus {
+		return &current.SOAStatus, nil
+	}
+	log.Info("Updating SOA record", "Current", current.String(), "Desired", desired.String())
+	if err := PDNSClient.Records.Change(ctx, makeCanonical(zone.GetName()), makeCanonical(zone.GetName()), powerdns.RRTypeSOA, desired.TTL, []string{desired.String()}); err != nil {
+		return nil, err
+	}
+
+	current, err = getSOA(ctx, zone, PDNSClient)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	return &current.SOAStatus, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestParseSOA(t *testing.T) {
+	var testCases = []struct {
+		content string
+		ok      bool
+	}{
+		{"ns1.example.org. hostmaster.example.org. 2024010101 10800 3600 604800 3600", true},
+		{"ns1.example.org. hostmaster.example.org. 2024010101 10800 3600 604800", false},
+		{"ns1.example.org. hostmaster.example.org. 2024010101 10800 3600 604800 -1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.content, func(t *testing.T) {
+			soa, err := parseSOA(tc.content, 300)
+			if (err == nil) != tc.ok {
+				t.Fatalf("got error %v, want ok %v", err, tc.ok)
+			}
+			if tc.ok && soa.String() != tc.content {
+				t.Errorf("got %q, want %q", soa.String(), tc.content)
+			}
+		})
+	}
+}
+
+func TestSOAContact(t *testing.T) {
+	var testCases = []struct {
+		contact string
+		want    string
+	}{
+		{"hostmaster.example.org", "hostmaster.example.org."},
+		{"hostmaster.example.org.", "hostmaster.example.org."},
+		{"hostmaster@example.org", "hostmaster.example.org."},
+		{"dns.admin@example.org", "dns\\.admin.example.org."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.contact, func(t *testing.T) {
+			if got := soaContact(tc.contact); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDesiredSOA(t *testing.T) {
+	current, err := parseSOA("a.misconfigured.dns.server.invalid. hostmaster.example.org. 2024010101 10800 3600 604800 3600", 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desired := desiredSOA(&dnsv1alpha2.SOA{
+		Primary: ptr.To("ns1.example.org"),
+		Contact: ptr.To("dns@example.org"),
+		Minimum: ptr.To(uint32(300)),
+	}, *current)
+	want := "ns1.example.org. dns.example.org. 2024010101 10800 3600 604800 300"
+	if desired.String() != want || desired.TTL != 3600 {
+		t.Errorf("got %q (TTL %d), want %q (TTL 3600)", desired.String(), desired.TTL, want)
+	}
+	if same := desiredSOA(&dnsv1alpha2.SOA{}, *current); same.SOAStatus != current.SOAStatus {
+		t.Errorf("got %q, want the current SOA record %q", same.String(), current.String())
+	}
+}
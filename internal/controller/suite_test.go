@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -42,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -57,8 +59,10 @@ var (
 )
 
 var (
-	zones   sync.Map
-	records sync.Map
+	zones    sync.Map
+	records  sync.Map
+	metadata sync.Map
+	actions  sync.Map
 )
 
 const (
@@ -128,6 +132,24 @@ func readFromRecordsMap(key string) (*powerdns.RRset, bool) {
 	return result, true
 }
 
+// recordsMapKey returns the key of a RRSet in the Records sync.Map:
+// RRSets are stored by name, except the SOA records which must not override the NS records of the zones
+func recordsMapKey(name string, recordType powerdns.RRType) string {
+	if recordType == powerdns.RRTypeSOA {
+		return makeCanonical(name) + "/" + string(recordType)
+	}
+	return makeCanonical(name)
+}
+
+// mockRecordKey returns the key of a RRSet of the domain in the Records sync.Map,
+// the NS records delegating a subdomain are kept apart from the NS records at the apex of the subdomain zone
+func mockRecordKey(domain, name string, recordType powerdns.RRType) string {
+	if recordType == powerdns.RRTypeNS && makeCanonical(domain) != makeCanonical(name) {
+		return makeCanonical(name) + "/NS/" + makeCanonical(domain)
+	}
+	return recordsMapKey(name, recordType)
+}
+
 // deleteFromRecordsMap removes a key from the Records sync.Map
 func deleteFromRecordsMap(key string) {
 	records.Delete(key)
@@ -143,6 +165,15 @@ func resetRecordsMap() {
 	records.Clear()
 }
 
+// waitUntilOlderThanNow waits for an existing object to be older than any object created from now on,
+// creation timestamps being precise to the second, to make sure it wins the election among duplicates
+func waitUntilOlderThanNow(ctx context.Context, key client.ObjectKey, obj client.Object) {
+	Expect(k8sClient.Get(ctx, key, obj)).To(Succeed())
+	Eventually(func() bool {
+		return time.Since(obj.GetCreationTimestamp().Time) >= time.Second
+	}, 2*time.Second, 100*time.Millisecond).Should(BeTrue())
+}
+
 func TestControllers(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -184,6 +215,11 @@ var _ = BeforeSuite(func() {
 
 	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme.Scheme,
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}: {Label: DKIMSecretSelector()},
+			},
+		},
 	})
 	Expect(err).ToNot(HaveOccurred())
 
@@ -193,8 +229,10 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:  m.Records,
+			Zones:    m.Zones,
+			Metadata: m.Metadata,
+			Actions:  m.Actions,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -203,8 +241,10 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:  m.Records,
+			Zones:    m.Zones,
+			Metadata: m.Metadata,
+			Actions:  m.Actions,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -213,18 +253,54 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:  m.Records,
+			Zones:    m.Zones,
+			Metadata: m.Metadata,
+			Actions:  m.Actions,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ZoneActionReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		PDNSClient: PdnsClienter{
+			Records:  m.Records,
+			Zones:    m.Zones,
+			Metadata: m.Metadata,
+			Actions:  m.Actions,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&DKIMKeyReconciler{
+		Client:    k8sManager.GetClient(),
+		Scheme:    k8sManager.GetScheme(),
+		APIReader: k8sManager.GetAPIReader(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MailPolicyReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ServiceReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("service-discovery"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&ClusterZoneReconciler{
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:  m.Records,
+			Zones:    m.Zones,
+			Metadata: m.Metadata,
+			Actions:  m.Actions,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -273,17 +349,23 @@ var _ = AfterSuite(func() {
 })
 
 type mockClient struct {
-	Zones   mockZonesClient
-	Records mockRecordsClient
+	Zones    mockZonesClient
+	Records  mockRecordsClient
+	Metadata mockMetadataClient
+	Actions  mockActionsClient
 }
 
 type mockZonesClient struct{}
 type mockRecordsClient struct{}
+type mockMetadataClient struct{}
+type mockActionsClient struct{}
 
 func NewMockClient() mockClient {
 	return mockClient{
-		Zones:   mockZonesClient{},
-		Records: mockRecordsClient{},
+		Zones:    mockZonesClient{},
+		Records:  mockRecordsClient{},
+		Metadata: mockMetadataClient{},
+		Actions:  mockActionsClient{},
 	}
 }
 
@@ -328,6 +410,15 @@ func (m mockZonesClient) Add(ctx context.Context, zone *powerdns.Zone) (*powerdn
 		rrset.Records = append(rrset.Records, powerdns.Record{Content: &nsName, Disabled: ptr.To(false), SetPTR: ptr.To(false)})
 	}
 	writeToRecordsMap(zoneCanonicalName, &rrset)
+
+	// RRset type SOA creation, with the default values of PowerDNS
+	soaContent := fmt.Sprintf("a.misconfigured.dns.server.invalid. hostmaster.%s %d 10800 3600 604800 3600", zoneCanonicalName, serial)
+	writeToRecordsMap(recordsMapKey(zoneCanonicalName, powerdns.RRTypeSOA), &powerdns.RRset{
+		Name:    &zoneCanonicalName,
+		TTL:     ptr.To(uint32(3600)),
+		Type:    ptr.To(powerdns.RRTypeSOA),
+		Records: []powerdns.Record{{Content: &soaContent, Disabled: ptr.To(false), SetPTR: ptr.To(false)}},
+	})
 	writeToZonesMap(zoneCanonicalName, zone)
 	return zone, nil
 }
@@ -361,6 +452,7 @@ func (m mockZonesClient) Delete(ctx context.Context, domain string) error {
 	}
 
 	deleteFromRecordsMap(makeCanonical(domain))
+	deleteFromRecordsMap(recordsMapKey(domain, powerdns.RRTypeSOA))
 	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
 		return powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
 	}
@@ -384,7 +476,7 @@ func (m mockZonesClient) Change(ctx context.Context, domain string, zone *powerd
 		return powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
 	}
 	serial := localZone.Serial
-	if *zone.Kind != *localZone.Kind || *zone.Catalog != *localZone.Catalog || *zone.SOAEditAPI != *localZone.SOAEditAPI {
+	if *zone.Kind != *localZone.Kind || ptr.Deref(zone.Catalog, "") != ptr.Deref(localZone.Catalog, "") || *zone.SOAEditAPI != *localZone.SOAEditAPI {
 		switch *zone.SOAEditAPI {
 		case "EPOCH":
 			serial = ptr.To(uint32(time.Now().UTC().Unix()))
@@ -408,7 +500,7 @@ func (m mockZonesClient) Change(ctx context.Context, domain string, zone *powerd
 
 func (m mockRecordsClient) Get(ctx context.Context, domain string, name string, recordType *powerdns.RRType) ([]powerdns.RRset, error) {
 	results := []powerdns.RRset{}
-	if record, ok := readFromRecordsMap(makeCanonical(name)); ok {
+	if record, ok := readFromRecordsMap(mockRecordKey(domain, name, ptr.Deref(recordType, ""))); ok {
 		results = append(results, *record)
 		return results, nil
 	}
@@ -455,7 +547,7 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 
 	var isRRsetIdentical, isNewRRset, ok bool
 	var rrset *powerdns.RRset
-	var comment, specifiedComment string
+	var comment, specifiedComment, account, specifiedAccount string
 
 	// The specified comment is included inside the opt function (through .WithComments)
 	// So to extract it, we need to apply opt() function on an empty RRSet
@@ -467,9 +559,10 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 	}
 	if len(fakeRrset.Comments) > 0 {
 		specifiedComment = *fakeRrset.Comments[0].Content
+		specifiedAccount = ptr.Deref(fakeRrset.Comments[0].Account, "")
 	}
 
-	if rrset, ok = readFromRecordsMap(makeCanonical(name)); !ok {
+	if rrset, ok = readFromRecordsMap(mockRecordKey(domain, name, recordType)); !ok {
 		rrset = &powerdns.RRset{}
 		isNewRRset = true
 	}
@@ -483,8 +576,9 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 
 		for _, c := range rrset.Comments {
 			comment = *c.Content
+			account = ptr.Deref(c.Account, "")
 		}
-		isRRsetIdentical = reflect.DeepEqual(localRecords, content) && reflect.DeepEqual(*rrset.TTL, ttl) && reflect.DeepEqual(comment, specifiedComment)
+		isRRsetIdentical = reflect.DeepEqual(localRecords, content) && reflect.DeepEqual(*rrset.TTL, ttl) && reflect.DeepEqual(comment, specifiedComment) && account == specifiedAccount
 	}
 
 	rrset.Name = &name
@@ -493,32 +587,98 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 	rrset.ChangeType = powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace)
 	rrset.Records = make([]powerdns.Record, 0)
 	rrset.Comments = []powerdns.Comment{}
-	if specifiedComment != "" {
-		rrset.Comments = append(rrset.Comments, powerdns.Comment{Content: &specifiedComment})
+	if len(fakeRrset.Comments) > 0 {
+		rrset.Comments = append(rrset.Comments, powerdns.Comment{Content: &specifiedComment, Account: ptr.To(specifiedAccount)})
 	}
 
-	for _, c := range content {
+	// Like PowerDNS, the records are stored in their canonical form,
+	// sending them in another form is a modification bumping the serial
+	for _, c := range normalize.Records(string(recordType), content) {
 		localContent := c
 		r := powerdns.Record{Content: &localContent, Disabled: ptr.To(false), SetPTR: ptr.To(false)}
 		rrset.Records = append(rrset.Records, r)
 	}
-	writeToRecordsMap(makeCanonical(name), rrset)
 
 	if !isRRsetIdentical || isNewRRset {
 		if zone, ok := readFromZonesMap(makeCanonical(domain)); ok {
 			zone.Serial = ptr.To(*zone.Serial + uint32(1))
 			writeToZonesMap(makeCanonical(domain), zone)
+			// Like SOA-EDIT-API, the serial of a modified SOA record is the serial of the zone
+			if recordType == powerdns.RRTypeSOA {
+				fields := strings.Fields(content[0])
+				fields[2] = fmt.Sprintf("%d", *zone.Serial)
+				rrset.Records[0].Content = ptr.To(strings.Join(fields, " "))
+			}
 		}
 	}
+	writeToRecordsMap(mockRecordKey(domain, name, recordType), rrset)
 
 	return nil
 }
 
 func (m mockRecordsClient) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
-	deleteFromRecordsMap(makeCanonical(name))
+	deleteFromRecordsMap(mockRecordKey(domain, name, recordType))
 	return nil
 }
 
+func (m mockMetadataClient) Get(ctx context.Context, domain string, kind powerdns.MetadataKind) (*powerdns.Metadata, error) {
+	result := &powerdns.Metadata{Kind: &kind, Metadata: []string{}}
+	if values, ok := metadata.Load(makeCanonical(domain) + "/" + string(kind)); ok {
+		result.Metadata = values.([]string)
+	}
+	return result, nil
+}
+
+func (m mockMetadataClient) Set(ctx context.Context, domain string, kind powerdns.MetadataKind, values []string) (*powerdns.Metadata, error) {
+	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
+		return nil, powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	metadata.Store(makeCanonical(domain)+"/"+string(kind), values)
+	return &powerdns.Metadata{Kind: &kind, Metadata: values}, nil
+}
+
+func (m mockActionsClient) Notify(ctx context.Context, domain string) (string, error) {
+	return m.run(domain, ZoneActionNotify, "Notification queued")
+}
+
+func (m mockActionsClient) Rectify(ctx context.Context, domain string) (string, error) {
+	return m.run(domain, ZoneActionRectify, "Rectified")
+}
+
+func (m mockActionsClient) AxfrRetrieve(ctx context.Context, domain string) (string, error) {
+	return m.run(domain, ZoneActionAxfrRetrieve, "Added retrieval request for '"+makeCanonical(domain)+"' from primary")
+}
+
+func (m mockActionsClient) FlushCache(ctx context.Context, domain string) (string, error) {
+	return m.run(domain, ZoneActionFlushCache, "Flushed cache., 1 entries flushed")
+}
+
+// run counts the actions run on each zone
+func (m mockActionsClient) run(domain, action, result string) (string, error) {
+	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
+		return "", powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	count, _ := actions.LoadOrStore(makeCanonical(domain)+"/"+action, 0)
+	actions.Store(makeCanonical(domain)+"/"+action, count.(int)+1)
+	return result, nil
+}
+
+func getMockedActionsCount(zoneName, action string) int {
+	count, ok := actions.Load(makeCanonical(zoneName) + "/" + action)
+	if !ok {
+		return 0
+	}
+	return count.(int)
+}
+
+func getMockedMetadata(zoneName string, kind powerdns.MetadataKind) []string {
+	values, ok := metadata.Load(makeCanonical(zoneName) + "/" + string(kind))
+	if !ok {
+		return nil
+	}
+	return values.([]string)
+}
+
 func getMockedNameservers(zoneName string) (result []string) {
 	rrset, _ := readFromRecordsMap(makeCanonical(zoneName))
 	for _, r := range rrset.Records {
@@ -0,0 +1,231 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// TEMPLATE_LABEL is set on the RRsets/ClusterRRsets rendered from a ZoneTemplate, its value is the name of the template
+	TEMPLATE_LABEL = "dns.cav.enablers.ob/zone-template"
+	// TEMPLATE_ZONE_PLACEHOLDER is replaced by the name of the zone in the rendered RRsets
+	TEMPLATE_ZONE_PLACEHOLDER = "{zone}"
+)
+
+// templateName returns the name of the ZoneTemplate of the zone, empty if none
+func templateName(zone dnsv1alpha2.GenericZone) string {
+	if zone.GetSpec().TemplateRef == nil {
+		return ""
+	}
+	return zone.GetSpec().TemplateRef.Name
+}
+
+// renderTemplateRRset returns the RRset spec rendered from a ZoneTemplate entry for the zone
+func renderTemplateRRset(zone dnsv1alpha2.GenericZone, entry dnsv1alpha2.ZoneTemplateRRset) dnsv1alpha2.RRsetSpec {
+	render := func(s string) string {
+		return strings.ReplaceAll(s, TEMPLATE_ZONE_PLACEHOLDER, zone.GetName())
+	}
+	spec := dnsv1alpha2.RRsetSpec{
+		Type:    entry.Type,
+		Name:    render(entry.Name),
+		TTL:     entry.TTL,
+		ZoneRef: dnsv1alpha2.ZoneRef{Name: zone.GetName(), Kind: zoneKind(zone)},
+	}
+	for _, record := range entry.Records {
+		spec.Records = append(spec.Records, render(record))
+	}
+	if entry.Comment != nil {
+		comment := render(*entry.Comment)
+		spec.Comment = &comment
+	}
+	return spec
+}
+
+// newTemplateRRset returns an empty RRset, or ClusterRRset for a ClusterZone, named name
+func newTemplateRRset(zone dnsv1alpha2.GenericZone, name string) dnsv1alpha2.GenericRRset {
+	if _, ok := zone.(*dnsv1alpha2.ClusterZone); ok {
+		return &dnsv1alpha2.ClusterRRset{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	return &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: zone.GetNamespace()}}
+}
+
+// templateRRsets returns the RRsets/ClusterRRsets rendered from a ZoneTemplate for the zone, indexed by name
+func templateRRsets(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) (map[string]dnsv1alpha2.GenericRRset, error) {
+	var rrsets []dnsv1alpha2.GenericRRset
+	if _, ok := zone.(*dnsv1alpha2.ClusterZone); ok {
+		var list dnsv1alpha2.ClusterRRsetList
+		if err := cl.List(ctx, &list, client.HasLabels{TEMPLATE_LABEL}); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			rrsets = append(rrsets, &list.Items[i])
+		}
+	} else {
+		var list dnsv1alpha2.RRsetList
+		if err := cl.List(ctx, &list, client.InNamespace(zone.GetNamespace()), client.HasLabels{TEMPLATE_LABEL}); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			rrsets = append(rrsets, &list.Items[i])
+		}
+	}
+
+	result := make(map[string]dnsv1alpha2.GenericRRset)
+	for _, rrset := range rrsets {
+		if metav1.IsControlledBy(rrset, zone) {
+			result[rrset.GetName()] = rrset
+		}
+	}
+	return result, nil
+}
+
+// withTemplateDefaults returns a copy of the zone whose SOA fields and NS TTL not set in its spec
+// are taken from its ZoneTemplate. The zone is returned unchanged without template:
+// a missing template is reported by reconcileTemplate.
+func withTemplateDefaults(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) (dnsv1alpha2.GenericZone, error) {
+	name := templateName(zone)
+	if name == "" {
+		return zone, nil
+	}
+	template := &dnsv1alpha2.ZoneTemplate{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+		return zone, client.IgnoreNotFound(err)
+	}
+	result := zone.Copy()
+	applyTemplateDefaults(result.GetSpec(), template.Spec)
+	return result, nil
+}
+
+// applyTemplateDefaults sets, in the zone spec, the SOA fields and NS TTL of the template the spec does not set
+func applyTemplateDefaults(spec *dnsv1alpha2.ZoneSpec, template dnsv1alpha2.ZoneTemplateSpec) {
+	if spec.NameserversTTL == nil && template.NameserversTTL != nil {
+		spec.NameserversTTL = ptr.To(*template.NameserversTTL)
+	}
+	if template.SOA == nil {
+		return
+	}
+	if spec.SOA == nil {
+		spec.SOA = &dnsv1alpha2.SOA{}
+	}
+	soa, defaults := spec.SOA, template.SOA.DeepCopy()
+	if soa.Primary == nil {
+		soa.Primary = defaults.Primary
+	}
+	if soa.Contact == nil {
+		soa.Contact = defaults.Contact
+	}
+	if soa.Refresh == nil {
+		soa.Refresh = defaults.Refresh
+	}
+	if soa.Retry == nil {
+		soa.Retry = defaults.Retry
+	}
+	if soa.Expire == nil {
+		soa.Expire = defaults.Expire
+	}
+	if soa.Minimum == nil {
+		soa.Minimum = defaults.Minimum
+	}
+	if soa.TTL == nil {
+		soa.TTL = defaults.TTL
+	}
+}
+
+// reconcileTemplate creates and updates the RRsets/ClusterRRsets rendered from the ZoneTemplate of the zone,
+// and deletes the ones no longer rendered (entry removed from the template, templateRef removed or changed)
+func reconcileTemplate(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client, log logr.Logger) error {
+	existing, err := templateRRsets(ctx, zone, cl)
+	if err != nil {
+		return err
+	}
+
+	if name := templateName(zone); name != "" {
+		template := &dnsv1alpha2.ZoneTemplate{}
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("ZoneTemplate %s not found", name)
+			}
+			return err
+		}
+
+		for _, entry := range template.Spec.RRsets {
+			rrsetName := zone.GetName() + "-" + entry.ID
+			spec := renderTemplateRRset(zone, entry)
+			rrset, ok := existing[rrsetName]
+			delete(existing, rrsetName)
+
+			if !ok {
+				rrset = newTemplateRRset(zone, rrsetName)
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(rrset), rrset); err == nil {
+					return fmt.Errorf("%s already exists and is not rendered from ZoneTemplate %s", rrsetName, name)
+				} else if !errors.IsNotFound(err) {
+					return err
+				}
+				rrset.SetLabels(map[string]string{TEMPLATE_LABEL: name})
+				*rrset.GetSpec() = spec
+				if err := ctrl.SetControllerReference(zone, rrset, cl.Scheme()); err != nil {
+					return err
+				}
+				log.Info("Creating RRset from ZoneTemplate", "RRset.Name", rrsetName, "ZoneTemplate.Name", name)
+				if err := cl.Create(ctx, rrset); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if !rrset.GetDeletionTimestamp().IsZero() {
+				// The RRset is created again once deleted
+				continue
+			}
+			if rrset.GetSpec().Name != spec.Name {
+				// The name of a RRset is immutable, the RRset is deleted to be created again
+				log.Info("Deleting renamed RRset from ZoneTemplate", "RRset.Name", rrsetName, "ZoneTemplate.Name", name)
+				if err := cl.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+					return err
+				}
+				continue
+			}
+			if rrset.GetLabels()[TEMPLATE_LABEL] == name && equality.Semantic.DeepEqual(*rrset.GetSpec(), spec) {
+				continue
+			}
+			labels := rrset.GetLabels()
+			labels[TEMPLATE_LABEL] = name
+			rrset.SetLabels(labels)
+			*rrset.GetSpec() = spec
+			log.Info("Updating RRset from ZoneTemplate", "RRset.Name", rrsetName, "ZoneTemplate.Name", name)
+			if err := cl.Update(ctx, rrset); err != nil {
+				return err
+			}
+		}
+	}
+
+	for rrsetName, rrset := range existing {
+		log.Info("Deleting RRset no longer rendered from ZoneTemplate", "RRset.Name", rrsetName)
+		if err := cl.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
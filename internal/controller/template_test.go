@@ -0,0 +1,84 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestApplyTemplateDefaults(t *testing.T) {
+	template := dnsv1alpha2.ZoneTemplateSpec{
+		SOA: &dnsv1alpha2.SOA{
+			Contact: ptr.To("hostmaster@example.org"),
+			Refresh: ptr.To(uint32(3600)),
+		},
+		NameserversTTL: ptr.To(uint32(86400)),
+	}
+
+	var testCases = []struct {
+		name     string
+		spec     dnsv1alpha2.ZoneSpec
+		template dnsv1alpha2.ZoneTemplateSpec
+		want     dnsv1alpha2.ZoneSpec
+	}{
+		{
+			name:     "no defaults",
+			spec:     dnsv1alpha2.ZoneSpec{NameserversTTL: ptr.To(uint32(300))},
+			template: dnsv1alpha2.ZoneTemplateSpec{},
+			want:     dnsv1alpha2.ZoneSpec{NameserversTTL: ptr.To(uint32(300))},
+		},
+		{
+			name:     "unset zone fields",
+			spec:     dnsv1alpha2.ZoneSpec{},
+			template: template,
+			want: dnsv1alpha2.ZoneSpec{
+				SOA:            &dnsv1alpha2.SOA{Contact: ptr.To("hostmaster@example.org"), Refresh: ptr.To(uint32(3600))},
+				NameserversTTL: ptr.To(uint32(86400)),
+			},
+		},
+		{
+			name: "zone fields win",
+			spec: dnsv1alpha2.ZoneSpec{
+				SOA:            &dnsv1alpha2.SOA{Contact: ptr.To("admin@example.org"), Retry: ptr.To(uint32(600))},
+				NameserversTTL: ptr.To(uint32(300)),
+			},
+			template: template,
+			want: dnsv1alpha2.ZoneSpec{
+				SOA:            &dnsv1alpha2.SOA{Contact: ptr.To("admin@example.org"), Refresh: ptr.To(uint32(3600)), Retry: ptr.To(uint32(600))},
+				NameserversTTL: ptr.To(uint32(300)),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec.DeepCopy()
+			applyTemplateDefaults(spec, tc.template)
+			if !equality.Semantic.DeepEqual(*spec, tc.want) {
+				t.Errorf("got %+v, want %+v", *spec, tc.want)
+			}
+		})
+	}
+
+	// The template is not modified through the zone spec
+	spec := &dnsv1alpha2.ZoneSpec{}
+	applyTemplateDefaults(spec, template)
+	*spec.SOA.Refresh = 60
+	if *template.SOA.Refresh != 3600 {
+		t.Errorf("template modified through the zone spec")
+	}
+}
@@ -13,16 +13,24 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
 )
 
 const (
@@ -37,12 +45,25 @@ const (
 )
 
 const (
-	ZoneReasonSynced                  = "ZoneSynced"
-	ZoneMessageSyncSucceeded          = "Zone synced with PowerDNS instance"
-	ZoneReasonSynchronizationFailed   = "SynchronizationFailed"
-	ZoneReasonNSSynchronizationFailed = "NSSynchronizationFailed"
-	ZoneReasonDuplicated              = "ZoneDuplicated"
-	ZoneMessageDuplicated             = "Already existing Zone with the same FQDN"
+	ZoneReasonSynced                   = "ZoneSynced"
+	ZoneMessageSyncSucceeded           = "Zone synced with PowerDNS instance"
+	ZoneReasonSynchronizationFailed    = "SynchronizationFailed"
+	ZoneReasonNSSynchronizationFailed  = "NSSynchronizationFailed"
+	ZoneReasonSOASynchronizationFailed = "SOASynchronizationFailed"
+	ZoneReasonDuplicated               = dnsv1alpha2.ZoneReasonDuplicated
+	ZoneMessageDuplicated              = "Already existing Zone with the same FQDN, synchronized by "
+	ZoneReasonPolicyViolation          = "PolicyViolation"
+	ZoneReasonDelegationFailed         = "DelegationFailed"
+	ZoneReasonInvalidCatalog           = "InvalidCatalog"
+	ZoneReasonCatalogHasMembers        = "CatalogHasMembers"
+	ZoneMessageCatalogHasMembers       = "Catalog cannot be deleted while it has members: "
+	ZoneReasonTemplateFailed           = "TemplateFailed"
+	ZoneReasonForeignOwner             = dnsv1alpha2.ZoneReasonForeignOwner
+	ZoneReasonPruneFailed              = "PruneFailed"
+	ZoneReasonObserved                 = "ZoneObserved"
+	ZoneMessageObserved                = "Zone observed from PowerDNS instance"
+	ZoneReasonNotFound                 = "ZoneNotFound"
+	ZoneMessageNotFound                = "Observed zone not found in PowerDNS instance"
 )
 
 // ZoneReconciler reconciles a Zone object
@@ -60,6 +81,9 @@ func init() {
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones/finalizers,verbs=update
+//+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonetemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *ZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -102,12 +126,44 @@ func (r *ZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
+	// ZonePolicies are checked again: the webhook may be disabled,
+	// or policies may have changed since the Zone was admitted
+	if !isDeleted {
+		policies, err := policy.ForNamespace(ctx, r.Client, zone.Namespace)
+		if err != nil {
+			log.Error(err, "Failed to get ZonePolicies")
+			return ctrl.Result{}, err
+		}
+		if err := policy.CheckZone(policies, zone.Name); err != nil {
+			original = zone.DeepCopy()
+			zone.Status.SyncStatus = ptr.To(FAILED_STATUS)
+			zone.Status.ObservedGeneration = &zone.Generation
+			meta.SetStatusCondition(&zone.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+				Reason:             ZoneReasonPolicyViolation,
+				Message:            err.Error(),
+			})
+			if err := r.Status().Patch(ctx, zone, client.MergeFrom(original)); err != nil {
+				log.Error(err, "unable to patch Zone status")
+				return ctrl.Result{}, err
+			}
+			updateZonesMetrics(zone)
+			return ctrl.Result{}, nil
+		}
+		// A Zone previously denied by a policy must be synchronized again
+		if condition := meta.FindStatusCondition(zone.Status.Conditions, "Available"); condition != nil && condition.Reason == ZoneReasonPolicyViolation {
+			isModified = true
+		}
+	}
+
 	return zoneReconcile(ctx, zone, isModified, isDeleted, r.Client, r.PDNSClient, log)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one Zone/ClusterZone exists for one DNS entry
+	// Synchronized Zones are indexed by DNS entry, to find the parent, catalog or duplicates of a zone
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.Entry.Name", func(rawObj client.Object) []string {
 		// grab the Zone object, extract its name...
 		var ZoneName string
@@ -118,9 +174,137 @@ func (r *ZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	// All the Zones are indexed by DNS entry, to elect the one synchronized among duplicates
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.Entry.Claim", zoneClaimIndexer); err != nil {
+		return err
+	}
+	// Members are indexed by catalog, to maintain the members of a catalog in its status
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.Catalog", catalogIndexer); err != nil {
+		return err
+	}
+	// Zones are indexed by their parent domains, to move their delegation when a parent zone changes
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.Parents", parentZonesIndexer); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.Zone{}).
 		Owns(&dnsv1alpha2.ClusterRRset{}).
 		Owns(&dnsv1alpha2.RRset{}).
+		Watches(&dnsv1alpha2.ZonePolicy{}, handler.EnqueueRequestsFromMapFunc(r.findZonesForPolicy), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&dnsv1alpha2.RRset{}, handler.EnqueueRequestsFromMapFunc(r.findZoneForCrossNamespaceRRset)).
+		// The related zones only depend on the spec and the synchronization status of a Zone/ClusterZone
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findChildZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findChildZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogMembers), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findCatalogMembers), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ZoneTemplate{}, handler.EnqueueRequestsFromMapFunc(r.findZonesForTemplate)).
+		Watches(&dnsv1alpha2.Zone{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedZones), builder.WithPredicates(zoneChangedPredicate)).
+		Watches(&dnsv1alpha2.ClusterZone{}, handler.EnqueueRequestsFromMapFunc(r.findDuplicatedZones), builder.WithPredicates(zoneChangedPredicate)).
 		Complete(r)
 }
+
+// findZonesForTemplate enqueues the Zones using a ZoneTemplate, to apply its defaults and render its RRsets again
+func (r *ZoneReconciler) findZonesForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ZoneList
+	if err := r.List(ctx, &zones); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Zones")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, z := range zones.Items {
+		if templateName(&z) == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+		}
+	}
+	return requests
+}
+
+// findCatalogMembers enqueues the Zones which are members of a Zone/ClusterZone,
+// they may be waiting for this catalog to be available
+func (r *ZoneReconciler) findCatalogMembers(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"Zone.Catalog": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Zones")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(zones.Items))
+	for _, z := range zones.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+	}
+	return requests
+}
+
+// findCatalogZones enqueues the Zones named after the catalog of a Zone/ClusterZone, their members may have changed
+func (r *ZoneReconciler) findCatalogZones(ctx context.Context, obj client.Object) []reconcile.Request {
+	zone, ok := obj.(dnsv1alpha2.GenericZone)
+	if !ok || catalogName(zone) == "" {
+		return nil
+	}
+	var zones dnsv1alpha2.ZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Name": catalogName(zone)}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Zones")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(zones.Items))
+	for _, z := range zones.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+	}
+	return requests
+}
+
+// findChildZones enqueues the Zones which are subdomains of a Zone/ClusterZone,
+// their delegation may have to be moved to, or from, this parent zone
+func (r *ZoneReconciler) findChildZones(ctx context.Context, obj client.Object) []reconcile.Request {
+	var zones dnsv1alpha2.ZoneList
+	if err := r.List(ctx, &zones, client.MatchingFields{"Zone.Parents": obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Zones")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(zones.Items))
+	for _, z := range zones.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+	}
+	return requests
+}
+
+// findZoneForCrossNamespaceRRset enqueues the Zone referenced by a RRset from another namespace,
+// such RRsets cannot be owned by the Zone
+func (r *ZoneReconciler) findZoneForCrossNamespaceRRset(_ context.Context, obj client.Object) []reconcile.Request {
+	rrset, ok := obj.(*dnsv1alpha2.RRset)
+	if !ok {
+		return nil
+	}
+	namespace := zoneRefNamespace(rrset)
+	if namespace == rrset.Namespace {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: namespace, Name: rrset.Spec.ZoneRef.Name}}}
+}
+
+// findZonesForPolicy enqueues the Zones of the namespaces selected by a modified ZonePolicy,
+// the map function is called with both the old and the new policy on update
+func (r *ZoneReconciler) findZonesForPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	zonePolicy, ok := obj.(*dnsv1alpha2.ZonePolicy)
+	if !ok {
+		return nil
+	}
+	namespaces, err := policy.SelectedNamespaces(ctx, r.Client, zonePolicy)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list the namespaces of the ZonePolicy")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, namespace := range namespaces {
+		var zones dnsv1alpha2.ZoneList
+		if err := r.List(ctx, &zones, client.InNamespace(namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list Zones")
+			return nil
+		}
+		for _, z := range zones.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&z)})
+		}
+	}
+	return requests
+}
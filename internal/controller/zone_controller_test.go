@@ -15,15 +15,18 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/joeig/go-powerdns/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
@@ -47,8 +50,34 @@ var _ = Describe("Zone Controller", func() {
 		Namespace: resourceNamespace,
 	}
 
+	catalogNamespacedName := types.NamespacedName{
+		Name:      strings.TrimSuffix(resourceCatalog, "."),
+		Namespace: resourceNamespace,
+	}
+
 	BeforeEach(func() {
 		ctx := context.Background()
+		By("creating the catalog Zone resource")
+		catalog := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      catalogNamespacedName.Name,
+				Namespace: catalogNamespacedName.Namespace,
+			},
+		}
+		catalog.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, catalog, func() error {
+			catalog.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        PRODUCER_KIND_ZONE,
+				Nameservers: resourceNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, catalogNamespacedName, catalog)
+			return err == nil && catalog.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+
 		By("creating the Zone resource")
 		resource := &dnsv1alpha2.Zone{
 			ObjectMeta: metav1.ObjectMeta{
@@ -57,7 +86,7 @@ var _ = Describe("Zone Controller", func() {
 			},
 		}
 		resource.SetResourceVersion("")
-		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
+		_, err = controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
 			resource.Spec = dnsv1alpha2.ZoneSpec{
 				Kind:        resourceKind,
 				Nameservers: resourceNameservers,
@@ -100,6 +129,15 @@ var _ = Describe("Zone Controller", func() {
 			_, found := readFromZonesMap(makeCanonical(resourceName))
 			return found
 		}, timeout, interval).Should(BeFalse())
+
+		By("Cleanup the catalog Zone")
+		catalog := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, catalogNamespacedName, catalog)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, catalog)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, catalogNamespacedName, catalog)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
 	})
 
 	Context("When existing resource", func() {
@@ -118,7 +156,8 @@ var _ = Describe("Zone Controller", func() {
 			Expect(getMockedNameservers(resourceName)).To(Equal(resourceNameservers), "Nameservers should be equal")
 			Expect(getMockedCatalog(resourceName)).To(Equal(resourceCatalog), "Catalog should be equal")
 			Expect(zone.GetFinalizers()).To(ContainElement(RESOURCES_FINALIZER_NAME), "Zone should contain the finalizer")
-			Expect(fmt.Sprintf("%d", *(zone.Status.Serial))).To(Equal(fmt.Sprintf("%s01", time.Now().UTC().Format("20060102"))), "Serial should be YYYYMMDD01")
+			// The serial is incremented by the delegation of the catalog zone, a subdomain of the zone
+			Expect(fmt.Sprintf("%d", *(zone.Status.Serial))).To(HavePrefix(time.Now().UTC().Format("20060102")), "Serial should be YYYYMMDDnn")
 		})
 	})
 
@@ -217,6 +256,27 @@ var _ = Describe("Zone Controller", func() {
 			// b. from a specific catalog to an empty catalog
 			var modifiedResourceCatalog = []string{"", "catalog.other-domain.org.", ""}
 
+			By("Creating the other catalog")
+			otherCatalog := &dnsv1alpha2.ClusterZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "catalog.other-domain.org",
+				},
+				Spec: dnsv1alpha2.ClusterZoneSpec{
+					ZoneSpec: dnsv1alpha2.ZoneSpec{
+						Kind:        PRODUCER_KIND_ZONE,
+						Nameservers: resourceNameservers,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, otherCatalog)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, otherCatalog)).To(Succeed())
+			})
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(otherCatalog), otherCatalog)
+				return err == nil && otherCatalog.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+
 			By("Getting the initial Serial of the resource")
 			zone := &dnsv1alpha2.Zone{}
 			Eventually(func() bool {
@@ -465,6 +525,7 @@ var _ = Describe("Zone Controller", func() {
 			recreationResourceKind := NATIVE_KIND_ZONE
 			recreationResourceCatalog := "catalog.example1.org."
 			recreationResourceNameservers := []string{"ns1.example1.org", "ns2.example1.org"}
+			waitUntilOlderThanNow(ctx, types.NamespacedName{Name: resourceName, Namespace: resourceNamespace}, &dnsv1alpha2.Zone{})
 
 			By("Creating a Zone")
 			resource := &dnsv1alpha2.Zone{
@@ -495,6 +556,19 @@ var _ = Describe("Zone Controller", func() {
 				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
 				return err == nil && updatedZone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
 			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(updatedZone.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(ZoneReasonDuplicated))
+			Expect(condition.Message).To(Equal(ZoneMessageDuplicated+"Zone "+resourceNamespace+"/"+resourceName), "the message should name the synchronized Zone")
+
+			By("Deleting the duplicated Zone")
+			Expect(k8sClient.Delete(ctx, updatedZone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromZonesMap(makeCanonical(recreationResourceName))
+			Expect(found).To(BeTrue(), "the zone synchronized by the existing Zone should not be deleted")
 		})
 	})
 	Context("When creating a ClusterZone with an existing Zone with same FQDN", func() {
@@ -505,6 +579,7 @@ var _ = Describe("Zone Controller", func() {
 			recreationResourceKind := NATIVE_KIND_ZONE
 			recreationResourceCatalog := "catalog.example1.org."
 			recreationResourceNameservers := []string{"ns1.example1.org", "ns2.example1.org"}
+			waitUntilOlderThanNow(ctx, types.NamespacedName{Name: resourceName, Namespace: resourceNamespace}, &dnsv1alpha2.Zone{})
 
 			By("Creating a Zone")
 			resource := &dnsv1alpha2.ClusterZone{
@@ -514,7 +589,7 @@ var _ = Describe("Zone Controller", func() {
 			}
 			resource.SetResourceVersion("")
 			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
-				resource.Spec = dnsv1alpha2.ZoneSpec{
+				resource.Spec.ZoneSpec = dnsv1alpha2.ZoneSpec{
 					Kind:        recreationResourceKind,
 					Nameservers: recreationResourceNameservers,
 					Catalog:     ptr.To(recreationResourceCatalog),
@@ -533,6 +608,491 @@ var _ = Describe("Zone Controller", func() {
 				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
 				return err == nil && updatedZone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
 			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(updatedZone.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(ZoneReasonDuplicated))
+			Expect(condition.Message).To(Equal(ZoneMessageDuplicated+"Zone "+resourceNamespace+"/"+resourceName), "the message should name the synchronized Zone")
+
+			By("Deleting the duplicated ClusterZone")
+			Expect(k8sClient.Delete(ctx, updatedZone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, updatedZone)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromZonesMap(makeCanonical(recreationResourceName))
+			Expect(found).To(BeTrue(), "the zone synchronized by the existing Zone should not be deleted")
+		})
+	})
+	Context("When deleting a Zone with a duplicated Zone", func() {
+		It("should synchronize the oldest duplicated Zone", Label("zone-deletion", "duplicated-zone"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			duplicatedResourceName := "example20.org"
+			firstResourceNamespace := resourceNamespace
+			secondResourceNamespace := "example3"
+			duplicatedResourceNameservers := []string{"ns1.example20.org", "ns2.example20.org"}
+
+			By("Creating the first Zone")
+			first := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      duplicatedResourceName,
+					Namespace: firstResourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: duplicatedResourceNameservers,
+				},
+			}
+			Expect(k8sClient.Create(ctx, first)).To(Succeed())
+			firstNamespacedName := types.NamespacedName{
+				Name:      duplicatedResourceName,
+				Namespace: firstResourceNamespace,
+			}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, firstNamespacedName, first)
+				return err == nil && first.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			waitUntilOlderThanNow(ctx, firstNamespacedName, first)
+
+			By("Creating the second Zone")
+			second := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      duplicatedResourceName,
+					Namespace: secondResourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: duplicatedResourceNameservers,
+				},
+			}
+			Expect(k8sClient.Create(ctx, second)).To(Succeed())
+			secondNamespacedName := types.NamespacedName{
+				Name:      duplicatedResourceName,
+				Namespace: secondResourceNamespace,
+			}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, secondNamespacedName, second)
+				return err == nil && second.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			condition := meta.FindStatusCondition(second.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal(ZoneMessageDuplicated + "Zone " + firstResourceNamespace + "/" + duplicatedResourceName))
+
+			By("Deleting the first Zone")
+			Expect(k8sClient.Delete(ctx, first)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, firstNamespacedName, first)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			By("Checking the second Zone is synchronized")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, secondNamespacedName, second)
+				return err == nil && second.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Eventually(func() bool {
+				_, found := readFromZonesMap(makeCanonical(duplicatedResourceName))
+				return found
+			}, timeout, interval).Should(BeTrue())
+
+			By("Deleting the second Zone")
+			Expect(k8sClient.Delete(ctx, second)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, secondNamespacedName, second)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			_, found := readFromZonesMap(makeCanonical(duplicatedResourceName))
+			Expect(found).To(BeFalse(), "the zone should be deleted")
+		})
+	})
+	Context("When a ZonePolicy does not allow an existing Zone", func() {
+		It("should reconcile the resource with Failed status until the policy is removed", Label("zone-policy"), func() {
+			ctx := context.Background()
+
+			By("Creating a ZonePolicy applying to the namespace")
+			zonePolicy := &dnsv1alpha2.ZonePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "example1-policy",
+				},
+				Spec: dnsv1alpha2.ZonePolicySpec{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": resourceNamespace},
+					},
+					AllowedZoneSuffixes: []string{"allowed.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zonePolicy)).To(Succeed())
+
+			By("Getting the Zone denied by the policy")
+			zone := &dnsv1alpha2.Zone{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(zone.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonPolicyViolation))
+			_, found := readFromZonesMap(makeCanonical(resourceName))
+			Expect(found).To(BeTrue(), "Zone should not be deleted from the backend")
+
+			By("Removing the ZonePolicy")
+			Expect(k8sClient.Delete(ctx, zonePolicy)).To(Succeed())
+
+			By("Getting the Zone synchronized again")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When an existing Zone references a ZoneTemplate", func() {
+		It("should render the RRsets of the template and keep them in sync", Label("zone-template"), func() {
+			ctx := context.Background()
+			mxName := types.NamespacedName{Name: resourceName + "-mx", Namespace: resourceNamespace}
+			dmarcName := types.NamespacedName{Name: resourceName + "-dmarc", Namespace: resourceNamespace}
+
+			By("Referencing a non-existing ZoneTemplate")
+			zone := &dnsv1alpha2.Zone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			zone.Spec.TemplateRef = &dnsv1alpha2.TemplateRef{Name: "example1-template"}
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(zone.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonTemplateFailed))
+
+			By("Creating the ZoneTemplate")
+			template := &dnsv1alpha2.ZoneTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "example1-template",
+				},
+				Spec: dnsv1alpha2.ZoneTemplateSpec{
+					RRsets: []dnsv1alpha2.ZoneTemplateRRset{
+						{ID: "mx", Type: "MX", Name: "mail", TTL: 3600, Records: []string{"10 mx1.{zone}."}},
+						{ID: "dmarc", Type: "TXT", Name: "_dmarc", TTL: 3600, Records: []string{"\"v=DMARC1; p=reject; rua=mailto:dmarc@{zone}\""}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, template)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, template)).To(Succeed())
+			})
+
+			By("Getting the rendered RRsets")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			mx := &dnsv1alpha2.RRset{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, mxName, mx)
+			}, timeout, interval).Should(Succeed())
+			Expect(mx.Spec.Records).To(Equal([]string{"10 mx1.example1.org."}))
+			Expect(mx.Spec.ZoneRef).To(Equal(dnsv1alpha2.ZoneRef{Name: resourceName, Kind: "Zone"}))
+			Expect(mx.Labels).To(HaveKeyWithValue(TEMPLATE_LABEL, "example1-template"))
+			Expect(metav1.IsControlledBy(mx, zone)).To(BeTrue())
+			Eventually(func() []string {
+				return getMockedRecordsForType("_dmarc."+resourceName, "TXT")
+			}, timeout, interval).Should(Equal([]string{"\"v=DMARC1; p=reject; rua=mailto:dmarc@example1.org\""}))
+
+			By("Modifying a rendered RRset")
+			mx.Spec.TTL = 60
+			Expect(k8sClient.Update(ctx, mx)).To(Succeed())
+			Eventually(func() uint32 {
+				_ = k8sClient.Get(ctx, mxName, mx)
+				return mx.Spec.TTL
+			}, timeout, interval).Should(Equal(uint32(3600)), "Rendered RRset should be reverted to the template")
+
+			By("Modifying the ZoneTemplate")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(template), template)).To(Succeed())
+			template.Spec.RRsets = []dnsv1alpha2.ZoneTemplateRRset{
+				{ID: "mx", Type: "MX", Name: "mail", TTL: 3600, Records: []string{"10 mx1.{zone}.", "20 mx2.{zone}."}},
+			}
+			Expect(k8sClient.Update(ctx, template)).To(Succeed())
+			Eventually(func() []string {
+				_ = k8sClient.Get(ctx, mxName, mx)
+				return mx.Spec.Records
+			}, timeout, interval).Should(Equal([]string{"10 mx1.example1.org.", "20 mx2.example1.org."}))
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, dmarcName, &dnsv1alpha2.RRset{}))
+			}, timeout, interval).Should(BeTrue())
+
+			By("Removing the templateRef")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			zone.Spec.TemplateRef = nil
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, mxName, &dnsv1alpha2.RRset{}))
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When existing resource", func() {
+		It("should successfully modify the SOA record of the zone", Label("zone-modification", "soa"), func() {
+			ctx := context.Background()
+
+			By("Getting the initial SOA record of the resource")
+			zone := &dnsv1alpha2.Zone{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.Status.SOA != nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(zone.Status.SOA.Contact).To(Equal("hostmaster." + makeCanonical(resourceName)))
+			Expect(zone.Status.SOA.Refresh).To(Equal(uint32(10800)))
+
+			By("Modifying the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			zone.Spec.SOA = &dnsv1alpha2.SOA{
+				Primary: ptr.To("ns1.example1.org"),
+				Contact: ptr.To("dns.admin@example1.org"),
+				Refresh: ptr.To(uint32(7200)),
+				TTL:     ptr.To(uint32(600)),
+			}
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+
+			By("Getting the modified resource")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(*zone.Status.SOA).To(Equal(dnsv1alpha2.SOAStatus{
+				Primary: "ns1.example1.org.",
+				Contact: "dns\\.admin.example1.org.",
+				Refresh: 7200,
+				Retry:   3600,
+				Expire:  604800,
+				Minimum: 3600,
+				TTL:     600,
+			}))
+			soa, found := readFromRecordsMap(recordsMapKey(resourceName, powerdns.RRTypeSOA))
+			Expect(found).To(BeTrue())
+			Expect(*soa.Records[0].Content).To(Equal(fmt.Sprintf("ns1.example1.org. dns\\.admin.example1.org. %d 7200 3600 604800 3600", *zone.Status.Serial)))
+
+			By("Checking the serial is not modified by further reconciliations")
+			serial := *zone.Status.Serial
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			zone.Annotations = map[string]string{"reconcile": "again"}
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Consistently(func() uint32 {
+				_ = k8sClient.Get(ctx, typeNamespacedName, zone)
+				return ptr.Deref(zone.Status.Serial, 0)
+			}, 2*time.Second, interval).Should(Equal(serial))
+		})
+	})
+
+	Context("When existing resource", func() {
+		It("should successfully modify the TTL of the nameservers, ignoring their order", Label("zone-modification", "nameservers-ttl"), func() {
+			ctx := context.Background()
+
+			By("Modifying the TTL of the nameservers")
+			zone := &dnsv1alpha2.Zone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			zone.Spec.NameserversTTL = ptr.To(uint32(3600))
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			ns, found := readFromRecordsMap(makeCanonical(resourceName))
+			Expect(found).To(BeTrue())
+			Expect(*ns.TTL).To(Equal(uint32(3600)))
+
+			By("Reordering the nameservers")
+			serial := *zone.Status.Serial
+			zone.Spec.Nameservers = []string{resourceNameservers[1], resourceNameservers[0]}
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION+1, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(*zone.Status.Serial).To(Equal(serial), "NS records should not be rewritten")
+		})
+	})
+
+	Context("When existing resource", func() {
+		It("should successfully switch the zone to the Authoritative records policy", Label("zone-modification", "records-policy"), func() {
+			ctx := context.Background()
+
+			By("Modifying the records policy of the resource")
+			zone := &dnsv1alpha2.Zone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, zone)).To(Succeed())
+			Expect(ptr.Deref(zone.Spec.RecordsPolicy, "")).To(Equal(ADDITIVE_RECORDS_POLICY))
+			zone.Spec.RecordsPolicy = ptr.To(AUTHORITATIVE_RECORDS_POLICY)
+			zone.Spec.Prune = &dnsv1alpha2.Prune{ReportOnly: true}
+			Expect(k8sClient.Update(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, zone)
+				return err == nil && zone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(zone.Status.UnmanagedRRsets).To(BeEmpty())
+
+			By("Refusing the Authoritative records policy on a Slave zone")
+			zone.Spec.Kind = SLAVE_KIND_ZONE
+			Expect(k8sClient.Update(ctx, zone)).NotTo(Succeed())
+		})
+	})
+
+	Context("When creating a Zone with the Observe management policy", func() {
+		It("should report the live state of the zone and its RRsets without writing to PowerDNS", Label("zone-creation", "observe"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			observedResourceName := "observed.org"
+			observedRecordName := "www.observed.org"
+			observedRecord := "192.0.2.1"
+			missingResourceName := "missing-observed.org"
+			observedLookupKey := types.NamespacedName{Name: observedResourceName, Namespace: resourceNamespace}
+			missingLookupKey := types.NamespacedName{Name: missingResourceName, Namespace: resourceNamespace}
+			rrsetLookupKey := types.NamespacedName{Name: observedRecordName, Namespace: resourceNamespace}
+			newZone := func(name string) *dnsv1alpha2.Zone {
+				return &dnsv1alpha2.Zone{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: resourceNamespace},
+					Spec: dnsv1alpha2.ZoneSpec{
+						Kind:             NATIVE_KIND_ZONE,
+						Nameservers:      []string{"ns1." + name},
+						ManagementPolicy: ptr.To(OBSERVE_MANAGEMENT_POLICY),
+					},
+				}
+			}
+
+			By("Creating a Zone directly in the mock, as managed by another tool")
+			now := time.Now().UTC()
+			initialSerial := uint32(now.Year())*1000000 + uint32((now.Month()))*10000 + uint32(now.Day())*100 + 1
+			writeToZonesMap(makeCanonical(observedResourceName), &powerdns.Zone{
+				Name:       ptr.To(makeCanonical(observedResourceName)),
+				Kind:       powerdns.ZoneKindPtr(powerdns.ZoneKind(MASTER_KIND_ZONE)),
+				Serial:     &initialSerial,
+				SOAEditAPI: ptr.To("DEFAULT"),
+			})
+			soaContent := fmt.Sprintf("ns1.observed.org. hostmaster.observed.org. %d 10800 3600 604800 3600", initialSerial)
+			writeToRecordsMap(recordsMapKey(observedResourceName, powerdns.RRTypeSOA), &powerdns.RRset{
+				Name:    ptr.To(makeCanonical(observedResourceName)),
+				Type:    ptr.To(powerdns.RRTypeSOA),
+				TTL:     ptr.To(uint32(3600)),
+				Records: []powerdns.Record{{Content: &soaContent}},
+			})
+			writeToRecordsMap(makeCanonical(observedRecordName), &powerdns.RRset{
+				Name:    ptr.To(makeCanonical(observedRecordName)),
+				Type:    ptr.To(powerdns.RRTypeA),
+				TTL:     ptr.To(uint32(300)),
+				Records: []powerdns.Record{{Content: &observedRecord}},
+			})
+
+			By("Creating the observed Zones")
+			Expect(k8sClient.Create(ctx, newZone(observedResourceName))).To(Succeed())
+			Expect(k8sClient.Create(ctx, newZone(missingResourceName))).To(Succeed())
+			zone := &dnsv1alpha2.Zone{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, observedLookupKey, zone)
+				return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(zone.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonObserved))
+			Expect(*zone.Status.Kind).To(Equal(MASTER_KIND_ZONE))
+			Expect(*zone.Status.Serial).To(Equal(initialSerial))
+			Expect(zone.Status.SOA.Primary).To(Equal("ns1.observed.org."))
+			Expect(zone.Status.RecordsCount).NotTo(BeNil())
+			missing := &dnsv1alpha2.Zone{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, missingLookupKey, missing)
+				return err == nil && missing.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(missing.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonNotFound))
+			_, found := readFromZonesMap(makeCanonical(missingResourceName))
+			Expect(found).To(BeFalse(), "The missing zone should not be created")
+
+			By("Creating a RRset referencing the observed Zone")
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Name: observedRecordName, Namespace: resourceNamespace},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: observedResourceName, Kind: "Zone"},
+					Type:    "A",
+					Name:    "www",
+					TTL:     600,
+					Records: []string{"192.0.2.2"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rrset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetLookupKey, rrset)
+				return err == nil && rrset.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(rrset.Status.Conditions, "Available").Reason).To(Equal(RrsetReasonObserved))
+			Expect(rrset.Status.ObservedRecords).To(Equal([]string{observedRecord}))
+			Expect(getMockedRecordsForType(observedRecordName, "A")).To(Equal([]string{observedRecord}), "The record should not be modified")
+
+			By("Deleting the RRset and the Zones")
+			Expect(k8sClient.Delete(ctx, rrset)).To(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, rrsetLookupKey, rrset))
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedRecordsForType(observedRecordName, "A")).To(Equal([]string{observedRecord}), "The record should not be deleted")
+			Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, missing)).To(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, observedLookupKey, zone)) && errors.IsNotFound(k8sClient.Get(ctx, missingLookupKey, missing))
+			}, timeout, interval).Should(BeTrue())
+			_, found = readFromZonesMap(makeCanonical(observedResourceName))
+			Expect(found).To(BeTrue(), "The zone should not be deleted")
+
+			deleteFromZonesMap(makeCanonical(observedResourceName))
+			deleteFromRecordsMap(makeCanonical(observedRecordName))
+			deleteFromRecordsMap(recordsMapKey(observedResourceName, powerdns.RRTypeSOA))
+		})
+	})
+
+	Context("When creating a Zone owned by another operator instance", func() {
+		It("should reconcile the resource with Failed status and leave the zone untouched", Label("zone-creation", "foreign-owner"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			foreignResourceName := "foreign-owner.org"
+			foreignResourceKind := NATIVE_KIND_ZONE
+			foreignResourceNameservers := []string{"ns1.foreign-owner.org", "ns2.foreign-owner.org"}
+			foreignAccount := operatorAccount("other-cluster")
+
+			By("Creating a Zone directly in the mock, as created by another operator instance")
+			now := time.Now().UTC()
+			initialSerial := uint32(now.Year())*1000000 + uint32((now.Month()))*10000 + uint32(now.Day())*100 + 1
+			writeToZonesMap(makeCanonical(foreignResourceName), &powerdns.Zone{
+				Name:       ptr.To(makeCanonical(foreignResourceName)),
+				Kind:       powerdns.ZoneKindPtr(powerdns.ZoneKind(MASTER_KIND_ZONE)),
+				Serial:     &initialSerial,
+				SOAEditAPI: ptr.To("DEFAULT"),
+				Account:    &foreignAccount,
+			})
+
+			By("Creating the Zone")
+			resource := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      foreignResourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        foreignResourceKind,
+					Nameservers: foreignResourceNameservers,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			foreignLookupKey := types.NamespacedName{
+				Name:      foreignResourceName,
+				Namespace: resourceNamespace,
+			}
+			zone := &dnsv1alpha2.Zone{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, foreignLookupKey, zone)
+				return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(zone.Status.Conditions, "Available").Reason).To(Equal(ZoneReasonForeignOwner))
+			Expect(getMockedKind(foreignResourceName)).To(Equal(MASTER_KIND_ZONE), "The zone should not be modified")
+
+			By("Deleting the Zone")
+			Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, foreignLookupKey, zone)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			externalZone, found := readFromZonesMap(makeCanonical(foreignResourceName))
+			Expect(found).To(BeTrue(), "The zone should not be deleted")
+			Expect(ptr.Deref(externalZone.Account, "")).To(Equal(foreignAccount))
+			deleteFromZonesMap(makeCanonical(foreignResourceName))
 		})
 	})
 })
@@ -0,0 +1,249 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZoneActionNotify       = "Notify"
+	ZoneActionRectify      = "Rectify"
+	ZoneActionAxfrRetrieve = "AxfrRetrieve"
+	ZoneActionFlushCache   = "FlushCache"
+)
+
+const (
+	ZoneActionReasonSucceeded        = "ActionSucceeded"
+	ZoneActionReasonFailed           = "ActionFailed"
+	ZoneActionReasonRunning          = "ActionRunning"
+	ZoneActionReasonInterrupted      = "ActionInterrupted"
+	ZoneActionReasonZoneNotAvailable = "ZoneNotAvailable"
+	ZoneActionReasonForbidden        = "Forbidden"
+)
+
+const (
+	RUNNING_STATUS = "Running"
+
+	// ZoneActionPendingTimeout is the time a ZoneAction waits for its zone before failing
+	ZoneActionPendingTimeout = 10 * time.Minute
+)
+
+// ZoneActionReconciler reconciles a ZoneAction object
+type ZoneActionReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	PDNSClient PdnsClienter
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zoneactions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zoneactions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonereferencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+func (r *ZoneActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile ZoneAction", "ZoneAction.Name", req.Name)
+
+	action := &dnsv1alpha2.ZoneAction{}
+	if err := r.Get(ctx, req.NamespacedName, action); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !action.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// The action is run once, a finished ZoneAction is only deleted after its TTL
+	if action.Status.CompletionTime != nil {
+		return r.cleanup(ctx, action)
+	}
+	// The outcome of an action claimed but not recorded (e.g. restart of the operator, failure of the status patch)
+	// is unknown, it is not run again. The cache may be stale, the outcome recorded meanwhile is not overwritten
+	if ptr.Deref(action.Status.SyncStatus, "") == RUNNING_STATUS {
+		result, err := r.complete(ctx, action, FAILED_STATUS, ZoneActionReasonInterrupted, nil, "the action was interrupted, its outcome is unknown", client.MergeFromWithOptimisticLock{})
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return result, err
+	}
+
+	// Zone
+	namespace := action.Namespace
+	var zone dnsv1alpha2.GenericZone
+	switch action.Spec.ZoneRef.Kind {
+	case "Zone":
+		zone = &dnsv1alpha2.Zone{}
+		namespace = ptr.Deref(action.Spec.ZoneRef.Namespace, action.Namespace)
+	case "ClusterZone":
+		zone = &dnsv1alpha2.ClusterZone{}
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: action.Spec.ZoneRef.Name}, zone); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to get zone")
+			return ctrl.Result{}, err
+		}
+		// The zone may be created along with the action, requeue after few seconds
+		return r.pending(ctx, action, fmt.Sprintf("non-existent zone: %s", action.Spec.ZoneRef.Name))
+	}
+	if ptr.Deref(zone.GetStatus().SyncStatus, "") != SUCCEEDED_STATUS {
+		return r.pending(ctx, action, fmt.Sprintf("unavailable zone: %s", zone.GetName()))
+	}
+
+	// A ZoneAction may only target the zones a RRset of its namespace could reference
+	denial, err := zoneAccessDenial(ctx, r.Client, &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Namespace: action.Namespace},
+		Spec: dnsv1alpha2.RRsetSpec{
+			Type:    "SOA",
+			Name:    makeCanonical(zone.GetName()),
+			ZoneRef: action.Spec.ZoneRef,
+		},
+	}, zone)
+	if err != nil {
+		log.Error(err, "Failed to check access to the zone")
+		return ctrl.Result{}, err
+	}
+	if denial != "" {
+		return r.complete(ctx, action, FAILED_STATUS, ZoneActionReasonForbidden, nil, denial)
+	}
+	// Nothing is run on an observed zone
+	if isObserved(zone) {
+		return r.complete(ctx, action, FAILED_STATUS, ZoneActionReasonForbidden, nil, fmt.Sprintf("zone %s is observed", zone.GetName()))
+	}
+
+	// The action is claimed before it is run, a concurrent or stale reconciliation fails to claim it
+	if err := r.claim(ctx, action, zone.GetName()); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch ZoneAction status")
+		return ctrl.Result{}, err
+	}
+	result, err := runZoneAction(ctx, r.PDNSClient, action.Spec.Action, zone.GetName())
+	if err != nil {
+		log.Error(err, "Failed to run zone action", "Action", action.Spec.Action)
+		return r.complete(ctx, action, FAILED_STATUS, ZoneActionReasonFailed, ptr.To(err.Error()), err.Error())
+	}
+	log.Info("Zone action run", "Action", action.Spec.Action, "Result", result)
+	return r.complete(ctx, action, SUCCEEDED_STATUS, ZoneActionReasonSucceeded, &result, fmt.Sprintf("%s run on zone %s", action.Spec.Action, zone.GetName()))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZoneActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.ZoneAction{}).
+		Complete(r)
+}
+
+// runZoneAction runs the action on the zone and returns the result message of the PowerDNS API
+func runZoneAction(ctx context.Context, PDNSClient PdnsClienter, action, domain string) (string, error) {
+	switch action {
+	case ZoneActionNotify:
+		return PDNSClient.Actions.Notify(ctx, domain)
+	case ZoneActionRectify:
+		return PDNSClient.Actions.Rectify(ctx, domain)
+	case ZoneActionAxfrRetrieve:
+		return PDNSClient.Actions.AxfrRetrieve(ctx, domain)
+	case ZoneActionFlushCache:
+		return PDNSClient.Actions.FlushCache(ctx, domain)
+	}
+	return "", fmt.Errorf("unknown action %s", action)
+}
+
+// pending sets the ZoneAction in Pending status until its zone is available, and in Failed status once ZoneActionPendingTimeout is expired
+func (r *ZoneActionReconciler) pending(ctx context.Context, action *dnsv1alpha2.ZoneAction, message string) (ctrl.Result, error) {
+	age := time.Since(action.CreationTimestamp.Time)
+	if age >= ZoneActionPendingTimeout {
+		return r.complete(ctx, action, FAILED_STATUS, ZoneActionReasonZoneNotAvailable, nil, fmt.Sprintf("%s after %s", message, ZoneActionPendingTimeout))
+	}
+	original := action.DeepCopy()
+	action.Status.SyncStatus = ptr.To(PENDING_STATUS)
+	action.Status.ObservedGeneration = &action.Generation
+	meta.SetStatusCondition(&action.Status.Conditions, metav1.Condition{
+		Type:               "Complete",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             ZoneActionReasonZoneNotAvailable,
+		Message:            message,
+	})
+	if err := r.Status().Patch(ctx, action, client.MergeFrom(original)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to patch ZoneAction status")
+		return ctrl.Result{}, err
+	}
+	// The zone is checked again with a delay growing with the age of the ZoneAction
+	return ctrl.Result{RequeueAfter: min(max(age/2, 2*time.Second), ZoneActionPendingTimeout-age)}, nil
+}
+
+// claim sets the ZoneAction in Running status, the patch fails with a conflict if the ZoneAction was modified meanwhile
+func (r *ZoneActionReconciler) claim(ctx context.Context, action *dnsv1alpha2.ZoneAction, zoneName string) error {
+	original := action.DeepCopy()
+	action.Status.SyncStatus = ptr.To(RUNNING_STATUS)
+	action.Status.ObservedGeneration = &action.Generation
+	meta.SetStatusCondition(&action.Status.Conditions, metav1.Condition{
+		Type:               "Complete",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             ZoneActionReasonRunning,
+		Message:            fmt.Sprintf("%s running on zone %s", action.Spec.Action, zoneName),
+	})
+	return r.Status().Patch(ctx, action, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// complete records the outcome of the action, which is never run again
+func (r *ZoneActionReconciler) complete(ctx context.Context, action *dnsv1alpha2.ZoneAction, status, reason string, result *string, message string, opts ...client.MergeFromOption) (ctrl.Result, error) {
+	original := action.DeepCopy()
+	action.Status.SyncStatus = ptr.To(status)
+	action.Status.ObservedGeneration = &action.Generation
+	action.Status.Result = result
+	action.Status.CompletionTime = ptr.To(metav1.NewTime(time.Now().UTC()))
+	conditionStatus := metav1.ConditionTrue
+	if status != SUCCEEDED_STATUS {
+		conditionStatus = metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&action.Status.Conditions, metav1.Condition{
+		Type:               "Complete",
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Patch(ctx, action, client.MergeFromWithOptions(original, opts...)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to patch ZoneAction status")
+		return ctrl.Result{}, err
+	}
+	return r.cleanup(ctx, action)
+}
+
+// cleanup deletes the finished ZoneAction once its TTL is expired, or requeues it until then
+func (r *ZoneActionReconciler) cleanup(ctx context.Context, action *dnsv1alpha2.ZoneAction) (ctrl.Result, error) {
+	if action.Spec.TTLSecondsAfterFinished == nil {
+		return ctrl.Result{}, nil
+	}
+	expiration := action.Status.CompletionTime.Add(time.Duration(*action.Spec.TTLSecondsAfterFinished) * time.Second)
+	if remaining := time.Until(expiration); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	log.FromContext(ctx).Info("Deleting finished ZoneAction", "ZoneAction.Name", action.Name)
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, action))
+}
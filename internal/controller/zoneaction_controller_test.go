@@ -0,0 +1,163 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("ZoneAction Controller", func() {
+	const (
+		zoneName      = "example15.org"
+		zoneNamespace = "example1"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+
+	newZoneAction := func(name, action string) *dnsv1alpha2.ZoneAction {
+		return &dnsv1alpha2.ZoneAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: zoneNamespace,
+			},
+			Spec: dnsv1alpha2.ZoneActionSpec{
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+				Action:  action,
+			},
+		}
+	}
+
+	Context("When creating a ZoneAction", func() {
+		It("should wait for the zone, run the action once and delete it after its TTL", Label("zoneaction-creation"), func() {
+			ctx := context.Background()
+
+			By("Creating a ZoneAction on a non-existing Zone")
+			notify := newZoneAction("notify", ZoneActionNotify)
+			notify.Spec.TTLSecondsAfterFinished = ptr.To(int32(2))
+			Expect(k8sClient.Create(ctx, notify)).To(Succeed())
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(notify), notify)
+				return ptr.Deref(notify.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(PENDING_STATUS))
+
+			By("Creating the Zone")
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      zoneName,
+					Namespace: zoneNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        NATIVE_KIND_ZONE,
+					Nameservers: []string{"ns1.example15.org", "ns2.example15.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+			})
+
+			By("Getting the outcome of the action")
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(notify), notify)
+				return ptr.Deref(notify.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(SUCCEEDED_STATUS))
+			Expect(notify.Status.Result).To(Equal(ptr.To("Notification queued")))
+			Expect(notify.Status.CompletionTime).NotTo(BeNil())
+			Expect(getMockedActionsCount(zoneName, ZoneActionNotify)).To(Equal(1))
+
+			By("Running another action without TTL")
+			flush := newZoneAction("flush", ZoneActionFlushCache)
+			Expect(k8sClient.Create(ctx, flush)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, flush)).To(Succeed())
+			})
+			Eventually(func() string {
+				_ = k8sClient.Get(ctx, client.ObjectKeyFromObject(flush), flush)
+				return ptr.Deref(flush.Status.SyncStatus, "")
+			}, timeout, interval).Should(Equal(SUCCEEDED_STATUS))
+
+			By("Waiting for the deletion of the action after its TTL")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(notify), notify)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			Expect(getMockedActionsCount(zoneName, ZoneActionNotify)).To(Equal(1), "Action should only be run once")
+			Consistently(func() error {
+				return k8sClient.Get(ctx, client.ObjectKeyFromObject(flush), flush)
+			}, 2*time.Second, interval).Should(Succeed())
+			Expect(getMockedActionsCount(zoneName, ZoneActionFlushCache)).To(Equal(1), "Action should only be run once")
+		})
+	})
+})
+
+func TestZoneActionReconcilerInterrupted(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	newAction := func(name string, created time.Time, status *string) *dnsv1alpha2.ZoneAction {
+		return &dnsv1alpha2.ZoneAction{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", CreationTimestamp: metav1.NewTime(created)},
+			Spec:       dnsv1alpha2.ZoneActionSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}, Action: ZoneActionNotify},
+			Status:     dnsv1alpha2.ZoneActionStatus{SyncStatus: status},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			newAction("running", time.Now(), ptr.To(RUNNING_STATUS)),
+			newAction("waiting", time.Now().Add(-ZoneActionPendingTimeout), ptr.To(PENDING_STATUS)),
+		).
+		WithStatusSubresource(&dnsv1alpha2.ZoneAction{}).
+		Build()
+	// The actions are never run, the PowerDNS client is not set
+	r := &ZoneActionReconciler{Client: cl, Scheme: scheme}
+
+	var testCases = []struct {
+		name   string
+		reason string
+	}{
+		{"running", ZoneActionReasonInterrupted},
+		{"waiting", ZoneActionReasonZoneNotAvailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := client.ObjectKey{Namespace: "team-a", Name: tc.name}
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var action dnsv1alpha2.ZoneAction
+			if err := cl.Get(ctx, key, &action); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			condition := meta.FindStatusCondition(action.Status.Conditions, "Complete")
+			if ptr.Deref(action.Status.SyncStatus, "") != FAILED_STATUS || action.Status.CompletionTime == nil || condition == nil || condition.Reason != tc.reason {
+				t.Errorf("got status %+v, want Failed with reason %s", action.Status, tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package dkim generates DKIM keys and builds the content of the TXT records publishing them
+// (RFC 6376 for RSA keys, RFC 8463 for Ed25519 keys).
+package dkim
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
+)
+
+const (
+	RSA     = "RSA"
+	Ed25519 = "Ed25519"
+)
+
+// GenerateKey generates a private key of the algorithm, of bits for RSA keys, and returns it PEM encoded in PKCS #8
+func GenerateKey(algorithm string, bits int) ([]byte, error) {
+	var key crypto.PrivateKey
+	var err error
+	switch algorithm {
+	case RSA:
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case Ed25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// PublicKey returns the algorithm of a PEM encoded private key, and the public key as set in the "p=" tag of its DKIM record:
+// the base64 encoded SubjectPublicKeyInfo for RSA keys, the base64 encoded raw public key for Ed25519 keys
+func PublicKey(privateKey []byte) (string, string, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return "", "", fmt.Errorf("no PEM encoded private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", "", err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		der, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+		if err != nil {
+			return "", "", err
+		}
+		return RSA, base64.StdEncoding.EncodeToString(der), nil
+	case ed25519.PrivateKey:
+		return Ed25519, base64.StdEncoding.EncodeToString(k.Public().(ed25519.PublicKey)), nil
+	default:
+		return "", "", fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// Record returns the content of the TXT record publishing the public key, split in strings of 255 bytes
+func Record(algorithm, publicKey string) string {
+	keyType := "rsa"
+	if algorithm == Ed25519 {
+		keyType = "ed25519"
+	}
+	return normalize.TXT(fmt.Sprintf("v=DKIM1; k=%s; p=%s", keyType, publicKey))
+}
+
+// ParseRecord returns the algorithm and the public key of the content of a TXT record built by Record,
+// and the size in bits of RSA keys
+func ParseRecord(record string) (string, string, int32, error) {
+	value := strings.ReplaceAll(strings.Trim(record, `"`), `" "`, "")
+	algorithm, publicKey := RSA, ""
+	for _, tag := range strings.Split(value, ";") {
+		name, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		switch name {
+		case "k":
+			if v == "ed25519" {
+				algorithm = Ed25519
+			}
+		case "p":
+			publicKey = v
+		}
+	}
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(der) == 0 {
+		return "", "", 0, fmt.Errorf("no public key found in %q", record)
+	}
+	if algorithm == Ed25519 {
+		return algorithm, publicKey, 0, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return "", "", 0, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return "", "", 0, fmt.Errorf("unsupported public key type %T", key)
+	}
+	return algorithm, publicKey, int32(rsaKey.N.BitLen()), nil
+}
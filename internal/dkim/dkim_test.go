@@ -0,0 +1,108 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package dkim
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	var testCases = []struct {
+		algorithm string
+		bits      int
+		keyLength int
+	}{
+		{RSA, 1024, 162},
+		{RSA, 2048, 294},
+		{Ed25519, 0, 32},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			privateKey, err := GenerateKey(tc.algorithm, tc.bits)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			algorithm, publicKey, err := PublicKey(privateKey)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if algorithm != tc.algorithm {
+				t.Errorf("got algorithm %q, want %q", algorithm, tc.algorithm)
+			}
+			der, err := base64.StdEncoding.DecodeString(publicKey)
+			if err != nil {
+				t.Fatalf("public key is not base64 encoded: %v", err)
+			}
+			if len(der) != tc.keyLength {
+				t.Errorf("got public key of %d bytes, want %d", len(der), tc.keyLength)
+			}
+		})
+	}
+
+	if _, err := GenerateKey("DSA", 1024); err == nil {
+		t.Errorf("unsupported algorithm should be refused")
+	}
+	if _, _, err := PublicKey([]byte("not a key")); err == nil {
+		t.Errorf("invalid private key should be refused")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	if got, want := Record(Ed25519, "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="), `"v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	publicKey := strings.Repeat("A", 392)
+	got := Record(RSA, publicKey)
+	want := `"v=DKIM1; k=rsa; p=` + publicKey[:237] + `" "` + publicKey[237:] + `"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	var testCases = []struct {
+		algorithm string
+		bits      int
+	}{
+		{RSA, 1024},
+		{RSA, 2048},
+		{Ed25519, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			privateKey, err := GenerateKey(tc.algorithm, tc.bits)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, publicKey, err := PublicKey(privateKey)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			algorithm, got, bits, err := ParseRecord(Record(tc.algorithm, publicKey))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if algorithm != tc.algorithm || got != publicKey || int(bits) != tc.bits {
+				t.Errorf("got %s %d %q, want %s %d %q", algorithm, bits, got, tc.algorithm, tc.bits, publicKey)
+			}
+		})
+	}
+
+	if _, _, _, err := ParseRecord(`"v=DKIM1; k=rsa"`); err == nil {
+		t.Errorf("record without public key should be refused")
+	}
+}
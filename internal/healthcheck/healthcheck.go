@@ -0,0 +1,184 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package healthcheck checks the addresses of RRsets and keeps track of their health.
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	TCP   = "TCP"
+	HTTP  = "HTTP"
+	HTTPS = "HTTPS"
+
+	// DefaultInterval, DefaultTimeout and the default thresholds apply when the fields are not defaulted by the API server
+	DefaultInterval           = 10 * time.Second
+	DefaultTimeout            = 3 * time.Second
+	DefaultHealthyThreshold   = 2
+	DefaultUnhealthyThreshold = 3
+)
+
+// Interval returns the interval between two checks of an address
+func Interval(check *dnsv1alpha2.HealthCheck) time.Duration {
+	if check.Interval == nil || check.Interval.Duration <= 0 {
+		return DefaultInterval
+	}
+	return check.Interval.Duration
+}
+
+// Probe checks an address, it returns the reason why the address is unhealthy, or nil
+func Probe(ctx context.Context, check *dnsv1alpha2.HealthCheck, address string) error {
+	timeout := DefaultTimeout
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		timeout = check.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	hostPort := net.JoinHostPort(address, strconv.Itoa(int(check.Port)))
+
+	if check.Type == TCP {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", hostPort)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	scheme := "http"
+	if check.Type == HTTPS {
+		scheme = "https"
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, hostPort, ptr.Deref(check.Path, "/")), nil)
+	if err != nil {
+		return err
+	}
+	request.Host = ptr.Deref(check.Host, "")
+	client := &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			TLSClientConfig: &tls.Config{
+				ServerName:         ptr.Deref(check.Host, ""),
+				InsecureSkipVerify: ptr.Deref(check.InsecureSkipVerify, false), //nolint:gosec
+			},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	if !expectedStatus(check, response.StatusCode) {
+		return fmt.Errorf("unexpected HTTP status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// expectedStatus returns True if the HTTP status is expected from a healthy address
+func expectedStatus(check *dnsv1alpha2.HealthCheck, status int) bool {
+	if len(check.ExpectedStatuses) == 0 {
+		return status >= 200 && status < 400
+	}
+	return slices.Contains(check.ExpectedStatuses, int32(status))
+}
+
+// ProbeAll checks the addresses in parallel, it returns the result of the check of each address
+func ProbeAll(ctx context.Context, check *dnsv1alpha2.HealthCheck, addresses []string) map[string]error {
+	results := make(map[string]error, len(addresses))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := Probe(ctx, check, address)
+			mu.Lock()
+			results[address] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Update returns the health of the addresses, from their previous health and the results of their checks, if any.
+// An address is healthy until it fails UnhealthyThreshold consecutive checks,
+// and then unhealthy until it succeeds HealthyThreshold consecutive checks.
+// All the addresses are published when fewer than MinHealthy addresses are healthy, or when none is.
+func Update(check *dnsv1alpha2.HealthCheck, addresses []string, previous []dnsv1alpha2.TargetHealth, results map[string]error, now metav1.Time) []dnsv1alpha2.TargetHealth {
+	healthyThreshold := check.HealthyThreshold
+	if healthyThreshold < 1 {
+		healthyThreshold = DefaultHealthyThreshold
+	}
+	unhealthyThreshold := check.UnhealthyThreshold
+	if unhealthyThreshold < 1 {
+		unhealthyThreshold = DefaultUnhealthyThreshold
+	}
+
+	targets := make([]dnsv1alpha2.TargetHealth, 0, len(addresses))
+	healthy := 0
+	for _, address := range addresses {
+		if slices.ContainsFunc(targets, func(t dnsv1alpha2.TargetHealth) bool { return t.Address == address }) {
+			continue
+		}
+		target := dnsv1alpha2.TargetHealth{Address: address, Healthy: true}
+		if i := slices.IndexFunc(previous, func(t dnsv1alpha2.TargetHealth) bool { return t.Address == address }); i >= 0 {
+			target = *previous[i].DeepCopy()
+		}
+		if err, checked := results[address]; checked {
+			if err == nil {
+				target.ConsecutiveSuccesses = min(target.ConsecutiveSuccesses+1, healthyThreshold)
+				target.ConsecutiveFailures = 0
+				target.Message = nil
+				if !target.Healthy && target.ConsecutiveSuccesses >= healthyThreshold {
+					target.Healthy = true
+					target.LastTransitionTime = &now
+				}
+			} else {
+				target.ConsecutiveFailures = min(target.ConsecutiveFailures+1, unhealthyThreshold)
+				target.ConsecutiveSuccesses = 0
+				target.Message = ptr.To(err.Error())
+				if target.Healthy && target.ConsecutiveFailures >= unhealthyThreshold {
+					target.Healthy = false
+					target.LastTransitionTime = &now
+				}
+			}
+		}
+		if target.Healthy {
+			healthy++
+		}
+		targets = append(targets, target)
+	}
+
+	minHealthy := max(int(check.MinHealthy), 1)
+	for i := range targets {
+		targets[i].Published = targets[i].Healthy || healthy < minHealthy
+	}
+	return targets
+}
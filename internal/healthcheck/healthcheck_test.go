@@ -0,0 +1,130 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	// A port which was listened on, and is closed
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	closedPort := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	var testCases = []struct {
+		description string
+		check       dnsv1alpha2.HealthCheck
+		healthy     bool
+	}{
+		{"TCP port open", dnsv1alpha2.HealthCheck{Type: TCP, Port: int32(port)}, true},
+		{"TCP port closed", dnsv1alpha2.HealthCheck{Type: TCP, Port: int32(closedPort)}, false},
+		{"HTTP status OK", dnsv1alpha2.HealthCheck{Type: HTTP, Port: int32(port), Path: ptr.To("/healthz")}, true},
+		{"HTTP redirection not followed", dnsv1alpha2.HealthCheck{Type: HTTP, Port: int32(port), Path: ptr.To("/moved")}, true},
+		{"HTTP status unavailable", dnsv1alpha2.HealthCheck{Type: HTTP, Port: int32(port)}, false},
+		{"HTTP status expected", dnsv1alpha2.HealthCheck{Type: HTTP, Port: int32(port), ExpectedStatuses: []int32{503}}, true},
+		{"HTTP status not expected", dnsv1alpha2.HealthCheck{Type: HTTP, Port: int32(port), Path: ptr.To("/healthz"), ExpectedStatuses: []int32{204}}, false},
+		{"HTTPS on a plain HTTP port", dnsv1alpha2.HealthCheck{Type: HTTPS, Port: int32(port), Timeout: &metav1.Duration{Duration: time.Second}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := Probe(context.Background(), &tc.check, "127.0.0.1")
+			if (err == nil) != tc.healthy {
+				t.Errorf("got error %v, want healthy %t", err, tc.healthy)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	check := &dnsv1alpha2.HealthCheck{Type: TCP, Port: 80, HealthyThreshold: 2, UnhealthyThreshold: 2, MinHealthy: 1}
+	addresses := []string{"192.0.2.1", "192.0.2.2"}
+	failure := errors.New("connection refused")
+	now := metav1.Now()
+
+	var testCases = []struct {
+		description   string
+		results       map[string]error
+		wantHealthy   []bool
+		wantPublished []bool
+	}{
+		{"new addresses are healthy", nil, []bool{true, true}, []bool{true, true}},
+		{"first failure", map[string]error{"192.0.2.1": failure, "192.0.2.2": nil}, []bool{true, true}, []bool{true, true}},
+		{"unhealthy threshold reached", map[string]error{"192.0.2.1": failure, "192.0.2.2": nil}, []bool{false, true}, []bool{false, true}},
+		{"all unhealthy, all published", map[string]error{"192.0.2.1": failure, "192.0.2.2": failure}, []bool{false, true}, []bool{false, true}},
+		{"minimum healthy safeguard", map[string]error{"192.0.2.1": failure, "192.0.2.2": failure}, []bool{false, false}, []bool{true, true}},
+		{"first success", map[string]error{"192.0.2.1": nil, "192.0.2.2": failure}, []bool{false, false}, []bool{true, true}},
+		{"healthy threshold reached", map[string]error{"192.0.2.1": nil, "192.0.2.2": failure}, []bool{true, false}, []bool{true, false}},
+		{"not checked", nil, []bool{true, false}, []bool{true, false}},
+	}
+
+	var targets []dnsv1alpha2.TargetHealth
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			targets = Update(check, addresses, targets, tc.results, now)
+			if len(targets) != len(addresses) {
+				t.Fatalf("got %d targets, want %d", len(targets), len(addresses))
+			}
+			for i, target := range targets {
+				if target.Address != addresses[i] || target.Healthy != tc.wantHealthy[i] || target.Published != tc.wantPublished[i] {
+					t.Errorf("got target %+v, want healthy %t and published %t", target, tc.wantHealthy[i], tc.wantPublished[i])
+				}
+			}
+		})
+	}
+
+	// An address removed from the RRset is no longer reported
+	targets = Update(check, addresses[1:], targets, nil, now)
+	if len(targets) != 1 || targets[0].Address != addresses[1] {
+		t.Errorf("got targets %+v, want only %s", targets, addresses[1])
+	}
+
+	// The addresses are published when none is healthy, even without minimum
+	check = &dnsv1alpha2.HealthCheck{Type: TCP, Port: 80, HealthyThreshold: 1, UnhealthyThreshold: 1}
+	targets = Update(check, addresses, nil, map[string]error{"192.0.2.1": failure, "192.0.2.2": failure}, now)
+	for _, target := range targets {
+		if target.Healthy || !target.Published {
+			t.Errorf("got target %+v, want unhealthy and published", target)
+		}
+	}
+}
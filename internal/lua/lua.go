@@ -0,0 +1,182 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package lua builds and validates the content of PowerDNS LUA records.
+// It is shared by the admission webhooks and the reconcilers.
+package lua
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// addressTypes are the record types returned by the address selection functions
+var addressTypes = []string{"A", "AAAA"}
+
+var recordTypeRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+
+// Content returns the content of the LUA record described by spec,
+// e.g. `A "ifportup(443, {'192.0.2.1', '192.0.2.2'})"`
+func Content(spec *dnsv1alpha2.LuaRecord) (string, error) {
+	var snippet string
+	switch {
+	case spec.IfPortUp != nil:
+		addresses, err := quoteAddresses(spec.Type, spec.IfPortUp.Addresses)
+		if err != nil {
+			return "", err
+		}
+		if spec.IfPortUp.Port < 1 || spec.IfPortUp.Port > 65535 {
+			return "", fmt.Errorf("invalid port %d", spec.IfPortUp.Port)
+		}
+		snippet = fmt.Sprintf("ifportup(%d, {%s})", spec.IfPortUp.Port, strings.Join(addresses, ", "))
+	case spec.IfURLUp != nil:
+		addresses, err := quoteAddresses(spec.Type, spec.IfURLUp.Addresses)
+		if err != nil {
+			return "", err
+		}
+		u, err := url.Parse(spec.IfURLUp.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("invalid URL %q, an absolute http or https URL is expected", spec.IfURLUp.URL)
+		}
+		if strings.ContainsAny(spec.IfURLUp.URL, `'"\`) {
+			return "", fmt.Errorf("invalid URL %q, quotes and backslashes are not allowed", spec.IfURLUp.URL)
+		}
+		snippet = fmt.Sprintf("ifurlup('%s', {%s})", spec.IfURLUp.URL, strings.Join(addresses, ", "))
+	case len(spec.PickRandom) > 0:
+		addresses, err := quoteAddresses(spec.Type, spec.PickRandom)
+		if err != nil {
+			return "", err
+		}
+		snippet = fmt.Sprintf("pickrandom({%s})", strings.Join(addresses, ", "))
+	case len(spec.PickWRandom) > 0:
+		entries := make([]string, 0, len(spec.PickWRandom))
+		for _, w := range spec.PickWRandom {
+			addresses, err := quoteAddresses(spec.Type, []string{w.Address})
+			if err != nil {
+				return "", err
+			}
+			if w.Weight < 0 {
+				return "", fmt.Errorf("invalid weight %d for address %s", w.Weight, w.Address)
+			}
+			entries = append(entries, fmt.Sprintf("{%d, %s}", w.Weight, addresses[0]))
+		}
+		snippet = fmt.Sprintf("pickwrandom({%s})", strings.Join(entries, ", "))
+	case spec.Snippet != nil:
+		if strings.TrimSpace(*spec.Snippet) == "" {
+			return "", fmt.Errorf("empty LUA snippet")
+		}
+		if err := checkStrings(*spec.Snippet); err != nil {
+			return "", err
+		}
+		snippet = *spec.Snippet
+	default:
+		return "", fmt.Errorf("no LUA function set")
+	}
+
+	if !recordTypeRegexp.MatchString(spec.Type) {
+		return "", fmt.Errorf("invalid record type %q", spec.Type)
+	}
+	return fmt.Sprintf("%s %s", spec.Type, quote(snippet)), nil
+}
+
+// ValidateRecord returns an error if content is not a valid LUA record content,
+// i.e. a record type followed by a quoted LUA snippet
+func ValidateRecord(content string) error {
+	recordType, quoted, found := strings.Cut(strings.TrimSpace(content), " ")
+	if !found || !recordTypeRegexp.MatchString(recordType) {
+		return fmt.Errorf("invalid LUA record %q, expected a record type followed by a quoted snippet", content)
+	}
+	snippet, err := unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return fmt.Errorf("invalid LUA record %q: %w", content, err)
+	}
+	if err := checkStrings(snippet); err != nil {
+		return fmt.Errorf("invalid LUA record %q: %w", content, err)
+	}
+	return nil
+}
+
+// quoteAddresses returns the addresses as LUA strings, after checking they match the record type
+func quoteAddresses(recordType string, addresses []string) ([]string, error) {
+	if !slices.Contains(addressTypes, recordType) {
+		return nil, fmt.Errorf("type %s is not supported, only %s addresses can be selected", recordType, strings.Join(addressTypes, " and "))
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no address set")
+	}
+	result := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		ip, err := netip.ParseAddr(a)
+		if err != nil || (recordType == "A") != ip.Is4() {
+			return nil, fmt.Errorf("invalid %s address %q", recordType, a)
+		}
+		result = append(result, "'"+ip.String()+"'")
+	}
+	return result, nil
+}
+
+// quote returns the snippet as a DNS character-string
+func quote(snippet string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(snippet) + `"`
+}
+
+// unquote returns the content of DNS character-strings, concatenated
+func unquote(quoted string) (string, error) {
+	var b strings.Builder
+	for len(quoted) > 0 {
+		if quoted[0] != '"' {
+			return "", fmt.Errorf("snippet must be enclosed in double quotes")
+		}
+		i := 1
+		for ; i < len(quoted) && quoted[i] != '"'; i++ {
+			if quoted[i] == '\\' {
+				i++
+				if i == len(quoted) {
+					break
+				}
+			}
+			b.WriteByte(quoted[i])
+		}
+		if i >= len(quoted) {
+			return "", fmt.Errorf("unterminated double quote")
+		}
+		quoted = strings.TrimLeft(quoted[i+1:], " ")
+	}
+	return b.String(), nil
+}
+
+// checkStrings returns an error if a LUA string literal of the snippet is not terminated
+func checkStrings(snippet string) error {
+	var delimiter rune
+	escaped := false
+	for _, c := range snippet {
+		switch {
+		case escaped:
+			escaped = false
+		case delimiter != 0 && c == '\\':
+			escaped = true
+		case delimiter != 0 && c == delimiter:
+			delimiter = 0
+		case delimiter == 0 && (c == '\'' || c == '"'):
+			delimiter = c
+		}
+	}
+	if delimiter != 0 {
+		return fmt.Errorf("unterminated string in LUA snippet")
+	}
+	return nil
+}
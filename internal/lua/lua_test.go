@@ -0,0 +1,126 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package lua
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestContent(t *testing.T) {
+	var testCases = []struct {
+		description string
+		spec        *dnsv1alpha2.LuaRecord
+		want        string
+		valid       bool
+	}{
+		{
+			"ifportup",
+			&dnsv1alpha2.LuaRecord{Type: "A", IfPortUp: &dnsv1alpha2.LuaIfPortUp{Port: 443, Addresses: []string{"192.0.2.1", "192.0.2.2"}}},
+			`A "ifportup(443, {'192.0.2.1', '192.0.2.2'})"`,
+			true,
+		},
+		{
+			"ifurlup",
+			&dnsv1alpha2.LuaRecord{Type: "AAAA", IfURLUp: &dnsv1alpha2.LuaIfURLUp{URL: "https://www.example.org/health", Addresses: []string{"2001:db8::1"}}},
+			`AAAA "ifurlup('https://www.example.org/health', {'2001:db8::1'})"`,
+			true,
+		},
+		{
+			"pickrandom",
+			&dnsv1alpha2.LuaRecord{Type: "A", PickRandom: []string{"192.0.2.1", "192.0.2.2"}},
+			`A "pickrandom({'192.0.2.1', '192.0.2.2'})"`,
+			true,
+		},
+		{
+			"pickwrandom",
+			&dnsv1alpha2.LuaRecord{Type: "A", PickWRandom: []dnsv1alpha2.LuaWeightedAddress{{Weight: 100, Address: "192.0.2.1"}, {Weight: 50, Address: "192.0.2.2"}}},
+			`A "pickwrandom({{100, '192.0.2.1'}, {50, '192.0.2.2'}})"`,
+			true,
+		},
+		{
+			"snippet with double quotes",
+			&dnsv1alpha2.LuaRecord{Type: "TXT", Snippet: ptr.To(`"hello " .. "world"`)},
+			`TXT "\"hello \" .. \"world\""`,
+			true,
+		},
+		{
+			"IPv6 address in A record",
+			&dnsv1alpha2.LuaRecord{Type: "A", PickRandom: []string{"2001:db8::1"}},
+			"",
+			false,
+		},
+		{
+			"address function returning CNAME",
+			&dnsv1alpha2.LuaRecord{Type: "CNAME", PickRandom: []string{"www.example.org."}},
+			"",
+			false,
+		},
+		{
+			"URL with quote",
+			&dnsv1alpha2.LuaRecord{Type: "A", IfURLUp: &dnsv1alpha2.LuaIfURLUp{URL: "https://www.example.org/'", Addresses: []string{"192.0.2.1"}}},
+			"",
+			false,
+		},
+		{
+			"snippet with unterminated string",
+			&dnsv1alpha2.LuaRecord{Type: "CNAME", Snippet: ptr.To(`'www.example.org.`)},
+			"",
+			false,
+		},
+		{
+			"no function",
+			&dnsv1alpha2.LuaRecord{Type: "A"},
+			"",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Content(tc.spec)
+			if valid := err == nil; valid != tc.valid {
+				t.Fatalf("got valid=%v (%v), want %v", valid, err, tc.valid)
+			}
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateRecord(t *testing.T) {
+	var testCases = []struct {
+		content string
+		valid   bool
+	}{
+		{`A "ifportup(443, {'192.0.2.1', '192.0.2.2'})"`, true},
+		{`TXT "\"hello\""`, true},
+		{`A ";return 'hello'" " .. 'world'"`, true},
+		{`a "pickrandom({'192.0.2.1'})"`, false},
+		{`A pickrandom({'192.0.2.1'})`, false},
+		{`A "pickrandom({'192.0.2.1'})`, false},
+		{`A "pickrandom({'192.0.2.1})"`, false},
+		{`"pickrandom({'192.0.2.1'})"`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.content, func(t *testing.T) {
+			if err := ValidateRecord(tc.content); (err == nil) != tc.valid {
+				t.Errorf("got %v, want valid=%v", err, tc.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package mailpolicy renders the mail policies of a MailPolicy into the content of TXT records
+package mailpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/normalize"
+)
+
+// Names of the records, relative to the mail domain
+const (
+	DMARCName  = "_dmarc"
+	MTASTSName = "_mta-sts"
+	TLSRPTName = "_smtp._tls"
+)
+
+var qualifiers = map[string]string{
+	"Pass":     "",
+	"Fail":     "-",
+	"SoftFail": "~",
+	"Neutral":  "?",
+}
+
+// SPF returns the content of the TXT record of a SPF policy (e.g. "v=spf1 mx include:_spf.example.net ~all")
+func SPF(spf dnsv1alpha2.SPF) string {
+	terms := []string{"v=spf1"}
+	for _, m := range spf.Mechanisms {
+		term := qualifier(m.Qualifier, "Pass") + m.Type
+		if m.Value != nil {
+			if !strings.HasPrefix(*m.Value, "/") {
+				term += ":"
+			}
+			term += *m.Value
+		}
+		terms = append(terms, term)
+	}
+	terms = append(terms, qualifier(spf.All, "SoftFail")+"all")
+	return normalize.TXT(strings.Join(terms, " "))
+}
+
+// DMARC returns the content of the TXT record of a DMARC policy (e.g. "v=DMARC1; p=reject; rua=mailto:dmarc@example.org")
+func DMARC(dmarc dnsv1alpha2.DMARC) string {
+	tags := []string{"v=DMARC1", "p=" + dmarc.Policy}
+	if dmarc.SubdomainPolicy != nil {
+		tags = append(tags, "sp="+*dmarc.SubdomainPolicy)
+	}
+	if dmarc.Percentage != nil {
+		tags = append(tags, fmt.Sprintf("pct=%d", *dmarc.Percentage))
+	}
+	if len(dmarc.AggregateReports) > 0 {
+		tags = append(tags, "rua="+mailtos(dmarc.AggregateReports))
+	}
+	if len(dmarc.ForensicReports) > 0 {
+		tags = append(tags, "ruf="+mailtos(dmarc.ForensicReports))
+	}
+	if dmarc.DKIMAlignment != nil {
+		tags = append(tags, "adkim="+(*dmarc.DKIMAlignment)[:1])
+	}
+	if dmarc.SPFAlignment != nil {
+		tags = append(tags, "aspf="+(*dmarc.SPFAlignment)[:1])
+	}
+	return normalize.TXT(strings.Join(tags, "; "))
+}
+
+// MTASTS returns the content of the TXT record announcing a MTA-STS policy (e.g. "v=STSv1; id=20240101")
+func MTASTS(mtaSTS dnsv1alpha2.MTASTS) string {
+	return normalize.TXT("v=STSv1; id=" + mtaSTS.ID)
+}
+
+// TLSRPT returns the content of the TXT record of a TLS-RPT policy (e.g. "v=TLSRPTv1; rua=mailto:tls-rpt@example.org")
+func TLSRPT(tlsRPT dnsv1alpha2.TLSRPT) string {
+	var uris []string
+	for _, report := range tlsRPT.Reports {
+		if strings.HasPrefix(string(report), "https://") {
+			uris = append(uris, string(report))
+		} else {
+			uris = append(uris, "mailto:"+string(report))
+		}
+	}
+	return normalize.TXT("v=TLSRPTv1; rua=" + strings.Join(uris, ","))
+}
+
+// qualifier returns the SPF qualifier prefix of a result, "Pass" being implicit
+func qualifier(result *string, defaultResult string) string {
+	if result == nil {
+		return qualifiers[defaultResult]
+	}
+	return qualifiers[*result]
+}
+
+// mailtos returns the comma separated "mailto:" URIs of the addresses
+func mailtos(addresses []dnsv1alpha2.MailAddress) string {
+	uris := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		uris = append(uris, "mailto:"+string(address))
+	}
+	return strings.Join(uris, ",")
+}
@@ -0,0 +1,103 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package mailpolicy
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestSPF(t *testing.T) {
+	var testCases = []struct {
+		description string
+		spf         dnsv1alpha2.SPF
+		want        string
+	}{
+		{
+			"no mechanism",
+			dnsv1alpha2.SPF{},
+			`"v=spf1 ~all"`,
+		},
+		{
+			"mechanisms",
+			dnsv1alpha2.SPF{
+				Mechanisms: []dnsv1alpha2.SPFMechanism{
+					{Type: "mx"},
+					{Type: "a", Value: ptr.To("/24")},
+					{Type: "a", Value: ptr.To("mail.example.org/28")},
+					{Type: "ip4", Value: ptr.To("192.0.2.0/24")},
+					{Type: "ip6", Value: ptr.To("2001:db8::/32")},
+					{Type: "include", Value: ptr.To("_spf.example.net"), Qualifier: ptr.To("SoftFail")},
+					{Type: "exists", Value: ptr.To("%{i}._spf.example.org"), Qualifier: ptr.To("Neutral")},
+				},
+				All: ptr.To("Fail"),
+			},
+			`"v=spf1 mx a/24 a:mail.example.org/28 ip4:192.0.2.0/24 ip6:2001:db8::/32 ~include:_spf.example.net ?exists:%{i}._spf.example.org -all"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := SPF(tc.spf); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDMARC(t *testing.T) {
+	var testCases = []struct {
+		description string
+		dmarc       dnsv1alpha2.DMARC
+		want        string
+	}{
+		{
+			"policy only",
+			dnsv1alpha2.DMARC{Policy: "none"},
+			`"v=DMARC1; p=none"`,
+		},
+		{
+			"all tags",
+			dnsv1alpha2.DMARC{
+				Policy:           "reject",
+				SubdomainPolicy:  ptr.To("quarantine"),
+				Percentage:       ptr.To(int32(50)),
+				AggregateReports: []dnsv1alpha2.MailAddress{"dmarc@example.org", "dmarc@example.net"},
+				ForensicReports:  []dnsv1alpha2.MailAddress{"forensic@example.org"},
+				DKIMAlignment:    ptr.To("strict"),
+				SPFAlignment:     ptr.To("relaxed"),
+			},
+			`"v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.org,mailto:dmarc@example.net; ruf=mailto:forensic@example.org; adkim=s; aspf=r"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := DMARC(tc.dmarc); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMTASTSAndTLSRPT(t *testing.T) {
+	if got, want := MTASTS(dnsv1alpha2.MTASTS{ID: "20240101"}), `"v=STSv1; id=20240101"`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	tlsRPT := dnsv1alpha2.TLSRPT{Reports: []dnsv1alpha2.TLSRPTDestination{"tls-rpt@example.org", "https://reports.example.org/tls"}}
+	if got, want := TLSRPT(tlsRPT), `"v=TLSRPTv1; rua=mailto:tls-rpt@example.org,https://reports.example.org/tls"`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package normalize converts the content of records to the form returned by PowerDNS
+// (compressed IPv6 addresses, lowercase absolute names, TXT strings split in 255 bytes chunks),
+// so that the records of a RRSet and the records of PowerDNS can be compared.
+// Content which cannot be parsed is only trimmed, it is rejected by PowerDNS anyway.
+package normalize
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// maxStringLength is the maximum length of a DNS character-string
+const maxStringLength = 255
+
+// Records returns the normalized content of the records of type rrType
+func Records(rrType string, contents []string) []string {
+	result := make([]string, 0, len(contents))
+	for _, content := range contents {
+		result = append(result, Record(rrType, content))
+	}
+	return result
+}
+
+// Record returns the normalized content of a record of type rrType
+func Record(rrType, content string) string {
+	content = strings.TrimSpace(content)
+	var normalized string
+	var err error
+	switch strings.ToUpper(rrType) {
+	case "A":
+		normalized, err = address(content, true)
+	case "AAAA":
+		normalized, err = address(content, false)
+	case "CNAME", "NS", "PTR", "DNAME", "ALIAS":
+		normalized = name(content)
+	case "MX":
+		normalized, err = fields(content, "u16", "name")
+	case "SRV":
+		normalized, err = fields(content, "u16", "u16", "u16", "name")
+	case "CAA":
+		normalized, err = caa(content)
+	case "TXT", "SPF":
+		normalized, err = txt(content)
+	default:
+		return content
+	}
+	if err != nil {
+		return content
+	}
+	return normalized
+}
+
+// address returns the canonical form of an IPv4 or IPv6 address
+func address(content string, ipv4 bool) (string, error) {
+	addr, err := netip.ParseAddr(content)
+	if err != nil {
+		return "", err
+	}
+	if addr.Is4() != ipv4 {
+		return "", fmt.Errorf("unexpected address family %q", content)
+	}
+	return addr.String(), nil
+}
+
+// name returns the lowercase absolute form of a domain name
+func name(content string) string {
+	content = strings.ToLower(content)
+	if !strings.HasSuffix(content, ".") {
+		content += "."
+	}
+	return content
+}
+
+// fields normalizes a record made of whitespace separated fields, of kind "u16" (16 bits integer) or "name"
+func fields(content string, kinds ...string) (string, error) {
+	values := strings.Fields(content)
+	if len(values) != len(kinds) {
+		return "", fmt.Errorf("%d fields expected in %q", len(kinds), content)
+	}
+	for i, kind := range kinds {
+		switch kind {
+		case "u16":
+			v, err := strconv.ParseUint(values[i], 10, 16)
+			if err != nil {
+				return "", err
+			}
+			values[i] = strconv.FormatUint(v, 10)
+		case "name":
+			values[i] = name(values[i])
+		}
+	}
+	return strings.Join(values, " "), nil
+}
+
+// caa normalizes a CAA record: flags, lowercase tag and quoted value
+func caa(content string) (string, error) {
+	values := strings.SplitN(content, " ", 3)
+	if len(values) != 3 {
+		return "", fmt.Errorf("3 fields expected in %q", content)
+	}
+	flags, err := strconv.ParseUint(values[0], 10, 8)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(values[2])
+	if !strings.HasPrefix(value, `"`) {
+		value = `"` + value + `"`
+	}
+	strs, err := parseStrings(value)
+	if err != nil || len(strs) != 1 {
+		return "", fmt.Errorf("a single value is expected in %q", content)
+	}
+	return fmt.Sprintf("%d %s %s", flags, strings.ToLower(values[1]), quote(strs[0])), nil
+}
+
+// txt normalizes a TXT record: quoted strings separated by a space, strings longer than 255 bytes are split
+func txt(content string) (string, error) {
+	strs, err := parseStrings(content)
+	if err != nil {
+		return "", err
+	}
+	var chunks []string
+	for _, s := range strs {
+		chunks = append(chunks, split(s)...)
+	}
+	return strings.Join(chunks, " "), nil
+}
+
+// TXT returns the content of a TXT record holding the value, quoted and split in strings of 255 bytes
+func TXT(value string) string {
+	return strings.Join(split(value), " ")
+}
+
+// split returns the quoted strings of 255 bytes a value is split in
+func split(s string) []string {
+	var chunks []string
+	for len(s) > maxStringLength {
+		chunks = append(chunks, quote(s[:maxStringLength]))
+		s = s[maxStringLength:]
+	}
+	return append(chunks, quote(s))
+}
+
+// parseStrings parses a list of quoted character-strings, and returns their unescaped values
+func parseStrings(content string) ([]string, error) {
+	var result []string
+	for i := 0; i < len(content); {
+		if content[i] == ' ' || content[i] == '\t' {
+			i++
+			continue
+		}
+		if content[i] != '"' {
+			return nil, fmt.Errorf("quoted string expected at position %d of %q", i, content)
+		}
+		var s strings.Builder
+		i++
+		for {
+			if i >= len(content) {
+				return nil, fmt.Errorf("unterminated string in %q", content)
+			}
+			c := content[i]
+			if c == '"' {
+				i++
+				break
+			}
+			if c == '\\' {
+				if i+3 < len(content) && isDigits(content[i+1:i+4]) {
+					v, _ := strconv.ParseUint(content[i+1:i+4], 10, 8)
+					s.WriteByte(byte(v))
+					i += 4
+					continue
+				}
+				if i+1 >= len(content) {
+					return nil, fmt.Errorf("unterminated string in %q", content)
+				}
+				c = content[i+1]
+				i++
+			}
+			s.WriteByte(c)
+			i++
+		}
+		result = append(result, s.String())
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no string in %q", content)
+	}
+	return result, nil
+}
+
+// quote returns the quoted form of a character-string, as written by PowerDNS
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
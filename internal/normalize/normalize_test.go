@@ -0,0 +1,103 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package normalize
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrType      string
+		content     string
+		want        string
+	}{
+		{"A", "A", "192.0.2.1", "192.0.2.1"},
+		{"A with spaces", "A", " 192.0.2.1 ", "192.0.2.1"},
+		{"invalid A", "A", "2001:db8::1", "2001:db8::1"},
+		{"AAAA uncompressed", "AAAA", "2001:0DB8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{"AAAA compressed", "AAAA", "2001:db8::1", "2001:db8::1"},
+		{"invalid AAAA", "AAAA", "not-an-address", "not-an-address"},
+		{"CNAME uppercase", "CNAME", "WWW.Example.ORG.", "www.example.org."},
+		{"CNAME relative", "CNAME", "www.example.org", "www.example.org."},
+		{"NS", "NS", "NS1.example.org.", "ns1.example.org."},
+		{"PTR", "PTR", "Host.example.org.", "host.example.org."},
+		{"MX", "MX", "010  MAIL.example.org.", "10 mail.example.org."},
+		{"invalid MX", "MX", "mail.example.org.", "mail.example.org."},
+		{"SRV", "SRV", "0 5 05060 SIP.example.org.", "0 5 5060 sip.example.org."},
+		{"CAA", "CAA", "0 ISSUE \"letsencrypt.org\"", "0 issue \"letsencrypt.org\""},
+		{"CAA unquoted", "CAA", "0 issue letsencrypt.org", "0 issue \"letsencrypt.org\""},
+		{"TXT", "TXT", "\"v=spf1 -all\"", "\"v=spf1 -all\""},
+		{"TXT several strings", "TXT", "\"a\"   \"b\"", "\"a\" \"b\""},
+		{"TXT escaped quote", "TXT", "\"say \\\"hi\\\"\"", "\"say \\\"hi\\\"\""},
+		{"TXT decimal escape", "TXT", "\"\\065\\009\"", "\"A\\009\""},
+		{"TXT long string", "TXT", "\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"", "\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\" \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\""},
+		{"TXT already split", "TXT", "\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\" \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"", "\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\" \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\""},
+		{"invalid TXT", "TXT", "unquoted", "unquoted"},
+		{"unterminated TXT", "TXT", "\"unterminated", "\"unterminated"},
+		{"lowercase type", "aaaa", "2001:db8:0:0::1", "2001:db8::1"},
+		{"other type", "LUA", "A \"ifportup(443, {'192.0.2.1'})\"", "A \"ifportup(443, {'192.0.2.1'})\""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := Record(tc.rrType, tc.content)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecords(t *testing.T) {
+	got := Records("AAAA", []string{"2001:DB8::1", "2001:db8:0:0:0:0:0:2"})
+	want := []string{"2001:db8::1", "2001:db8::2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRecordIsIdempotent(t *testing.T) {
+	contents := map[string]string{
+		"AAAA": "2001:0db8::0001",
+		"MX":   "10 Mail.example.org",
+		"TXT":  "\"" + strings.Repeat("b", 600) + "\"",
+		"CAA":  "128 Iodef \"mailto:security@example.org\"",
+	}
+	for rrType, content := range contents {
+		once := Record(rrType, content)
+		if twice := Record(rrType, once); twice != once {
+			t.Errorf("%s: got %q, want %q", rrType, twice, once)
+		}
+	}
+}
+
+func TestTXT(t *testing.T) {
+	var testCases = []struct {
+		value string
+		want  string
+	}{
+		{"v=spf1 mx -all", `"v=spf1 mx -all"`},
+		{`say "hello"`, `"say \"hello\""`},
+		{strings.Repeat("a", 300), `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`},
+	}
+	for _, tc := range testCases {
+		if got := TXT(tc.value); got != tc.want {
+			t.Errorf("TXT(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+		if got := Record("TXT", TXT(tc.value)); got != tc.want {
+			t.Errorf("TXT(%q) is not normalized: %q", tc.value, got)
+		}
+	}
+}
@@ -0,0 +1,751 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package pdnsfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	apiPrefix = "/api/v1/servers/{server}"
+	// defaultTTL is the TTL of the SOA and NS records created along with a zone
+	defaultTTL = 3600
+)
+
+// readOnlyMetadata are the metadata kinds which cannot be written through the metadata endpoints
+var readOnlyMetadata = []powerdns.MetadataKind{
+	powerdns.MetadataAPIRectify, powerdns.MetadataAXFRMasterTSIG, powerdns.MetadataLuaAXFRScript,
+	"NSEC3NARROW", powerdns.MetadataNSEC3Param, powerdns.MetadataPresigned, powerdns.MetadataSOAEditAPI,
+}
+
+var zoneKinds = []powerdns.ZoneKind{
+	powerdns.NativeZoneKind, powerdns.MasterZoneKind, powerdns.SlaveZoneKind,
+	powerdns.ProducerZoneKind, powerdns.ConsumerZoneKind,
+}
+
+// patchRRset is a RRSet of a PATCH request, absent records and comments are left untouched
+type patchRRset struct {
+	Name       string              `json:"name"`
+	Type       powerdns.RRType     `json:"type"`
+	TTL        *uint32             `json:"ttl"`
+	ChangeType powerdns.ChangeType `json:"changetype"`
+	Records    *[]powerdns.Record  `json:"records"`
+	Comments   *[]powerdns.Comment `json:"comments"`
+}
+
+// metadata is the metadata of a zone as answered by PowerDNS, with an empty list rather than no list
+type metadata struct {
+	Kind     powerdns.MetadataKind `json:"kind"`
+	Metadata []string              `json:"metadata"`
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+apiPrefix+"/zones", s.listZones)
+	mux.HandleFunc("POST "+apiPrefix+"/zones", s.createZone)
+	mux.HandleFunc("GET "+apiPrefix+"/zones/{zone}", s.getZone)
+	mux.HandleFunc("PUT "+apiPrefix+"/zones/{zone}", s.changeZone)
+	mux.HandleFunc("PATCH "+apiPrefix+"/zones/{zone}", s.patchZone)
+	mux.HandleFunc("DELETE "+apiPrefix+"/zones/{zone}", s.deleteZone)
+	mux.HandleFunc("GET "+apiPrefix+"/zones/{zone}/export", s.exportZone)
+	mux.HandleFunc("PUT "+apiPrefix+"/zones/{zone}/notify", s.notifyZone)
+	mux.HandleFunc("PUT "+apiPrefix+"/zones/{zone}/rectify", s.rectifyZone)
+	mux.HandleFunc("PUT "+apiPrefix+"/zones/{zone}/axfr-retrieve", s.axfrRetrieveZone)
+	mux.HandleFunc("GET "+apiPrefix+"/zones/{zone}/metadata", s.listMetadata)
+	mux.HandleFunc("POST "+apiPrefix+"/zones/{zone}/metadata", s.createMetadata)
+	mux.HandleFunc("GET "+apiPrefix+"/zones/{zone}/metadata/{kind}", s.getMetadata)
+	mux.HandleFunc("PUT "+apiPrefix+"/zones/{zone}/metadata/{kind}", s.setMetadata)
+	mux.HandleFunc("DELETE "+apiPrefix+"/zones/{zone}/metadata/{kind}", s.deleteMetadata)
+	mux.HandleFunc("PUT "+apiPrefix+"/cache/flush", s.flushCache)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != APIKey {
+			writeError(w, &apiError{status: http.StatusUnauthorized, message: "Unauthorized"})
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+		i := slices.IndexFunc(s.failures, func(f failure) bool { return f.method == "" || f.method == r.Method })
+		var injected failure
+		if i >= 0 {
+			injected = s.failures[i]
+			s.failures = slices.Delete(s.failures, i, i+1)
+		}
+		s.mu.Unlock()
+		if i >= 0 {
+			writeError(w, &apiError{status: injected.status, message: injected.message})
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, map[string]string{"error": err.message})
+}
+
+func decode(r *http.Request, v any) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf("Could not parse JSON body: %v", err)}
+	}
+	return nil
+}
+
+// lookupZone returns the zone of the request, the lock of the Server must be held
+func (s *Server) lookupZone(r *http.Request) (*powerdns.Zone, *apiError) {
+	if r.PathValue("server") != VHost {
+		return nil, notFound()
+	}
+	zone, ok := s.zones[zoneKey(r.PathValue("zone"))]
+	if !ok {
+		return nil, notFound()
+	}
+	return zone, nil
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("server") != VHost {
+		writeError(w, notFound())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zones := make([]*powerdns.Zone, 0, len(s.zones))
+	for _, zone := range s.zones {
+		z := clone(zone)
+		z.RRsets = nil
+		zones = append(zones, z)
+	}
+	slices.SortFunc(zones, func(a, b *powerdns.Zone) int { return strings.Compare(*a.Name, *b.Name) })
+	writeJSON(w, http.StatusOK, zones)
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("server") != VHost {
+		writeError(w, notFound())
+		return
+	}
+	var zone powerdns.Zone
+	if err := decode(r, &zone); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	created, err := s.newZone(zone)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.zones[zoneKey(*created.Name)] = created
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// newZone validates a zone to create and sets its defaults and initial RRSets, as PowerDNS does
+func (s *Server) newZone(zone powerdns.Zone) (*powerdns.Zone, *apiError) {
+	name := ptr.Deref(zone.Name, "")
+	if name == "" {
+		return nil, unprocessable("Key 'name' not present or not a String")
+	}
+	if !strings.HasSuffix(name, ".") {
+		return nil, unprocessable("DNS Name '%s' is not canonical", name)
+	}
+	name = canonical(name)
+	if _, ok := s.zones[zoneKey(name)]; ok {
+		return nil, &apiError{status: http.StatusConflict, message: "Conflict"}
+	}
+	kind, err := zoneKind(zone.Kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range zone.Nameservers {
+		if !strings.HasSuffix(ns, ".") {
+			return nil, unprocessable("Nameserver is not canonical: '%s'", ns)
+		}
+	}
+
+	created := &powerdns.Zone{
+		ID:             powerdns.String(name),
+		Name:           powerdns.String(name),
+		Type:           powerdns.ZoneTypePtr(powerdns.ZoneZoneType),
+		URL:            powerdns.String(fmt.Sprintf("/api/v1/servers/%s/zones/%s", VHost, name)),
+		Kind:           powerdns.ZoneKindPtr(kind),
+		RRsets:         []powerdns.RRset{},
+		Serial:         powerdns.Uint32(0),
+		NotifiedSerial: powerdns.Uint32(0),
+		EditedSerial:   powerdns.Uint32(0),
+		Masters:        zone.Masters,
+		DNSsec:         powerdns.Bool(ptr.Deref(zone.DNSsec, false)),
+		Nsec3Param:     powerdns.String(ptr.Deref(zone.Nsec3Param, "")),
+		Nsec3Narrow:    powerdns.Bool(ptr.Deref(zone.Nsec3Narrow, false)),
+		Presigned:      powerdns.Bool(ptr.Deref(zone.Presigned, false)),
+		SOAEdit:        powerdns.String(ptr.Deref(zone.SOAEdit, "")),
+		SOAEditAPI:     powerdns.String(ptr.Deref(zone.SOAEditAPI, "DEFAULT")),
+		APIRectify:     powerdns.Bool(ptr.Deref(zone.APIRectify, false)),
+		Catalog:        powerdns.String(ptr.Deref(zone.Catalog, "")),
+		Account:        powerdns.String(ptr.Deref(zone.Account, "")),
+	}
+	if created.Masters == nil {
+		created.Masters = []string{}
+	}
+
+	// Secondary zones are filled by zone transfers, the other ones start with a SOA and the given nameservers
+	if kind != powerdns.SlaveZoneKind && kind != powerdns.ConsumerZoneKind {
+		created.Serial = powerdns.Uint32(1)
+		created.EditedSerial = powerdns.Uint32(1)
+		created.RRsets = append(created.RRsets, powerdns.RRset{
+			Name:    powerdns.String(name),
+			Type:    powerdns.RRTypePtr(powerdns.RRTypeSOA),
+			TTL:     powerdns.Uint32(defaultTTL),
+			Records: []powerdns.Record{{Content: powerdns.String(soaContent(name, 1)), Disabled: powerdns.Bool(false)}},
+		})
+		if len(zone.Nameservers) > 0 {
+			ns := powerdns.RRset{
+				Name: powerdns.String(name),
+				Type: powerdns.RRTypePtr(powerdns.RRTypeNS),
+				TTL:  powerdns.Uint32(defaultTTL),
+			}
+			for _, n := range zone.Nameservers {
+				ns.Records = append(ns.Records, powerdns.Record{Content: powerdns.String(n), Disabled: powerdns.Bool(false)})
+			}
+			created.RRsets = append(created.RRsets, ns)
+		}
+	}
+
+	// The RRSets given along with the zone are added as if they were patched
+	changes := make([]patchRRset, 0, len(zone.RRsets))
+	for _, rr := range zone.RRsets {
+		if len(zone.Nameservers) > 0 && ptr.Deref(rr.Type, "") == powerdns.RRTypeNS && canonical(ptr.Deref(rr.Name, "")) == name {
+			return nil, unprocessable("Nameservers list MUST NOT be mixed with zone-level NS in rrsets")
+		}
+		changes = append(changes, patchRRset{
+			Name:       ptr.Deref(rr.Name, ""),
+			Type:       ptr.Deref(rr.Type, ""),
+			TTL:        rr.TTL,
+			ChangeType: powerdns.ChangeTypeReplace,
+			Records:    &rr.Records,
+			Comments:   &rr.Comments,
+		})
+	}
+	if err := applyChanges(created, changes); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func zoneKind(kind *powerdns.ZoneKind) (powerdns.ZoneKind, *apiError) {
+	if kind == nil {
+		return "", unprocessable("Key 'kind' not present or not a String")
+	}
+	for _, k := range zoneKinds {
+		if strings.EqualFold(string(k), string(*kind)) {
+			return k, nil
+		}
+	}
+	return "", unprocessable("Zone kind '%s' is not supported", *kind)
+}
+
+func soaContent(zone string, serial uint32) string {
+	return fmt.Sprintf("a.misconfigured.dns.server.invalid. hostmaster.%s %d 10800 3600 604800 3600", zone, serial)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// The RRSets may be filtered by name, and by type along with a name
+	z := clone(zone)
+	if name := r.URL.Query().Get("rrset_name"); name != "" {
+		rrType := powerdns.RRType(r.URL.Query().Get("rrset_type"))
+		z.RRsets = slices.DeleteFunc(z.RRsets, func(rr powerdns.RRset) bool {
+			return *rr.Name != canonical(name) || (rrType != "" && *rr.Type != rrType)
+		})
+	}
+	if z.RRsets == nil {
+		z.RRsets = []powerdns.RRset{}
+	}
+	writeJSON(w, http.StatusOK, z)
+}
+
+func (s *Server) changeZone(w http.ResponseWriter, r *http.Request) {
+	var change powerdns.Zone
+	if err := decode(r, &change); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if change.Kind != nil {
+		kind, err := zoneKind(change.Kind)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		zone.Kind = powerdns.ZoneKindPtr(kind)
+	}
+	// The nameservers of an existing zone are only changed by patching its NS RRSet
+	if change.Masters != nil {
+		zone.Masters = change.Masters
+	}
+	if change.Account != nil {
+		zone.Account = change.Account
+	}
+	if change.Catalog != nil {
+		zone.Catalog = change.Catalog
+	}
+	if change.SOAEdit != nil {
+		zone.SOAEdit = change.SOAEdit
+	}
+	if change.SOAEditAPI != nil {
+		zone.SOAEditAPI = change.SOAEditAPI
+	}
+	if change.APIRectify != nil {
+		zone.APIRectify = change.APIRectify
+	}
+	if change.DNSsec != nil {
+		zone.DNSsec = change.DNSsec
+	}
+	if change.Nsec3Param != nil {
+		zone.Nsec3Param = change.Nsec3Param
+	}
+	if change.Nsec3Narrow != nil {
+		zone.Nsec3Narrow = change.Nsec3Narrow
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) patchZone(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		RRsets []patchRRset `json:"rrsets"`
+	}
+	if err := decode(r, &patch); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// A PATCH is applied as a whole or not at all
+	patched := clone(zone)
+	if err := applyChanges(patched, patch.RRsets); err != nil {
+		writeError(w, err)
+		return
+	}
+	bumpSerial(patched)
+	s.zones[zoneKey(*zone.Name)] = patched
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyChanges applies the REPLACE and DELETE changes of RRSets to the zone
+func applyChanges(zone *powerdns.Zone, changes []patchRRset) *apiError {
+	for _, change := range changes {
+		if change.Name == "" || !strings.HasSuffix(change.Name, ".") {
+			return unprocessable("DNS Name '%s' is not canonical", change.Name)
+		}
+		name := canonical(change.Name)
+		rrType := powerdns.RRType(strings.ToUpper(string(change.Type)))
+		if rrType == "" {
+			return unprocessable("Key 'type' not present or not a String")
+		}
+		if name != *zone.Name && !strings.HasSuffix(name, "."+*zone.Name) {
+			return unprocessable("RRset %s IN %s: Name is out of zone", name, rrType)
+		}
+		i := findRRset(zone.RRsets, name, rrType)
+
+		switch change.ChangeType {
+		case powerdns.ChangeTypeDelete:
+			if i >= 0 {
+				zone.RRsets = slices.Delete(zone.RRsets, i, i+1)
+			}
+		case powerdns.ChangeTypeReplace:
+			rrset := powerdns.RRset{Name: powerdns.String(name), Type: powerdns.RRTypePtr(rrType), Records: []powerdns.Record{}}
+			if i >= 0 {
+				rrset = zone.RRsets[i]
+			}
+			if change.TTL != nil {
+				rrset.TTL = change.TTL
+			}
+			if change.Records != nil {
+				records, err := replaceRecords(zone, name, rrType, *change.Records)
+				if err != nil {
+					return err
+				}
+				if len(records) > 0 && rrset.TTL == nil {
+					return unprocessable("Key 'ttl' not present or not an Integer")
+				}
+				rrset.Records = records
+			}
+			if change.Comments != nil {
+				rrset.Comments = replaceComments(*change.Comments)
+			}
+
+			// A RRSet without records nor comments does not exist
+			switch {
+			case len(rrset.Records) == 0 && len(rrset.Comments) == 0:
+				if i >= 0 {
+					zone.RRsets = slices.Delete(zone.RRsets, i, i+1)
+				}
+			case i >= 0:
+				zone.RRsets[i] = rrset
+			default:
+				zone.RRsets = append(zone.RRsets, rrset)
+			}
+		default:
+			return unprocessable("Changetype not understood")
+		}
+	}
+	sortRRsets(zone.RRsets)
+	return nil
+}
+
+// replaceRecords validates the records replacing the ones of a RRSet
+func replaceRecords(zone *powerdns.Zone, name string, rrType powerdns.RRType, records []powerdns.Record) ([]powerdns.Record, *apiError) {
+	replaced := make([]powerdns.Record, 0, len(records))
+	seen := map[string]bool{}
+	for _, record := range records {
+		content := ptr.Deref(record.Content, "")
+		if seen[content] {
+			return nil, unprocessable("Duplicate record in RRset %s IN %s with content \"%s\"", name, rrType, content)
+		}
+		seen[content] = true
+		if err := validateContent(name, rrType, content); err != nil {
+			return nil, err
+		}
+		replaced = append(replaced, powerdns.Record{Content: powerdns.String(content), Disabled: powerdns.Bool(ptr.Deref(record.Disabled, false))})
+	}
+	if len(replaced) == 0 {
+		return replaced, nil
+	}
+
+	// A CNAME is the only RRSet of its name
+	for _, rr := range zone.RRsets {
+		if *rr.Name != name || *rr.Type == rrType || len(rr.Records) == 0 {
+			continue
+		}
+		if rrType == powerdns.RRTypeCNAME {
+			return nil, unprocessable("RRset %s IN CNAME: Conflicts with pre-existing RRset", name)
+		}
+		if *rr.Type == powerdns.RRTypeCNAME {
+			return nil, unprocessable("RRset %s IN %s: Conflicts with pre-existing CNAME RRset", name, rrType)
+		}
+	}
+	if rrType == powerdns.RRTypeCNAME && len(replaced) > 1 {
+		return nil, unprocessable("RRset %s IN CNAME has more than one record", name)
+	}
+	return replaced, nil
+}
+
+// validateContent checks the content of the records whose format is simple to check
+func validateContent(name string, rrType powerdns.RRType, content string) *apiError {
+	switch rrType {
+	case powerdns.RRTypeA:
+		if ip := net.ParseIP(content); ip == nil || ip.To4() == nil {
+			return unprocessable("Record %s/A '%s': Parsing record content (try 'pdnsutil check-zone'): unable to parse IP address", name, content)
+		}
+	case powerdns.RRTypeAAAA:
+		if ip := net.ParseIP(content); ip == nil || ip.To4() != nil {
+			return unprocessable("Record %s/AAAA '%s': Parsing record content (try 'pdnsutil check-zone'): unable to parse IPv6 address", name, content)
+		}
+	case powerdns.RRTypeCNAME, powerdns.RRTypeNS, powerdns.RRTypePTR:
+		if !strings.HasSuffix(content, ".") {
+			return unprocessable("Record %s/%s '%s': Not in expected format (parsed as '%s.')", name, rrType, content, content)
+		}
+	}
+	return nil
+}
+
+// replaceComments sets the modification time of the new comments, as PowerDNS does
+func replaceComments(comments []powerdns.Comment) []powerdns.Comment {
+	replaced := make([]powerdns.Comment, 0, len(comments))
+	for _, c := range comments {
+		if ptr.Deref(c.ModifiedAt, 0) == 0 {
+			c.ModifiedAt = powerdns.Uint64(uint64(time.Now().Unix()))
+		}
+		replaced = append(replaced, c)
+	}
+	return replaced
+}
+
+// bumpSerial increments the serial of the zone and of its SOA record after a change
+func bumpSerial(zone *powerdns.Zone) {
+	serial := ptr.Deref(zone.Serial, 0) + 1
+	zone.Serial = powerdns.Uint32(serial)
+	zone.EditedSerial = powerdns.Uint32(serial)
+	i := findRRset(zone.RRsets, *zone.Name, powerdns.RRTypeSOA)
+	if i < 0 || len(zone.RRsets[i].Records) == 0 {
+		return
+	}
+	fields := strings.Fields(ptr.Deref(zone.RRsets[i].Records[0].Content, ""))
+	if len(fields) == 7 {
+		fields[2] = strconv.FormatUint(uint64(serial), 10)
+		zone.RRsets[i].Records[0].Content = powerdns.String(strings.Join(fields, " "))
+	}
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	delete(s.zones, zoneKey(*zone.Name))
+	delete(s.metadata, zoneKey(*zone.Name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) exportZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var export strings.Builder
+	for _, rr := range zone.RRsets {
+		for _, record := range rr.Records {
+			if ptr.Deref(record.Disabled, false) {
+				continue
+			}
+			fmt.Fprintf(&export, "%s\t%d\tIN\t%s\t%s\n", *rr.Name, ptr.Deref(rr.TTL, 0), *rr.Type, *record.Content)
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=us-ascii")
+	_, _ = w.Write([]byte(export.String()))
+}
+
+func (s *Server) notifyZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if *zone.Kind == powerdns.NativeZoneKind {
+		writeError(w, unprocessable("Domain '%s' is not a primary or secondary", *zone.Name))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"result": "Notification queued"})
+}
+
+func (s *Server) rectifyZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if ptr.Deref(zone.Presigned, false) {
+		writeError(w, unprocessable("Zone '%s' is a presigned zone, not rectifying.", *zone.Name))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"result": "Rectified"})
+}
+
+func (s *Server) axfrRetrieveZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if (*zone.Kind != powerdns.SlaveZoneKind && *zone.Kind != powerdns.ConsumerZoneKind) || len(zone.Masters) == 0 {
+		writeError(w, unprocessable("Domain '%s' is not a secondary domain (or has no primary defined)", *zone.Name))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"result": fmt.Sprintf("Added retrieval request for '%s' from primary %s", *zone.Name, zone.Masters[0]),
+	})
+}
+
+func (s *Server) listMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	list := []metadata{}
+	for kind, values := range s.metadata[zoneKey(*zone.Name)] {
+		list = append(list, metadata{Kind: kind, Metadata: slices.Clone(values)})
+	}
+	slices.SortFunc(list, func(a, b metadata) int { return strings.Compare(string(a.Kind), string(b.Kind)) })
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) createMetadata(w http.ResponseWriter, r *http.Request) {
+	var m metadata
+	if err := decode(r, &m); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := writableMetadata(m.Kind); err != nil {
+		writeError(w, err)
+		return
+	}
+	// Values are added to the existing ones of the kind
+	values := s.metadata[zoneKey(*zone.Name)][m.Kind]
+	for _, v := range m.Metadata {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	s.storeMetadata(*zone.Name, m.Kind, values)
+	writeJSON(w, http.StatusCreated, metadata{Kind: m.Kind, Metadata: slices.Clone(values)})
+}
+
+func (s *Server) getMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// An unset kind is answered with no values
+	kind := powerdns.MetadataKind(r.PathValue("kind"))
+	values := slices.Clone(s.metadata[zoneKey(*zone.Name)][kind])
+	if values == nil {
+		values = []string{}
+	}
+	writeJSON(w, http.StatusOK, metadata{Kind: kind, Metadata: values})
+}
+
+func (s *Server) setMetadata(w http.ResponseWriter, r *http.Request) {
+	var m metadata
+	if err := decode(r, &m); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	kind := powerdns.MetadataKind(r.PathValue("kind"))
+	if err := writableMetadata(kind); err != nil {
+		writeError(w, err)
+		return
+	}
+	values := slices.Clone(m.Metadata)
+	if values == nil {
+		values = []string{}
+	}
+	s.storeMetadata(*zone.Name, kind, values)
+	writeJSON(w, http.StatusOK, metadata{Kind: kind, Metadata: slices.Clone(values)})
+}
+
+func (s *Server) deleteMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, err := s.lookupZone(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	kind := powerdns.MetadataKind(r.PathValue("kind"))
+	if err := writableMetadata(kind); err != nil {
+		writeError(w, err)
+		return
+	}
+	delete(s.metadata[zoneKey(*zone.Name)], kind)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writableMetadata(kind powerdns.MetadataKind) *apiError {
+	if kind == "" {
+		return unprocessable("Key 'kind' not present or not a String")
+	}
+	if slices.Contains(readOnlyMetadata, kind) {
+		return unprocessable("Unsupported metadata kind '%s'", kind)
+	}
+	return nil
+}
+
+// storeMetadata sets the values of a metadata kind of the zone, an empty list removes the kind
+func (s *Server) storeMetadata(zone string, kind powerdns.MetadataKind, values []string) {
+	key := zoneKey(zone)
+	if len(values) == 0 {
+		delete(s.metadata[key], kind)
+		return
+	}
+	if s.metadata[key] == nil {
+		s.metadata[key] = map[powerdns.MetadataKind][]string{}
+	}
+	s.metadata[key][kind] = values
+}
+
+func (s *Server) flushCache(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("server") != VHost {
+		writeError(w, notFound())
+		return
+	}
+	domain := r.URL.Query().Get("domain")
+	if domain == "" {
+		writeError(w, unprocessable("No domain specified"))
+		return
+	}
+	// The fake does not cache anything
+	writeJSON(w, http.StatusOK, map[string]any{"count": 0, "result": "Flushed cache."})
+}
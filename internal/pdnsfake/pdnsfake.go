@@ -0,0 +1,199 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package pdnsfake serves an in-process fake of the PowerDNS HTTP API, so that tests exercise
+// the real go-powerdns client end-to-end without a live PowerDNS.
+//
+// The Server implements the endpoints used by the operator: zones, RRSets with the PATCH semantics
+// of PowerDNS (REPLACE/DELETE changetypes, comments kept unless given), metadata and zone actions,
+// answering the same status codes and error messages as PowerDNS.
+package pdnsfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/joeig/go-powerdns/v3"
+)
+
+const (
+	// APIKey is the API key expected by the Server
+	APIKey = "pdnsfake"
+	// VHost is the only server (virtual host) known by the Server
+	VHost = "localhost"
+)
+
+// Request is an API request received by the Server
+type Request struct {
+	Method string
+	Path   string
+}
+
+// failure is an error answered instead of the next request with the same method
+type failure struct {
+	method  string
+	status  int
+	message string
+}
+
+// Server is a fake PowerDNS API running on an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	zones    map[string]*powerdns.Zone
+	metadata map[string]map[powerdns.MetadataKind][]string
+	failures []failure
+	requests []Request
+}
+
+// New starts a Server without any zone, it must be closed by the caller
+func New() *Server {
+	s := &Server{
+		zones:    map[string]*powerdns.Zone{},
+		metadata: map[string]map[powerdns.MetadataKind][]string{},
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Client returns a go-powerdns client authenticated on the Server
+func (s *Server) Client() *powerdns.Client {
+	return powerdns.New(s.URL, VHost, powerdns.WithAPIKey(APIKey))
+}
+
+// FailNext answers the next request with the given method (any method if empty) with an error
+func (s *Server) FailNext(method string, status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, status: status, message: message})
+}
+
+// Requests returns the authenticated requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// Zone returns a copy of the zone with its RRSets, nil if it does not exist
+func (s *Server) Zone(name string) *powerdns.Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zones[zoneKey(name)]
+	if !ok {
+		return nil
+	}
+	return clone(zone)
+}
+
+// RRset returns a copy of the RRSet of the zone, nil if it does not exist
+func (s *Server) RRset(zone, name string, rrType powerdns.RRType) *powerdns.RRset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zoneKey(zone)]
+	if !ok {
+		return nil
+	}
+	i := findRRset(z.RRsets, canonical(name), rrType)
+	if i < 0 {
+		return nil
+	}
+	return clone(&z.RRsets[i])
+}
+
+// Metadata returns the values of a metadata kind of the zone
+func (s *Server) Metadata(zone string, kind powerdns.MetadataKind) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.metadata[zoneKey(zone)][kind])
+}
+
+// AddRRset adds or replaces a RRSet in an existing zone as is, e.g. to seed RRSets not created by the operator
+func (s *Server) AddRRset(zone string, rrset powerdns.RRset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[zoneKey(zone)]
+	if !ok {
+		return fmt.Errorf("zone %s not found", zone)
+	}
+	rrset.Name = powerdns.String(canonical(*rrset.Name))
+	rrset.ChangeType = nil
+	if i := findRRset(z.RRsets, *rrset.Name, *rrset.Type); i >= 0 {
+		z.RRsets[i] = *clone(&rrset)
+	} else {
+		z.RRsets = append(z.RRsets, *clone(&rrset))
+	}
+	sortRRsets(z.RRsets)
+	return nil
+}
+
+// apiError is an error answered by the API, PowerDNS sends its message as {"error": "..."}
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func notFound() *apiError {
+	return &apiError{status: http.StatusNotFound, message: "Not Found"}
+}
+
+func unprocessable(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, message: fmt.Sprintf(format, args...)}
+}
+
+// canonical returns the lowercase absolute form of a name
+func canonical(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+// zoneKey returns the key of a zone in the Server maps, zones are requested with or without the final dot
+func zoneKey(name string) string {
+	return canonical(name)
+}
+
+func findRRset(rrsets []powerdns.RRset, name string, rrType powerdns.RRType) int {
+	return slices.IndexFunc(rrsets, func(rr powerdns.RRset) bool {
+		return *rr.Name == name && *rr.Type == rrType
+	})
+}
+
+// sortRRsets sorts the RRSets by name and type, as listed by PowerDNS
+func sortRRsets(rrsets []powerdns.RRset) {
+	slices.SortStableFunc(rrsets, func(a, b powerdns.RRset) int {
+		if c := strings.Compare(*a.Name, *b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(string(*a.Type), string(*b.Type))
+	})
+}
+
+// clone returns a deep copy of v, the Server never shares its state with the callers
+func clone[T any](v *T) *T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	out := new(T)
+	if err := json.Unmarshal(data, out); err != nil {
+		panic(err)
+	}
+	return out
+}
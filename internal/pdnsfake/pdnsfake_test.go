@@ -0,0 +1,310 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package pdnsfake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+)
+
+// apiStatus returns the status code of an error of the PowerDNS API, 0 for any other error
+func apiStatus(err error) int {
+	if pdnsErr, ok := err.(*powerdns.Error); ok {
+		return pdnsErr.StatusCode
+	}
+	return 0
+}
+
+func recordsOf(rrset *powerdns.RRset) []string {
+	var records []string
+	for _, r := range rrset.Records {
+		records = append(records, *r.Content)
+	}
+	return records
+}
+
+func newZone(t *testing.T, s *Server, name string) {
+	t.Helper()
+	_, err := s.Client().Zones.Add(context.Background(), &powerdns.Zone{
+		Name:        powerdns.String(name),
+		Kind:        powerdns.ZoneKindPtr(powerdns.NativeZoneKind),
+		Nameservers: []string{"ns1.example.org.", "ns2.example.org."},
+	})
+	if err != nil {
+		t.Fatalf("unable to create zone %s: %v", name, err)
+	}
+}
+
+func TestZones(t *testing.T) {
+	s := New()
+	defer s.Close()
+	ctx := context.Background()
+	client := s.Client()
+
+	newZone(t, s, "example.org")
+	zone, err := client.Zones.Get(ctx, "example.org")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *zone.Name != "example.org." || *zone.Kind != powerdns.NativeZoneKind || *zone.Serial != 1 || *zone.SOAEditAPI != "DEFAULT" {
+		t.Errorf("unexpected zone %+v", zone)
+	}
+	ns := s.RRset("example.org", "example.org", powerdns.RRTypeNS)
+	if ns == nil || !slices.Equal(recordsOf(ns), []string{"ns1.example.org.", "ns2.example.org."}) || *ns.TTL != defaultTTL {
+		t.Errorf("unexpected NS RRset %+v", ns)
+	}
+	if s.RRset("example.org", "example.org", powerdns.RRTypeSOA) == nil {
+		t.Errorf("SOA RRset not created")
+	}
+
+	var testCases = []struct {
+		name   string
+		zone   powerdns.Zone
+		status int
+	}{
+		{"existing", powerdns.Zone{Name: powerdns.String("example.org."), Kind: powerdns.ZoneKindPtr(powerdns.NativeZoneKind)}, http.StatusConflict},
+		{"kind missing", powerdns.Zone{Name: powerdns.String("example.com.")}, http.StatusUnprocessableEntity},
+		{"unknown kind", powerdns.Zone{Name: powerdns.String("example.com."), Kind: powerdns.ZoneKindPtr("Primary")}, http.StatusUnprocessableEntity},
+		{"nameserver not canonical", powerdns.Zone{Name: powerdns.String("example.com."), Kind: powerdns.ZoneKindPtr(powerdns.NativeZoneKind), Nameservers: []string{"ns1.example.com"}}, http.StatusUnprocessableEntity},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.Zones.Add(ctx, &tc.zone)
+			if apiStatus(err) != tc.status {
+				t.Errorf("got error %v, want status %d", err, tc.status)
+			}
+		})
+	}
+
+	err = client.Zones.Change(ctx, "example.org", &powerdns.Zone{Kind: powerdns.ZoneKindPtr(powerdns.MasterZoneKind), Account: powerdns.String("operator")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zone = s.Zone("example.org.")
+	if *zone.Kind != powerdns.MasterZoneKind || *zone.Account != "operator" {
+		t.Errorf("zone not changed: %+v", zone)
+	}
+
+	if err := client.Zones.Delete(ctx, "example.org"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.Zones.Get(ctx, "example.org")
+	if apiStatus(err) != http.StatusNotFound || err.Error() != "Not Found" {
+		t.Errorf("got error %v, want Not Found", err)
+	}
+	if err := client.Zones.Delete(ctx, "example.org"); apiStatus(err) != http.StatusNotFound {
+		t.Errorf("got error %v, want Not Found", err)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	s := New()
+	defer s.Close()
+	ctx := context.Background()
+	client := s.Client()
+	newZone(t, s, "example.org")
+
+	err := client.Records.Change(ctx, "example.org", "www.example.org", powerdns.RRTypeA, 300, []string{"192.0.2.1", "192.0.2.2"},
+		powerdns.WithComments(powerdns.Comment{Content: powerdns.String("front"), Account: powerdns.String("operator")}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rrsets, err := client.Records.Get(ctx, "example.org", "www.example.org", powerdns.RRTypePtr(powerdns.RRTypeA))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rrsets) != 1 || *rrsets[0].TTL != 300 || !slices.Equal(recordsOf(&rrsets[0]), []string{"192.0.2.1", "192.0.2.2"}) {
+		t.Fatalf("unexpected RRsets %+v", rrsets)
+	}
+	if len(rrsets[0].Comments) != 1 || *rrsets[0].Comments[0].Account != "operator" || *rrsets[0].Comments[0].ModifiedAt == 0 {
+		t.Errorf("unexpected comments %+v", rrsets[0].Comments)
+	}
+	if serial := *s.Zone("example.org").Serial; serial != 2 {
+		t.Errorf("got serial %d, want 2", serial)
+	}
+
+	// A REPLACE without comments keeps the existing ones
+	if err := client.Records.Change(ctx, "example.org", "www.example.org", powerdns.RRTypeA, 60, []string{"192.0.2.3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	www := s.RRset("example.org", "www.example.org.", powerdns.RRTypeA)
+	if *www.TTL != 60 || !slices.Equal(recordsOf(www), []string{"192.0.2.3"}) || len(www.Comments) != 1 {
+		t.Errorf("unexpected RRset %+v", www)
+	}
+
+	var testCases = []struct {
+		name    string
+		rrName  string
+		rrType  powerdns.RRType
+		content []string
+		message string
+	}{
+		{"out of zone", "www.example.com", powerdns.RRTypeA, []string{"192.0.2.1"}, "Name is out of zone"},
+		{"invalid address", "api.example.org", powerdns.RRTypeA, []string{"2001:db8::1"}, "unable to parse IP address"},
+		{"duplicate record", "api.example.org", powerdns.RRTypeAAAA, []string{"2001:db8::1", "2001:db8::1"}, "Duplicate record"},
+		{"CNAME along with another RRset", "www.example.org", powerdns.RRTypeCNAME, []string{"front.example.org."}, "Conflicts with pre-existing RRset"},
+		{"RRset along with a CNAME", "alias.example.org", powerdns.RRTypeTXT, []string{`"text"`}, "Conflicts with pre-existing CNAME RRset"},
+	}
+	if err := client.Records.Change(ctx, "example.org", "alias.example.org", powerdns.RRTypeCNAME, 300, []string{"www.example.org."}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := client.Records.Change(ctx, "example.org", tc.rrName, tc.rrType, 300, tc.content)
+			if apiStatus(err) != http.StatusUnprocessableEntity || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("got error %v, want %q", err, tc.message)
+			}
+		})
+	}
+
+	if err := client.Records.Delete(ctx, "example.org", "www.example.org", powerdns.RRTypeA); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.RRset("example.org", "www.example.org", powerdns.RRTypeA) != nil {
+		t.Errorf("RRset not deleted")
+	}
+	if err := client.Records.Delete(ctx, "example.com", "www.example.com", powerdns.RRTypeA); err == nil || err.Error() != "Not Found" {
+		t.Errorf("got error %v, want Not Found", err)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	s := New()
+	defer s.Close()
+	ctx := context.Background()
+	client := s.Client()
+	newZone(t, s, "example.org")
+
+	metadata, err := client.Metadata.Get(ctx, "example.org", "ENABLE-LUA-RECORDS")
+	if err != nil || len(metadata.Metadata) != 0 {
+		t.Fatalf("got %+v, %v, want no values", metadata, err)
+	}
+	if _, err := client.Metadata.Set(ctx, "example.org", "ENABLE-LUA-RECORDS", []string{"1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Metadata.Create(ctx, "example.org", powerdns.MetadataAlsoNotify, []string{"192.0.2.1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Metadata.Create(ctx, "example.org", powerdns.MetadataAlsoNotify, []string{"192.0.2.2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Metadata("example.org", "ENABLE-LUA-RECORDS"); !slices.Equal(got, []string{"1"}) {
+		t.Errorf("got %v, want [1]", got)
+	}
+	if got := s.Metadata("example.org", powerdns.MetadataAlsoNotify); !slices.Equal(got, []string{"192.0.2.1", "192.0.2.2"}) {
+		t.Errorf("got %v, want both addresses", got)
+	}
+	list, err := client.Metadata.List(ctx, "example.org")
+	if err != nil || len(list) != 2 {
+		t.Errorf("got %+v, %v, want 2 kinds", list, err)
+	}
+
+	if _, err := client.Metadata.Set(ctx, "example.org", powerdns.MetadataPresigned, []string{"1"}); apiStatus(err) != http.StatusUnprocessableEntity {
+		t.Errorf("got error %v, want read-only kind rejected", err)
+	}
+	if err := client.Metadata.Delete(ctx, "example.org", powerdns.MetadataAlsoNotify); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Metadata("example.org", powerdns.MetadataAlsoNotify); len(got) != 0 {
+		t.Errorf("got %v, want no values", got)
+	}
+	if _, err := client.Metadata.Get(ctx, "example.com", "ENABLE-LUA-RECORDS"); apiStatus(err) != http.StatusNotFound {
+		t.Errorf("got error %v, want Not Found", err)
+	}
+}
+
+func TestActions(t *testing.T) {
+	s := New()
+	defer s.Close()
+	ctx := context.Background()
+	client := s.Client()
+	newZone(t, s, "example.org")
+
+	if _, err := client.Zones.Notify(ctx, "example.org"); apiStatus(err) != http.StatusUnprocessableEntity {
+		t.Errorf("got error %v, want Native zone rejected", err)
+	}
+	if err := client.Zones.Change(ctx, "example.org", &powerdns.Zone{Kind: powerdns.ZoneKindPtr(powerdns.MasterZoneKind)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := client.Zones.Notify(ctx, "example.org")
+	if err != nil || *result.Result != "Notification queued" {
+		t.Errorf("got %v, %v", result, err)
+	}
+	if _, err := client.Zones.AxfrRetrieve(ctx, "example.org"); apiStatus(err) != http.StatusUnprocessableEntity {
+		t.Errorf("got error %v, want primary zone rejected", err)
+	}
+	flush, err := client.Servers.CacheFlush(ctx, VHost, "example.org")
+	if err != nil || *flush.Result != "Flushed cache." {
+		t.Errorf("got %v, %v", flush, err)
+	}
+	export, err := client.Zones.Export(ctx, "example.org")
+	if err != nil || !strings.Contains(string(export), "example.org.\t3600\tIN\tNS\tns1.example.org.") {
+		t.Errorf("got %q, %v", export, err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := New()
+	defer s.Close()
+	ctx := context.Background()
+	newZone(t, s, "example.org")
+
+	// Requests with a wrong API key are rejected
+	_, err := powerdns.New(s.URL, VHost, powerdns.WithAPIKey("wrong")).Zones.Get(ctx, "example.org")
+	if apiStatus(err) != http.StatusUnauthorized {
+		t.Errorf("got error %v, want Unauthorized", err)
+	}
+	// Requests on another server are not found
+	_, err = powerdns.New(s.URL, "other", powerdns.WithAPIKey(APIKey)).Zones.Get(ctx, "example.org")
+	if apiStatus(err) != http.StatusNotFound {
+		t.Errorf("got error %v, want Not Found", err)
+	}
+
+	// Only the next request with the given method fails
+	s.FailNext(http.MethodPatch, http.StatusInternalServerError, "Backend error")
+	if _, err := s.Client().Zones.Get(ctx, "example.org"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = s.Client().Records.Change(ctx, "example.org", "www.example.org", powerdns.RRTypeA, 300, []string{"192.0.2.1"})
+	var pdnsErr *powerdns.Error
+	if !errors.As(err, &pdnsErr) || pdnsErr.StatusCode != http.StatusInternalServerError || pdnsErr.Message != "Backend error" {
+		t.Errorf("got error %v, want injected failure", err)
+	}
+	if err := s.Client().Records.Change(ctx, "example.org", "www.example.org", powerdns.RRTypeA, 300, []string{"192.0.2.1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// RRsets are seeded as is
+	err = s.AddRRset("example.org", powerdns.RRset{
+		Name:    powerdns.String("foreign.example.org"),
+		Type:    powerdns.RRTypePtr(powerdns.RRTypeTXT),
+		TTL:     powerdns.Uint32(300),
+		Records: []powerdns.Record{{Content: powerdns.String(`"manual"`)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rrset := s.RRset("example.org", "foreign.example.org.", powerdns.RRTypeTXT); rrset == nil || len(rrset.Comments) != 0 {
+		t.Errorf("unexpected RRset %+v", rrset)
+	}
+
+	requests := s.Requests()
+	if last := requests[len(requests)-1]; last.Method != http.MethodPatch || last.Path != "/api/v1/servers/localhost/zones/example.org." {
+		t.Errorf("unexpected last request %+v", last)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package policy evaluates the ZonePolicies restricting which DNS names a namespace may manage.
+// It is shared by the admission webhooks and the reconcilers.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// ForNamespace returns the ZonePolicies whose namespace selector matches the namespace
+func ForNamespace(ctx context.Context, c client.Reader, namespace string) ([]dnsv1alpha2.ZonePolicy, error) {
+	var policies dnsv1alpha2.ZonePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return nil, err
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, err
+	}
+
+	var result []dnsv1alpha2.ZonePolicy
+	for _, p := range policies.Items {
+		selector, err := namespaceSelector(p)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// SelectedNamespaces returns the names of the namespaces the ZonePolicy applies to
+func SelectedNamespaces(ctx context.Context, c client.Reader, p *dnsv1alpha2.ZonePolicy) ([]string, error) {
+	selector, err := namespaceSelector(*p)
+	if err != nil {
+		return nil, err
+	}
+	var namespaces corev1.NamespaceList
+	if err := c.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		result = append(result, ns.Name)
+	}
+	return result, nil
+}
+
+// namespaceSelector returns the selector of the namespaces the ZonePolicy applies to, all of them if not set
+func namespaceSelector(p dnsv1alpha2.ZonePolicy) (labels.Selector, error) {
+	if p.Spec.NamespaceSelector == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector in ZonePolicy %s: %w", p.Name, err)
+	}
+	return selector, nil
+}
+
+// CheckZone returns an error if none of the policies allows a Zone named zoneName.
+// When no policy applies, every Zone is allowed.
+func CheckZone(policies []dnsv1alpha2.ZonePolicy, zoneName string) error {
+	return check(policies, func(p dnsv1alpha2.ZonePolicy) string {
+		return zoneViolation(p, zoneName)
+	})
+}
+
+// CheckRRset returns an error if none of the policies allows the RRset.
+// When no policy applies, every RRset is allowed.
+func CheckRRset(policies []dnsv1alpha2.ZonePolicy, rrset dnsv1alpha2.GenericRRset) error {
+	return check(policies, func(p dnsv1alpha2.ZonePolicy) string {
+		return rrsetViolation(p, rrset)
+	})
+}
+
+// check returns nil if at least one policy has no violation, an error listing all violations otherwise
+func check(policies []dnsv1alpha2.ZonePolicy, violation func(dnsv1alpha2.ZonePolicy) string) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	violations := make([]string, 0, len(policies))
+	for _, p := range policies {
+		v := violation(p)
+		if v == "" {
+			return nil
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s", p.Name, v))
+	}
+	return fmt.Errorf("not allowed by ZonePolicy (%s)", strings.Join(violations, "; "))
+}
+
+func zoneViolation(p dnsv1alpha2.ZonePolicy, zoneName string) string {
+	if len(p.Spec.AllowedZoneSuffixes) == 0 {
+		return ""
+	}
+	name := normalize(zoneName)
+	for _, s := range p.Spec.AllowedZoneSuffixes {
+		suffix := normalize(s)
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("zone %s is not within %s", name, strings.Join(p.Spec.AllowedZoneSuffixes, ", "))
+}
+
+func rrsetViolation(p dnsv1alpha2.ZonePolicy, rrset dnsv1alpha2.GenericRRset) string {
+	spec := rrset.GetSpec()
+	if len(p.Spec.AllowedTypes) > 0 && !containsFold(p.Spec.AllowedTypes, spec.Type) {
+		return fmt.Sprintf("type %s is not in %s", spec.Type, strings.Join(p.Spec.AllowedTypes, ", "))
+	}
+	if p.Spec.MinTTL != nil && spec.TTL < *p.Spec.MinTTL {
+		return fmt.Sprintf("TTL %d is lower than %d", spec.TTL, *p.Spec.MinTTL)
+	}
+	if p.Spec.MaxTTL != nil && spec.TTL > *p.Spec.MaxTTL {
+		return fmt.Sprintf("TTL %d is greater than %d", spec.TTL, *p.Spec.MaxTTL)
+	}
+	// Record patterns only restrict names inside ClusterZones, namespaced Zones are checked on their own
+	if spec.ZoneRef.Kind == "ClusterZone" && len(p.Spec.AllowedRecordPatterns) > 0 {
+		fqdn := FQDN(rrset)
+		for _, pattern := range p.Spec.AllowedRecordPatterns {
+			if MatchPattern(pattern, fqdn) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("name %s does not match %s", fqdn, strings.Join(p.Spec.AllowedRecordPatterns, ", "))
+	}
+	return ""
+}
+
+// CheckClusterZoneAccess returns an error if the ClusterZone does not allow RRsets from namespace,
+// or if the RRset name is not within the allowed suffix
+func CheckClusterZoneAccess(zone *dnsv1alpha2.ClusterZone, namespace *corev1.Namespace, rrset dnsv1alpha2.GenericRRset) error {
+	allowed := zone.Spec.AllowedNamespaces
+	if allowed == nil {
+		return nil
+	}
+
+	if len(allowed.Names) > 0 || allowed.Selector != nil {
+		namespaceAllowed := slices.Contains(allowed.Names, namespace.Name)
+		if !namespaceAllowed && allowed.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+			if err != nil {
+				return fmt.Errorf("invalid namespace selector in ClusterZone %s: %w", zone.Name, err)
+			}
+			namespaceAllowed = selector.Matches(labels.Set(namespace.Labels))
+		}
+		if !namespaceAllowed {
+			return fmt.Errorf("namespace %s is not allowed to reference ClusterZone %s", namespace.Name, zone.Name)
+		}
+	}
+
+	if allowed.NameSuffix != nil {
+		fqdn := FQDN(rrset)
+		suffix := normalize(*allowed.NameSuffix)
+		if fqdn != suffix && !strings.HasSuffix(fqdn, "."+suffix) {
+			return fmt.Errorf("name %s is not within %s in ClusterZone %s", fqdn, suffix, zone.Name)
+		}
+	}
+	return nil
+}
+
+// ReferenceGranted reports whether one of the grants allows RRsets from namespace to reference the Zone zoneName.
+// The grants must be the ones of the Zone namespace.
+func ReferenceGranted(grants []dnsv1alpha2.ZoneReferenceGrant, namespace, zoneName string) bool {
+	for _, g := range grants {
+		fromGranted := slices.ContainsFunc(g.Spec.From, func(f dnsv1alpha2.ZoneReferenceGrantFrom) bool {
+			return f.Namespace == namespace
+		})
+		toGranted := slices.ContainsFunc(g.Spec.To, func(t dnsv1alpha2.ZoneReferenceGrantTo) bool {
+			return t.Name == nil || *t.Name == zoneName
+		})
+		if fromGranted && toGranted {
+			return true
+		}
+	}
+	return false
+}
+
+// FQDN returns the fully qualified name of the RRset, without trailing dot
+func FQDN(rrset dnsv1alpha2.GenericRRset) string {
+	name := rrset.GetSpec().Name
+	if !strings.HasSuffix(name, ".") {
+		name = name + "." + rrset.GetSpec().ZoneRef.Name
+	}
+	return normalize(name)
+}
+
+// MatchPattern reports whether name matches pattern, '*' matching any sequence of characters,
+// name being lowercase and without trailing dot, as returned by FQDN
+func MatchPattern(pattern, name string) bool {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(normalize(pattern)), `\*`, `.*`)
+	matched, _ := regexp.MatchString("^"+expr+"$", name)
+	return matched
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestForNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = dnsv1alpha2.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+		&dnsv1alpha2.ZonePolicy{ObjectMeta: metav1.ObjectMeta{Name: "all"}, Spec: dnsv1alpha2.ZonePolicySpec{NamespaceSelector: &metav1.LabelSelector{}}},
+		&dnsv1alpha2.ZonePolicy{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&dnsv1alpha2.ZonePolicy{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}, Spec: dnsv1alpha2.ZonePolicySpec{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}},
+	).Build()
+
+	var testCases = []struct {
+		namespace string
+		want      []string
+	}{
+		{"team-a", []string{"all", "default", "team-a"}},
+		{"team-b", []string{"all", "default"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.namespace, func(t *testing.T) {
+			policies, err := ForNamespace(context.Background(), cl, tc.namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, p := range policies {
+				got = append(got, p.Name)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelectedNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = dnsv1alpha2.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+	).Build()
+
+	var testCases = []struct {
+		description string
+		selector    *metav1.LabelSelector
+		want        []string
+	}{
+		{"no selector", nil, []string{"team-a", "team-b"}},
+		{"empty selector", &metav1.LabelSelector{}, []string{"team-a", "team-b"}},
+		{"label selector", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, []string{"team-a"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p := &dnsv1alpha2.ZonePolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: dnsv1alpha2.ZonePolicySpec{NamespaceSelector: tc.selector}}
+			got, err := SelectedNamespaces(context.Background(), cl, p)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckZone(t *testing.T) {
+	policies := []dnsv1alpha2.ZonePolicy{
+		{ObjectMeta: metav1.ObjectMeta{Name: "apps"}, Spec: dnsv1alpha2.ZonePolicySpec{AllowedZoneSuffixes: []string{"apps.example.org"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "internal"}, Spec: dnsv1alpha2.ZonePolicySpec{AllowedZoneSuffixes: []string{"internal.example.org."}}},
+	}
+
+	var testCases = []struct {
+		description string
+		policies    []dnsv1alpha2.ZonePolicy
+		zoneName    string
+		allowed     bool
+	}{
+		{"no policy", nil, "example.com", true},
+		{"suffix itself", policies, "apps.example.org", true},
+		{"subdomain of first suffix", policies, "myapp.apps.example.org", true},
+		{"subdomain of second suffix", policies, "MyApp.Internal.example.org.", true},
+		{"parent domain", policies, "example.org", false},
+		{"partial label", policies, "myapps.example.org", false},
+		{"other domain", policies, "example.com", false},
+		{"policy without restriction", []dnsv1alpha2.ZonePolicy{{ObjectMeta: metav1.ObjectMeta{Name: "open"}}}, "example.com", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckZone(tc.policies, tc.zoneName)
+			if allowed := err == nil; allowed != tc.allowed {
+				t.Errorf("got allowed=%v (%v), want %v", allowed, err, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestCheckRRset(t *testing.T) {
+	policies := []dnsv1alpha2.ZonePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "myapp"},
+			Spec: dnsv1alpha2.ZonePolicySpec{
+				AllowedRecordPatterns: []string{"*.myapp.example.org", "myapp.example.org"},
+				AllowedTypes:          []string{"A", "AAAA", "CNAME"},
+				MinTTL:                ptr.To(uint32(60)),
+				MaxTTL:                ptr.To(uint32(3600)),
+			},
+		},
+	}
+	rrset := func(name, zoneKind, rrType string, ttl uint32) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{
+			Name:    name,
+			Type:    rrType,
+			TTL:     ttl,
+			ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: zoneKind},
+		}}
+	}
+
+	var testCases = []struct {
+		description string
+		rrset       *dnsv1alpha2.RRset
+		allowed     bool
+	}{
+		{"allowed name in ClusterZone", rrset("front.myapp", "ClusterZone", "A", 300), true},
+		{"allowed deep name in ClusterZone", rrset("a.b.myapp", "ClusterZone", "A", 300), true},
+		{"allowed absolute name in ClusterZone", rrset("myapp.example.org.", "ClusterZone", "CNAME", 300), true},
+		{"forbidden name in ClusterZone", rrset("front.otherapp", "ClusterZone", "A", 300), false},
+		{"any name in Zone", rrset("front.otherapp", "Zone", "A", 300), true},
+		{"lowercase type", rrset("front.myapp", "ClusterZone", "aaaa", 300), true},
+		{"forbidden type", rrset("front.myapp", "ClusterZone", "TXT", 300), false},
+		{"TTL too low", rrset("front.myapp", "Zone", "A", 30), false},
+		{"TTL too high", rrset("front.myapp", "Zone", "A", 86400), false},
+		{"TTL on bound", rrset("front.myapp", "Zone", "A", 3600), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckRRset(policies, tc.rrset)
+			if allowed := err == nil; allowed != tc.allowed {
+				t.Errorf("got allowed=%v (%v), want %v", allowed, err, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestCheckClusterZoneAccess(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}}
+	rrset := func(name string) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: name, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}}}
+	}
+	zone := func(allowed *dnsv1alpha2.AllowedNamespaces) *dnsv1alpha2.ClusterZone {
+		return &dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Spec: dnsv1alpha2.ClusterZoneSpec{AllowedNamespaces: allowed}}
+	}
+
+	var testCases = []struct {
+		description string
+		zone        *dnsv1alpha2.ClusterZone
+		rrset       *dnsv1alpha2.RRset
+		allowed     bool
+	}{
+		{"no restriction", zone(nil), rrset("front"), true},
+		{"namespace in names", zone(&dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b", "team-a"}}), rrset("front"), true},
+		{"namespace not in names", zone(&dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b"}}), rrset("front"), false},
+		{"namespace matching selector", zone(&dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b"}, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}), rrset("front"), true},
+		{"namespace not matching selector", zone(&dnsv1alpha2.AllowedNamespaces{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}}), rrset("front"), false},
+		{"name within suffix", zone(&dnsv1alpha2.AllowedNamespaces{NameSuffix: ptr.To("team-a.example.org")}), rrset("front.team-a"), true},
+		{"name equal to suffix", zone(&dnsv1alpha2.AllowedNamespaces{NameSuffix: ptr.To("team-a.example.org.")}), rrset("team-a.example.org."), true},
+		{"name outside suffix", zone(&dnsv1alpha2.AllowedNamespaces{Names: []string{"team-a"}, NameSuffix: ptr.To("team-a.example.org")}), rrset("front.team-b"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckClusterZoneAccess(tc.zone, namespace, tc.rrset)
+			if allowed := err == nil; allowed != tc.allowed {
+				t.Errorf("got allowed=%v (%v), want %v", allowed, err, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestReferenceGranted(t *testing.T) {
+	grants := []dnsv1alpha2.ZoneReferenceGrant{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "platform"},
+			Spec: dnsv1alpha2.ZoneReferenceGrantSpec{
+				From: []dnsv1alpha2.ZoneReferenceGrantFrom{{Namespace: "team-a"}},
+				To:   []dnsv1alpha2.ZoneReferenceGrantTo{{Name: ptr.To("example.org")}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "platform"},
+			Spec: dnsv1alpha2.ZoneReferenceGrantSpec{
+				From: []dnsv1alpha2.ZoneReferenceGrantFrom{{Namespace: "team-b"}, {Namespace: "team-c"}},
+				To:   []dnsv1alpha2.ZoneReferenceGrantTo{{}},
+			},
+		},
+	}
+
+	var testCases = []struct {
+		description string
+		namespace   string
+		zoneName    string
+		granted     bool
+	}{
+		{"granted zone", "team-a", "example.org", true},
+		{"other zone", "team-a", "example.com", false},
+		{"all zones granted", "team-c", "example.com", true},
+		{"namespace without grant", "team-d", "example.org", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if granted := ReferenceGranted(grants, tc.namespace, tc.zoneName); granted != tc.granted {
+				t.Errorf("got %v, want %v", granted, tc.granted)
+			}
+		})
+	}
+}
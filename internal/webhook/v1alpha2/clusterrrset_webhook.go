@@ -0,0 +1,89 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// log is for logging in this package.
+var clusterrrsetlog = logf.Log.WithName("clusterrrset-resource")
+
+// SetupClusterRRsetWebhookWithManager registers the webhook for ClusterRRset in the manager.
+func SetupClusterRRsetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&dnsv1alpha2.ClusterRRset{}).
+		WithValidator(&ClusterRRsetCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-clusterrrset,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=clusterrrsets,verbs=create;update,versions=v1alpha2,name=vclusterrrset-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// ClusterRRsetCustomValidator validates the LUA records of ClusterRRsets,
+// and denies the creation of a ClusterRRset whose name and type are already declared by another RRset/ClusterRRset
+type ClusterRRsetCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ClusterRRsetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterRRset.
+func (v *ClusterRRsetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterRRset, ok := obj.(*dnsv1alpha2.ClusterRRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterRRset object but got %T", obj)
+	}
+	clusterrrsetlog.V(1).Info("Validation for ClusterRRset upon creation", "name", clusterRRset.GetName())
+
+	if errs := validateLua(&clusterRRset.Spec); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(dnsv1alpha2.GroupVersion.WithKind("ClusterRRset").GroupKind(), clusterRRset.Name, errs)
+	}
+	return nil, validateRRsetUniqueness(ctx, v.Client, clusterRRset, dnsv1alpha2.GroupVersion.WithResource("clusterrrsets").GroupResource())
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterRRset.
+func (v *ClusterRRsetCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	clusterRRset, ok := newObj.(*dnsv1alpha2.ClusterRRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterRRset object for the newObj but got %T", newObj)
+	}
+	oldClusterRRset, ok := oldObj.(*dnsv1alpha2.ClusterRRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterRRset object for the oldObj but got %T", oldObj)
+	}
+	clusterrrsetlog.V(1).Info("Validation for ClusterRRset upon update", "name", clusterRRset.GetName())
+
+	// Metadata-only updates (finalizers, owner references) must never be blocked
+	if equality.Semantic.DeepEqual(oldClusterRRset.Spec, clusterRRset.Spec) {
+		return nil, nil
+	}
+	if errs := validateLua(&clusterRRset.Spec); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(dnsv1alpha2.GroupVersion.WithKind("ClusterRRset").GroupKind(), clusterRRset.Name, errs)
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterRRset.
+func (v *ClusterRRsetCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
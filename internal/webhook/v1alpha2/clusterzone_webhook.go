@@ -0,0 +1,73 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// log is for logging in this package.
+var clusterzonelog = logf.Log.WithName("clusterzone-resource")
+
+// SetupClusterZoneWebhookWithManager registers the webhook for ClusterZone in the manager.
+func SetupClusterZoneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&dnsv1alpha2.ClusterZone{}).
+		WithValidator(&ClusterZoneCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-clusterzone,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=clusterzones,verbs=create;delete,versions=v1alpha2,name=vclusterzone-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// ClusterZoneCustomValidator denies the creation of a ClusterZone already declared by another Zone/ClusterZone,
+// and denies the deletion of catalogs which still have members
+type ClusterZoneCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ClusterZoneCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterZone.
+func (v *ClusterZoneCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterZone, ok := obj.(*dnsv1alpha2.ClusterZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterZone object but got %T", obj)
+	}
+	clusterzonelog.V(1).Info("Validation for ClusterZone upon creation", "name", clusterZone.GetName())
+
+	return nil, validateZoneUniqueness(ctx, v.Client, clusterZone.Name, dnsv1alpha2.GroupVersion.WithResource("clusterzones").GroupResource())
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterZone.
+func (v *ClusterZoneCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterZone.
+func (v *ClusterZoneCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterZone, ok := obj.(*dnsv1alpha2.ClusterZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterZone object but got %T", obj)
+	}
+	clusterzonelog.V(1).Info("Validation for ClusterZone upon deletion", "name", clusterZone.GetName())
+
+	return nil, validateCatalogDeletion(ctx, v.Client, clusterZone.Name, clusterZone.Spec.Kind, dnsv1alpha2.GroupVersion.WithResource("clusterzones").GroupResource())
+}
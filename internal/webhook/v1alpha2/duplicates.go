@@ -0,0 +1,73 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// The duplicates are looked up in the claim indexes registered by the reconcilers, holding all the objects declaring a DNS entry.
+// Only the creation of a duplicate is denied: the reconcilers still elect the oldest object among duplicates
+// created while the webhook was disabled.
+
+// duplicatedZone returns the Zone/ClusterZone already declaring the DNS name, "" if none.
+// An object being deleted is not a duplicate, to allow its replacement
+func duplicatedZone(ctx context.Context, cl client.Reader, name string) (string, error) {
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Entry.Claim": name}); err != nil {
+		return "", err
+	}
+	for _, z := range zones.Items {
+		if z.DeletionTimestamp.IsZero() {
+			return fmt.Sprintf("Zone %s/%s", z.Namespace, z.Name), nil
+		}
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Entry.Claim": name}); err != nil {
+		return "", err
+	}
+	for _, z := range clusterZones.Items {
+		if z.DeletionTimestamp.IsZero() {
+			return fmt.Sprintf("ClusterZone %s", z.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// duplicatedRRset returns the RRset/ClusterRRset already declaring the DNS name and type of the RRset, "" if none.
+// An object being deleted is not a duplicate, to allow its replacement
+func duplicatedRRset(ctx context.Context, cl client.Reader, rrset dnsv1alpha2.GenericRRset) (string, error) {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets, client.MatchingFields{"RRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(rrset)}); err != nil {
+		return "", err
+	}
+	for _, r := range rrsets.Items {
+		if r.DeletionTimestamp.IsZero() {
+			return fmt.Sprintf("RRset %s/%s", r.Namespace, r.Name), nil
+		}
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Claim": dnsv1alpha2.RRsetClaimKey(rrset)}); err != nil {
+		return "", err
+	}
+	for _, r := range clusterRRsets.Items {
+		if r.DeletionTimestamp.IsZero() {
+			return fmt.Sprintf("ClusterRRset %s", r.Name), nil
+		}
+	}
+	return "", nil
+}
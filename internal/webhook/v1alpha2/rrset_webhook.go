@@ -0,0 +1,136 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/lua"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
+)
+
+// log is for logging in this package.
+var rrsetlog = logf.Log.WithName("rrset-resource")
+
+// SetupRRsetWebhookWithManager registers the webhook for RRset in the manager.
+func SetupRRsetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&dnsv1alpha2.RRset{}).
+		WithValidator(&RRsetCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-rrset,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=rrsets,verbs=create;update,versions=v1alpha2,name=vrrset-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// RRsetCustomValidator validates the LUA records of RRsets, and RRsets against the ZonePolicies applying to their namespace,
+// and denies the creation of a RRset whose name and type are already declared by another RRset/ClusterRRset
+type RRsetCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &RRsetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RRset.
+func (v *RRsetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rrset, ok := obj.(*dnsv1alpha2.RRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a RRset object but got %T", obj)
+	}
+	rrsetlog.V(1).Info("Validation for RRset upon creation", "name", rrset.GetName(), "namespace", rrset.GetNamespace())
+
+	if err := v.validate(ctx, rrset); err != nil {
+		return nil, err
+	}
+	return nil, validateRRsetUniqueness(ctx, v.Client, rrset, dnsv1alpha2.GroupVersion.WithResource("rrsets").GroupResource())
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RRset.
+func (v *RRsetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rrset, ok := newObj.(*dnsv1alpha2.RRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a RRset object for the newObj but got %T", newObj)
+	}
+	oldRRset, ok := oldObj.(*dnsv1alpha2.RRset)
+	if !ok {
+		return nil, fmt.Errorf("expected a RRset object for the oldObj but got %T", oldObj)
+	}
+	rrsetlog.V(1).Info("Validation for RRset upon update", "name", rrset.GetName(), "namespace", rrset.GetNamespace())
+
+	// Metadata-only updates (finalizers, owner references) must never be blocked,
+	// otherwise a RRset violating a policy created afterwards could not be deleted
+	if equality.Semantic.DeepEqual(oldRRset.Spec, rrset.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, rrset)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RRset.
+func (v *RRsetCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *RRsetCustomValidator) validate(ctx context.Context, rrset *dnsv1alpha2.RRset) error {
+	if errs := validateLua(&rrset.Spec); len(errs) > 0 {
+		return apierrors.NewInvalid(dnsv1alpha2.GroupVersion.WithKind("RRset").GroupKind(), rrset.Name, errs)
+	}
+
+	policies, err := policy.ForNamespace(ctx, v.Client, rrset.Namespace)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if err := policy.CheckRRset(policies, rrset); err != nil {
+		return apierrors.NewForbidden(dnsv1alpha2.GroupVersion.WithResource("rrsets").GroupResource(), rrset.Name, err)
+	}
+	return nil
+}
+
+// validateRRsetUniqueness denies a RRset/ClusterRRset whose DNS name and type are already declared by another one
+func validateRRsetUniqueness(ctx context.Context, cl client.Reader, rrset dnsv1alpha2.GenericRRset, resource schema.GroupResource) error {
+	existing, err := duplicatedRRset(ctx, cl, rrset)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if existing != "" {
+		return apierrors.NewForbidden(resource, rrset.GetName(), fmt.Errorf("the RRset is already declared by %s", existing))
+	}
+	return nil
+}
+
+// validateLua checks the LUA record built from the spec, or the LUA records set as is
+func validateLua(spec *dnsv1alpha2.RRsetSpec) field.ErrorList {
+	var errs field.ErrorList
+	if spec.Lua != nil {
+		if _, err := lua.Content(spec.Lua); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "lua"), spec.Lua, err.Error()))
+		}
+	}
+	if spec.Type == "LUA" {
+		for i, r := range spec.Records {
+			if err := lua.ValidateRecord(r); err != nil {
+				errs = append(errs, field.Invalid(field.NewPath("spec", "records").Index(i), r, err.Error()))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,216 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestRRsetCustomValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &RRsetCustomValidator{Client: newFakeClient(
+		&dnsv1alpha2.ZonePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "myapp"},
+			Spec: dnsv1alpha2.ZonePolicySpec{
+				AllowedRecordPatterns: []string{"*.myapp.example.org"},
+				AllowedTypes:          []string{"A", "CNAME"},
+				MaxTTL:                ptr.To(uint32(3600)),
+			},
+		},
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "team-b"},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    "www.myapp",
+				Type:    "A",
+				TTL:     300,
+				Records: []string{"1.1.1.1"},
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"},
+			},
+		},
+		&dnsv1alpha2.ClusterRRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "api"},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    "api.myapp.example.org.",
+				Type:    "A",
+				TTL:     300,
+				Records: []string{"1.1.1.1"},
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"},
+			},
+		},
+	)}
+	rrset := func(name, rrType string, ttl uint32) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    name,
+				Type:    rrType,
+				TTL:     ttl,
+				Records: []string{"1.1.1.1"},
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"},
+			},
+		}
+	}
+
+	var testCases = []struct {
+		description string
+		validate    func() error
+		forbidden   bool
+	}{
+		{
+			"create allowed rrset",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("front.myapp", "A", 300)); return err },
+			false,
+		},
+		{
+			"create rrset with forbidden name",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("front.other", "A", 300)); return err },
+			true,
+		},
+		{
+			"create rrset with forbidden type",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("front.myapp", "TXT", 300)); return err },
+			true,
+		},
+		{
+			"create rrset already declared by a RRset",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("www.myapp", "A", 300)); return err },
+			true,
+		},
+		{
+			"create rrset already declared by a ClusterRRset",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("api.myapp", "A", 300)); return err },
+			true,
+		},
+		{
+			"create rrset with the name of a RRset of another type",
+			func() error { _, err := v.ValidateCreate(ctx, rrset("www.myapp", "CNAME", 300)); return err },
+			false,
+		},
+		{
+			"update rrset with forbidden TTL",
+			func() error {
+				_, err := v.ValidateUpdate(ctx, rrset("front.myapp", "A", 300), rrset("front.myapp", "A", 86400))
+				return err
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.validate()
+			if forbidden := apierrors.IsForbidden(err); forbidden != tc.forbidden {
+				t.Errorf("got forbidden=%v (%v), want %v", forbidden, err, tc.forbidden)
+			}
+		})
+	}
+}
+
+func TestClusterRRsetCustomValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &ClusterRRsetCustomValidator{Client: newFakeClient(
+		&dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "team-a"},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    "www",
+				Type:    "A",
+				TTL:     300,
+				Records: []string{"1.1.1.1"},
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"},
+			},
+		},
+	)}
+	clusterRRset := func(name, rrType string) *dnsv1alpha2.ClusterRRset {
+		return &dnsv1alpha2.ClusterRRset{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Name:    name,
+				Type:    rrType,
+				TTL:     300,
+				Records: []string{"1.1.1.1"},
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"},
+			},
+		}
+	}
+
+	if _, err := v.ValidateCreate(ctx, clusterRRset("www", "AAAA")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// The name is canonical, and the zone is then not appended
+	if _, err := v.ValidateCreate(ctx, clusterRRset("www.example.org.", "A")); !apierrors.IsForbidden(err) {
+		t.Errorf("got %v, want forbidden", err)
+	}
+
+	// LUA records are validated on creation and on update
+	lua := clusterRRset("front", "LUA")
+	lua.Spec.Records = []string{`A "pickrandom({'192.0.2.1', '192.0.2.2})"`}
+	if _, err := v.ValidateCreate(ctx, lua); !apierrors.IsInvalid(err) {
+		t.Errorf("got %v, want invalid", err)
+	}
+	valid := clusterRRset("front", "LUA")
+	valid.Spec.Records = []string{`A "pickrandom({'192.0.2.1', '192.0.2.2'})"`}
+	if _, err := v.ValidateCreate(ctx, valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := v.ValidateUpdate(ctx, valid, lua); !apierrors.IsInvalid(err) {
+		t.Errorf("got %v, want invalid", err)
+	}
+}
+
+func TestValidateLua(t *testing.T) {
+	var testCases = []struct {
+		description string
+		spec        dnsv1alpha2.RRsetSpec
+		want        int
+	}{
+		{
+			"valid LUA record",
+			dnsv1alpha2.RRsetSpec{Type: "LUA", Records: []string{`A "pickrandom({'192.0.2.1', '192.0.2.2'})"`}},
+			0,
+		},
+		{
+			"LUA record with unterminated string",
+			dnsv1alpha2.RRsetSpec{Type: "LUA", Records: []string{`A "pickrandom({'192.0.2.1', '192.0.2.2})"`, `A pickrandom({'192.0.2.1'})`}},
+			2,
+		},
+		{
+			"valid structured LUA record",
+			dnsv1alpha2.RRsetSpec{Type: "LUA", Lua: &dnsv1alpha2.LuaRecord{Type: "A", PickRandom: []string{"192.0.2.1"}}},
+			0,
+		},
+		{
+			"structured LUA record with IPv6 address",
+			dnsv1alpha2.RRsetSpec{Type: "LUA", Lua: &dnsv1alpha2.LuaRecord{Type: "A", PickRandom: []string{"2001:db8::1"}}},
+			1,
+		},
+		{
+			"not a LUA record",
+			dnsv1alpha2.RRsetSpec{Type: "TXT", Records: []string{`"pickrandom({'192.0.2.1})"`}},
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := validateLua(&tc.spec); len(got) != tc.want {
+				t.Errorf("got %d errors (%v), want %d", len(got), got, tc.want)
+			}
+		})
+	}
+}
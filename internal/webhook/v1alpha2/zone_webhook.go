@@ -0,0 +1,157 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/policy"
+)
+
+// log is for logging in this package.
+var zonelog = logf.Log.WithName("zone-resource")
+
+// SetupZoneWebhookWithManager registers the webhook for Zone in the manager.
+func SetupZoneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&dnsv1alpha2.Zone{}).
+		WithValidator(&ZoneCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-zone,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=zones,verbs=create;update;delete,versions=v1alpha2,name=vzone-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// ZoneCustomValidator validates Zones against the ZonePolicies applying to their namespace,
+// denies the creation of a Zone already declared by another Zone/ClusterZone,
+// and denies the deletion of catalogs which still have members
+type ZoneCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ZoneCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Zone.
+func (v *ZoneCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	zone, ok := obj.(*dnsv1alpha2.Zone)
+	if !ok {
+		return nil, fmt.Errorf("expected a Zone object but got %T", obj)
+	}
+	zonelog.V(1).Info("Validation for Zone upon creation", "name", zone.GetName(), "namespace", zone.GetNamespace())
+
+	if err := v.validate(ctx, zone); err != nil {
+		return nil, err
+	}
+	return nil, validateZoneUniqueness(ctx, v.Client, zone.Name, dnsv1alpha2.GroupVersion.WithResource("zones").GroupResource())
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Zone.
+func (v *ZoneCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	zone, ok := newObj.(*dnsv1alpha2.Zone)
+	if !ok {
+		return nil, fmt.Errorf("expected a Zone object for the newObj but got %T", newObj)
+	}
+	oldZone, ok := oldObj.(*dnsv1alpha2.Zone)
+	if !ok {
+		return nil, fmt.Errorf("expected a Zone object for the oldObj but got %T", oldObj)
+	}
+	zonelog.V(1).Info("Validation for Zone upon update", "name", zone.GetName(), "namespace", zone.GetNamespace())
+
+	// Metadata-only updates (finalizers, owner references) must never be blocked,
+	// otherwise a Zone violating a policy created afterwards could not be deleted
+	if equality.Semantic.DeepEqual(oldZone.Spec, zone.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, zone)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Zone.
+func (v *ZoneCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	zone, ok := obj.(*dnsv1alpha2.Zone)
+	if !ok {
+		return nil, fmt.Errorf("expected a Zone object but got %T", obj)
+	}
+	zonelog.V(1).Info("Validation for Zone upon deletion", "name", zone.GetName(), "namespace", zone.GetNamespace())
+
+	return nil, validateCatalogDeletion(ctx, v.Client, zone.Name, zone.Spec.Kind, dnsv1alpha2.GroupVersion.WithResource("zones").GroupResource())
+}
+
+func (v *ZoneCustomValidator) validate(ctx context.Context, zone *dnsv1alpha2.Zone) error {
+	policies, err := policy.ForNamespace(ctx, v.Client, zone.Namespace)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if err := policy.CheckZone(policies, zone.Name); err != nil {
+		return apierrors.NewForbidden(dnsv1alpha2.GroupVersion.WithResource("zones").GroupResource(), zone.Name, err)
+	}
+	return nil
+}
+
+// validateZoneUniqueness denies a Zone/ClusterZone whose DNS name is already declared by another one
+func validateZoneUniqueness(ctx context.Context, cl client.Reader, name string, resource schema.GroupResource) error {
+	existing, err := duplicatedZone(ctx, cl, name)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if existing != "" {
+		return apierrors.NewForbidden(resource, name, fmt.Errorf("the zone is already declared by %s", existing))
+	}
+	return nil
+}
+
+// validateCatalogDeletion denies the deletion of a Zone/ClusterZone catalog which still has members
+func validateCatalogDeletion(ctx context.Context, cl client.Reader, name, kind string, resource schema.GroupResource) error {
+	if kind != string(powerdns.ProducerZoneKind) {
+		return nil
+	}
+	members, err := catalogMembers(ctx, cl, name)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if len(members) > 0 {
+		return apierrors.NewForbidden(resource, name, fmt.Errorf("catalog still has members: %s", strings.Join(members, ", ")))
+	}
+	return nil
+}
+
+// catalogMembers returns the names of the Zones/ClusterZones which are members of the catalog,
+// looked up through the catalog indexes of the reconcilers
+func catalogMembers(ctx context.Context, cl client.Reader, catalog string) ([]string, error) {
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones, client.MatchingFields{"Zone.Catalog": catalog}); err != nil {
+		return nil, err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones, client.MatchingFields{"ClusterZone.Catalog": catalog}); err != nil {
+		return nil, err
+	}
+	var members []string
+	for _, z := range zones.Items {
+		members = append(members, z.Name)
+	}
+	for _, z := range clusterZones.Items {
+		members = append(members, z.Name)
+	}
+	return members, nil
+}
@@ -0,0 +1,210 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// newFakeClient returns a client knowing namespace "team-a" and objs, with the claim and catalog indexes of the reconcilers
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = dnsv1alpha2.AddToScheme(scheme)
+	objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})
+	zoneIndexer := func(obj client.Object) []string { return []string{obj.GetName()} }
+	rrsetIndexer := func(obj client.Object) []string {
+		return []string{dnsv1alpha2.RRsetClaimKey(obj.(dnsv1alpha2.GenericRRset))}
+	}
+	catalogIndexer := func(obj client.Object) []string {
+		if catalog := dnsv1alpha2.ZoneCatalogKey(obj.(dnsv1alpha2.GenericZone)); catalog != "" {
+			return []string{catalog}
+		}
+		return nil
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithIndex(&dnsv1alpha2.Zone{}, "Zone.Entry.Claim", zoneIndexer).
+		WithIndex(&dnsv1alpha2.ClusterZone{}, "ClusterZone.Entry.Claim", zoneIndexer).
+		WithIndex(&dnsv1alpha2.RRset{}, "RRset.Entry.Claim", rrsetIndexer).
+		WithIndex(&dnsv1alpha2.ClusterRRset{}, "ClusterRRset.Entry.Claim", rrsetIndexer).
+		WithIndex(&dnsv1alpha2.Zone{}, "Zone.Catalog", catalogIndexer).
+		WithIndex(&dnsv1alpha2.ClusterZone{}, "ClusterZone.Catalog", catalogIndexer).
+		Build()
+}
+
+func TestZoneCustomValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &ZoneCustomValidator{Client: newFakeClient(
+		&dnsv1alpha2.ZonePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec:       dnsv1alpha2.ZonePolicySpec{AllowedZoneSuffixes: []string{"apps.example.org"}},
+		},
+		&dnsv1alpha2.ClusterZone{
+			ObjectMeta: metav1.ObjectMeta{Name: "member.example.org"},
+			Spec:       dnsv1alpha2.ClusterZoneSpec{ZoneSpec: dnsv1alpha2.ZoneSpec{Kind: "Master", Nameservers: []string{"ns1"}, Catalog: ptr.To("catalog.apps.example.org.")}},
+		},
+		&dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop.apps.example.org", Namespace: "team-b"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}},
+		},
+		&dnsv1alpha2.ClusterZone{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared.apps.example.org"},
+			Spec:       dnsv1alpha2.ClusterZoneSpec{ZoneSpec: dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}}},
+		},
+		&dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: "old.apps.example.org", Namespace: "team-b", DeletionTimestamp: ptr.To(metav1.Now()), Finalizers: []string{"dns.cav.enablers.ob/finalizer"}},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}},
+		},
+	)}
+	zone := func(name string, nameservers ...string) *dnsv1alpha2.Zone {
+		return &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: nameservers},
+		}
+	}
+
+	var testCases = []struct {
+		description string
+		validate    func() error
+		forbidden   bool
+	}{
+		{
+			"create allowed zone",
+			func() error { _, err := v.ValidateCreate(ctx, zone("myapp.apps.example.org", "ns1")); return err },
+			false,
+		},
+		{
+			"create forbidden zone",
+			func() error { _, err := v.ValidateCreate(ctx, zone("example.com", "ns1")); return err },
+			true,
+		},
+		{
+			"create zone already declared by a Zone",
+			func() error { _, err := v.ValidateCreate(ctx, zone("shop.apps.example.org", "ns1")); return err },
+			true,
+		},
+		{
+			"create zone already declared by a ClusterZone",
+			func() error { _, err := v.ValidateCreate(ctx, zone("shared.apps.example.org", "ns1")); return err },
+			true,
+		},
+		{
+			"create zone replacing a Zone being deleted",
+			func() error { _, err := v.ValidateCreate(ctx, zone("old.apps.example.org", "ns1")); return err },
+			false,
+		},
+		{
+			"metadata-only update of forbidden zone",
+			func() error {
+				_, err := v.ValidateUpdate(ctx, zone("example.com", "ns1"), zone("example.com", "ns1"))
+				return err
+			},
+			false,
+		},
+		{
+			"spec update of forbidden zone",
+			func() error {
+				_, err := v.ValidateUpdate(ctx, zone("example.com", "ns1"), zone("example.com", "ns1", "ns2"))
+				return err
+			},
+			true,
+		},
+		{
+			"delete catalog with members",
+			func() error {
+				catalog := zone("catalog.apps.example.org", "ns1")
+				catalog.Spec.Kind = "Producer"
+				_, err := v.ValidateDelete(ctx, catalog)
+				return err
+			},
+			true,
+		},
+		{
+			"delete catalog without members",
+			func() error {
+				catalog := zone("empty.apps.example.org", "ns1")
+				catalog.Spec.Kind = "Producer"
+				_, err := v.ValidateDelete(ctx, catalog)
+				return err
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.validate()
+			if forbidden := apierrors.IsForbidden(err); forbidden != tc.forbidden {
+				t.Errorf("got forbidden=%v (%v), want %v", forbidden, err, tc.forbidden)
+			}
+		})
+	}
+}
+
+func TestClusterZoneCustomValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &ClusterZoneCustomValidator{Client: newFakeClient(
+		&dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "team-a"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}, Catalog: ptr.To("catalog.example.org.")},
+			// A member in failure is still a member of the catalog
+			Status: dnsv1alpha2.ZoneStatus{SyncStatus: ptr.To("Failed"), Conditions: []metav1.Condition{{Type: "Available", Reason: "SynchronizationFailed"}}},
+		},
+		&dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{Name: "example.net", Namespace: "team-a"},
+			Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}, Catalog: ptr.To("empty.example.org.")},
+			// A duplicated zone is not
+			Status: dnsv1alpha2.ZoneStatus{SyncStatus: ptr.To("Failed"), Conditions: []metav1.Condition{{Type: "Available", Reason: dnsv1alpha2.ZoneReasonDuplicated}}},
+		},
+	)}
+	clusterZone := func(name string) *dnsv1alpha2.ClusterZone {
+		return &dnsv1alpha2.ClusterZone{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       dnsv1alpha2.ClusterZoneSpec{ZoneSpec: dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1"}}},
+		}
+	}
+
+	if _, err := v.ValidateCreate(ctx, clusterZone("example.com")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err := v.ValidateCreate(ctx, clusterZone("example.org"))
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("got %v, want forbidden", err)
+	}
+	if want := "the zone is already declared by Zone team-a/example.org"; !strings.Contains(err.Error(), want) {
+		t.Errorf("got %q, want it to contain %q", err.Error(), want)
+	}
+
+	catalog := clusterZone("catalog.example.org")
+	catalog.Spec.Kind = "Producer"
+	if _, err := v.ValidateDelete(ctx, catalog); !apierrors.IsForbidden(err) {
+		t.Errorf("got %v, want forbidden deletion of catalog with members", err)
+	}
+	empty := clusterZone("empty.example.org")
+	empty.Spec.Kind = "Producer"
+	if _, err := v.ValidateDelete(ctx, empty); err != nil {
+		t.Errorf("unexpected error deleting catalog without members: %v", err)
+	}
+}